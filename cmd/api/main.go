@@ -1,6 +1,7 @@
 package main
 
 import (
+	"errors"
 	"log"
 	"net/http"
 	"os"
@@ -10,17 +11,26 @@ import (
 	bcryptadapter "github.com/ivsanmendez/ControlDeContabilidad/internal/adapter/bcrypt"
 	"github.com/ivsanmendez/ControlDeContabilidad/internal/adapter/certsigner"
 	"github.com/ivsanmendez/ControlDeContabilidad/internal/adapter/eventbus"
+	"github.com/ivsanmendez/ControlDeContabilidad/internal/adapter/export"
 	"github.com/ivsanmendez/ControlDeContabilidad/internal/adapter/httpapi"
 	"github.com/ivsanmendez/ControlDeContabilidad/internal/adapter/i18n"
 	jwtadapter "github.com/ivsanmendez/ControlDeContabilidad/internal/adapter/jwt"
 	"github.com/ivsanmendez/ControlDeContabilidad/internal/adapter/postgres"
+	"github.com/ivsanmendez/ControlDeContabilidad/internal/domain/annualreport"
+	"github.com/ivsanmendez/ControlDeContabilidad/internal/domain/budget"
 	"github.com/ivsanmendez/ControlDeContabilidad/internal/domain/category"
 	"github.com/ivsanmendez/ControlDeContabilidad/internal/domain/contribution"
 	"github.com/ivsanmendez/ControlDeContabilidad/internal/domain/contributor"
 	"github.com/ivsanmendez/ControlDeContabilidad/internal/domain/expense"
 	ec "github.com/ivsanmendez/ControlDeContabilidad/internal/domain/expense_category"
+	"github.com/ivsanmendez/ControlDeContabilidad/internal/domain/ledger"
+	"github.com/ivsanmendez/ControlDeContabilidad/internal/domain/period"
+	"github.com/ivsanmendez/ControlDeContabilidad/internal/domain/pettycash"
 	"github.com/ivsanmendez/ControlDeContabilidad/internal/domain/receipt"
 	"github.com/ivsanmendez/ControlDeContabilidad/internal/domain/report"
+	"github.com/ivsanmendez/ControlDeContabilidad/internal/domain/signingcert"
+	"github.com/ivsanmendez/ControlDeContabilidad/internal/domain/supplier"
+	"github.com/ivsanmendez/ControlDeContabilidad/internal/domain/treasury"
 	"github.com/ivsanmendez/ControlDeContabilidad/internal/domain/user"
 )
 
@@ -51,36 +61,85 @@ func main() {
 	categoryRepo := postgres.NewCategoryRepo(db)
 	expCatRepo := postgres.NewExpenseCategoryRepo(db)
 	receiptFolioRepo := postgres.NewReceiptFolioRepo(db)
+	supplierRepo := postgres.NewSupplierRepo(db)
+	pettyCashRepo := postgres.NewPettyCashRepo(db)
+	ledgerRepo := postgres.NewLedgerRepo(db)
+	treasuryRepo := postgres.NewTreasuryRepo(db)
+	periodRepo := postgres.NewPeriodRepo(db)
+	budgetRepo := postgres.NewBudgetRepo(db)
+	annualReportRepo := postgres.NewAnnualReportRepo(db)
+	signingCertRepo := postgres.NewSigningCertificateRepo(db)
 	bus := eventbus.New()
+	contribBus := eventbus.NewContributionBus()
+	treasuryBus := eventbus.NewTreasuryBus()
 	hasher := bcryptadapter.New()
 	jwtIssuer := jwtadapter.NewIssuer(jwtSecret)
-	signer, err := certsigner.New(os.Getenv("SIGN_CERT_PATH"), os.Getenv("SIGN_KEY_PATH"))
+	trust, err := certsigner.LoadTrustStore(os.Getenv("SAT_TRUST_STORE"))
 	if err != nil {
 		log.Fatalf("certsigner: %v", err)
 	}
-	if signer.Available() {
-		log.Println("Receipt signing enabled")
+	if trust == nil {
+		if os.Getenv("SAT_TRUST_STORE_DISABLED") != "true" {
+			log.Fatal("SAT_TRUST_STORE environment variable is required; set SAT_TRUST_STORE_DISABLED=true to sign without the SAT chain check")
+		}
+		log.Println("WARNING: SAT_TRUST_STORE_DISABLED set; signing certificates are not checked against the SAT chain")
+	}
+	signer, err := certsigner.New(os.Getenv("SIGN_CERT_PATH"), os.Getenv("SIGN_KEY_PATH"), trust)
+	switch {
+	case certificateRejected(err):
+		// An expired or untrusted e.firma only disables the community signer;
+		// the API and per-user certificates keep working.
+		log.Printf("WARNING: community signing certificate rejected, signing with it is disabled: %v", err)
+		signer = &certsigner.Signer{}
+	case err != nil:
+		log.Fatalf("certsigner: %v", err)
+	}
+	if id := signer.Identity(); id != nil {
+		log.Printf("Receipt signing enabled for %s (RFC %q), certificate valid until %s", id.Name, id.RFC, id.NotAfter.Format("2006-01-02"))
 	} else {
-		log.Println("Receipt signing disabled (SIGN_CERT_PATH / SIGN_KEY_PATH not set)")
+		log.Println("Community signing certificate not configured (SIGN_CERT_PATH / SIGN_KEY_PATH not set); only per-user certificates can sign")
 	}
 
 	// Domain services
-	expenseSvc := expense.NewService(expenseRepo, bus)
+	periodSvc := period.NewService(periodRepo, auditRepo)
+	expenseSvc := expense.NewService(expenseRepo, bus, periodSvc)
 	authSvc := user.NewService(userRepo, hasher, jwtIssuer, auditRepo)
 	contributorSvc := contributor.NewService(contributorRepo)
-	contribSvc := contribution.NewService(contribRepo)
+	contribSvc := contribution.NewService(contribRepo, contribBus, periodSvc)
 	categorySvc := category.NewService(categoryRepo)
 	expCatSvc := ec.NewService(expCatRepo)
-	receiptSvc := receipt.NewService(receiptFolioRepo)
+	receiptSvc := receipt.NewService(receiptFolioRepo, auditRepo)
 	reportRepo := postgres.NewReportRepo(db)
 	reportSvc := report.NewService(reportRepo)
+	supplierSvc := supplier.NewService(supplierRepo)
+	ledgerSvc := ledger.NewService(ledgerRepo, ledger.StandardAccounts, ledger.Sources{
+		Contributions: contribRepo,
+		Expenses:      expenseRepo,
+		Transfers:     treasuryRepo,
+	})
+	treasurySvc := treasury.NewService(treasuryRepo, treasuryBus)
+	pettyCashSvc := pettycash.NewService(pettyCashRepo, expenseSvc, treasurySvc)
+	budgetSvc := budget.NewService(budgetRepo)
+	annualReportSvc := annualreport.NewService(annualReportRepo, reportSvc)
+	signingCertSvc := signingcert.NewService(signingCertRepo, openSigner(trust), trust, auditRepo)
+
+	// Event subscribers
+	bus.Subscribe(eventbus.SubscriberFunc[expense.Event](ledgerSvc.HandleExpenseEvent))
+	contribBus.Subscribe(eventbus.SubscriberFunc[contribution.Event](ledgerSvc.HandleContributionEvent))
+	treasuryBus.Subscribe(eventbus.SubscriberFunc[treasury.Event](ledgerSvc.HandleTransferEvent))
 
 	// i18n translator
 	tr := i18n.New()
 
+	// Header printed on exported reports
+	community := export.Header{
+		Community: os.Getenv("COMMUNITY_NAME"),
+		Address:   os.Getenv("COMMUNITY_ADDRESS"),
+	}
+
 	// Inbound adapters
 	mux := http.NewServeMux()
-	httpapi.RegisterRoutes(mux, expenseSvc, authSvc, contribSvc, contributorSvc, categorySvc, expCatSvc, receiptSvc, reportSvc, jwtIssuer, signer, tr)
+	httpapi.RegisterRoutes(mux, expenseSvc, authSvc, contribSvc, contributorSvc, categorySvc, expCatSvc, receiptSvc, reportSvc, supplierSvc, pettyCashSvc, ledgerSvc, treasurySvc, periodSvc, budgetSvc, annualReportSvc, signingCertSvc, community, os.Getenv("PUBLIC_BASE_URL"), jwtIssuer, signer, tr)
 
 	// Serve static files (production React build)
 	staticDir := os.Getenv("STATIC_DIR")
@@ -132,3 +191,31 @@ func serveSPA(mux *http.ServeMux, staticDir string) {
 		http.ServeFile(w, r, indexPath)
 	})
 }
+
+// openSigner adapts certsigner to the signing certificate registry.
+// certificateRejected reports whether err is the community certificate
+// failing validation, as opposed to a missing or unreadable file.
+func certificateRejected(err error) bool {
+	for _, target := range []error{
+		signingcert.ErrInvalidCertificate,
+		signingcert.ErrCertificateExpired,
+		signingcert.ErrCertificateNotYetValid,
+		signingcert.ErrCertificateKeyUsage,
+		signingcert.ErrCertificateUntrusted,
+	} {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+func openSigner(trust *signingcert.TrustStore) signingcert.Opener {
+	return func(certDER, encryptedKey []byte) (signingcert.Signer, error) {
+		s, err := certsigner.Open(certDER, encryptedKey, trust)
+		if err != nil {
+			return nil, err
+		}
+		return s, nil
+	}
+}
@@ -0,0 +1,31 @@
+-- +goose Up
+
+-- 1. Supplier (proveedor) catalog
+CREATE TABLE suppliers (
+    id                  BIGSERIAL    PRIMARY KEY,
+    name                VARCHAR(200) NOT NULL,
+    rfc                 VARCHAR(13),
+    contact_name        VARCHAR(200),
+    phone               VARCHAR(30),
+    email               VARCHAR(255),
+    clabe               CHAR(18),
+    default_category_id BIGINT       REFERENCES expense_categories(id) ON DELETE SET NULL,
+    is_active           BOOLEAN      NOT NULL DEFAULT TRUE,
+    user_id             BIGINT       NOT NULL REFERENCES users(id),
+    created_at          TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    updated_at          TIMESTAMPTZ  NOT NULL DEFAULT NOW()
+);
+
+-- RFC is optional, but must be unique when present
+CREATE UNIQUE INDEX uq_suppliers_rfc ON suppliers(rfc) WHERE rfc IS NOT NULL;
+
+-- 2. Optional link from expenses to the supplier that was paid
+ALTER TABLE expenses ADD COLUMN supplier_id BIGINT;
+ALTER TABLE expenses ADD CONSTRAINT fk_expenses_supplier FOREIGN KEY (supplier_id) REFERENCES suppliers(id);
+CREATE INDEX idx_expenses_supplier ON expenses(supplier_id);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_expenses_supplier;
+ALTER TABLE expenses DROP CONSTRAINT IF EXISTS fk_expenses_supplier;
+ALTER TABLE expenses DROP COLUMN IF EXISTS supplier_id;
+DROP TABLE IF EXISTS suppliers;
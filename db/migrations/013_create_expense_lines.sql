@@ -0,0 +1,23 @@
+-- +goose Up
+
+-- 1. Line items: one expense (ticket) may be split across several categories
+CREATE TABLE expense_lines (
+    id          BIGSERIAL      PRIMARY KEY,
+    expense_id  BIGINT         NOT NULL REFERENCES expenses(id) ON DELETE CASCADE,
+    line_no     INT            NOT NULL CHECK (line_no > 0),
+    category_id BIGINT         NOT NULL REFERENCES expense_categories(id),
+    description TEXT           NOT NULL,
+    amount      NUMERIC(12, 2) NOT NULL CHECK (amount > 0),
+
+    UNIQUE (expense_id, line_no)
+);
+
+CREATE INDEX idx_expense_lines_category ON expense_lines(category_id);
+
+-- 2. Backfill: every existing expense becomes a single line mirroring its header
+INSERT INTO expense_lines (expense_id, line_no, category_id, description, amount)
+SELECT id, 1, category_id, description, amount
+FROM expenses;
+
+-- +goose Down
+DROP TABLE IF EXISTS expense_lines;
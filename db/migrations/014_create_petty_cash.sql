@@ -0,0 +1,60 @@
+-- +goose Up
+
+-- 1. Petty cash (caja chica) funds: a fixed float held by a custodian
+CREATE TABLE petty_cash_funds (
+    id                BIGSERIAL      PRIMARY KEY,
+    name              VARCHAR(100)   NOT NULL UNIQUE,
+    custodian_user_id BIGINT         NOT NULL REFERENCES users(id),
+    float_amount      NUMERIC(12, 2) NOT NULL CHECK (float_amount > 0),
+    is_active         BOOLEAN        NOT NULL DEFAULT TRUE,
+    user_id           BIGINT         NOT NULL REFERENCES users(id),
+    created_at        TIMESTAMPTZ    NOT NULL DEFAULT NOW(),
+    updated_at        TIMESTAMPTZ    NOT NULL DEFAULT NOW()
+);
+
+-- 2. Replenishments bring the fund back up to its float
+CREATE TABLE petty_cash_replenishments (
+    id           BIGSERIAL      PRIMARY KEY,
+    fund_id      BIGINT         NOT NULL REFERENCES petty_cash_funds(id),
+    amount       NUMERIC(12, 2) NOT NULL CHECK (amount > 0),
+    charge_count INT            NOT NULL,
+    status       VARCHAR(20)    NOT NULL CHECK (status IN ('requested', 'completed')),
+    requested_by BIGINT         NOT NULL REFERENCES users(id),
+    requested_at TIMESTAMPTZ    NOT NULL DEFAULT NOW(),
+    completed_by BIGINT         REFERENCES users(id),
+    completed_at TIMESTAMPTZ
+);
+
+CREATE INDEX idx_petty_cash_replenishments_fund ON petty_cash_replenishments(fund_id);
+
+-- 3. Charges link regular expenses to the fund they were paid from
+CREATE TABLE petty_cash_charges (
+    id               BIGSERIAL   PRIMARY KEY,
+    fund_id          BIGINT      NOT NULL REFERENCES petty_cash_funds(id),
+    expense_id       BIGINT      NOT NULL UNIQUE REFERENCES expenses(id) ON DELETE CASCADE,
+    replenishment_id BIGINT      REFERENCES petty_cash_replenishments(id),
+    user_id          BIGINT      NOT NULL REFERENCES users(id),
+    created_at       TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_petty_cash_charges_fund ON petty_cash_charges(fund_id, replenishment_id);
+
+-- 4. Cash counts (arqueos) against the book balance
+CREATE TABLE petty_cash_reconciliations (
+    id             BIGSERIAL      PRIMARY KEY,
+    fund_id        BIGINT         NOT NULL REFERENCES petty_cash_funds(id),
+    counted_amount NUMERIC(12, 2) NOT NULL CHECK (counted_amount >= 0),
+    book_balance   NUMERIC(12, 2) NOT NULL,
+    difference     NUMERIC(12, 2) NOT NULL,
+    notes          TEXT,
+    user_id        BIGINT         NOT NULL REFERENCES users(id),
+    counted_at     TIMESTAMPTZ    NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_petty_cash_reconciliations_fund ON petty_cash_reconciliations(fund_id);
+
+-- +goose Down
+DROP TABLE IF EXISTS petty_cash_reconciliations;
+DROP TABLE IF EXISTS petty_cash_charges;
+DROP TABLE IF EXISTS petty_cash_replenishments;
+DROP TABLE IF EXISTS petty_cash_funds;
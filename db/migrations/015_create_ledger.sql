@@ -0,0 +1,79 @@
+-- +goose Up
+
+-- 1. Chart of accounts
+CREATE TABLE ledger_accounts (
+    id         BIGSERIAL    PRIMARY KEY,
+    code       VARCHAR(20)  NOT NULL UNIQUE,
+    name       VARCHAR(200) NOT NULL,
+    type       VARCHAR(20)  NOT NULL CHECK (type IN ('asset', 'liability', 'equity', 'income', 'expense')),
+    is_active  BOOLEAN      NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ  NOT NULL DEFAULT NOW()
+);
+
+INSERT INTO ledger_accounts (code, name, type) VALUES
+    ('1100', 'Caja y bancos',                      'asset'),
+    ('1200', 'Cuotas por cobrar',                  'asset'),
+    ('2100', 'Cuentas por pagar',                  'liability'),
+    ('3100', 'Patrimonio',                         'equity'),
+    ('3200', 'Resultado de ejercicios anteriores', 'equity'),
+    ('4100', 'Ingresos por cuotas',                'income'),
+    ('5100', 'Gastos de operación',                'expense');
+
+-- 2. Journal entries (pólizas); generated entries point back to their source document
+CREATE TABLE journal_entries (
+    id          BIGSERIAL   PRIMARY KEY,
+    date        DATE        NOT NULL,
+    description TEXT        NOT NULL,
+    source      VARCHAR(20) NOT NULL CHECK (source IN ('manual', 'contribution', 'expense')),
+    source_id   BIGINT,
+    user_id     BIGINT      NOT NULL REFERENCES users(id),
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CHECK ((source = 'manual') = (source_id IS NULL))
+);
+
+CREATE UNIQUE INDEX idx_journal_entries_source ON journal_entries(source, source_id) WHERE source_id IS NOT NULL;
+CREATE INDEX idx_journal_entries_date ON journal_entries(date);
+
+-- 3. Postings: one-sided debit or credit lines
+CREATE TABLE journal_postings (
+    id         BIGSERIAL      PRIMARY KEY,
+    entry_id   BIGINT         NOT NULL REFERENCES journal_entries(id) ON DELETE CASCADE,
+    account_id BIGINT         NOT NULL REFERENCES ledger_accounts(id),
+    debit      NUMERIC(12, 2) NOT NULL DEFAULT 0 CHECK (debit >= 0),
+    credit     NUMERIC(12, 2) NOT NULL DEFAULT 0 CHECK (credit >= 0),
+    memo       TEXT           NOT NULL DEFAULT '',
+
+    CHECK ((debit > 0) <> (credit > 0))
+);
+
+CREATE INDEX idx_journal_postings_entry ON journal_postings(entry_id);
+CREATE INDEX idx_journal_postings_account ON journal_postings(account_id);
+
+-- 4. Backfill: one entry per existing contribution and expense
+INSERT INTO journal_entries (date, description, source, source_id, user_id)
+SELECT payment_date, 'Contribution #' || id || ' (' || LPAD(month::TEXT, 2, '0') || '/' || year || ')', 'contribution', id, user_id
+FROM contributions;
+
+INSERT INTO journal_entries (date, description, source, source_id, user_id)
+SELECT date, 'Expense #' || id || ': ' || description, 'expense', id, user_id
+FROM expenses;
+
+INSERT INTO journal_postings (entry_id, account_id, debit, credit)
+SELECT je.id, (SELECT id FROM ledger_accounts WHERE code = '1100'), c.amount, 0
+FROM journal_entries je JOIN contributions c ON je.source = 'contribution' AND je.source_id = c.id
+UNION ALL
+SELECT je.id, (SELECT id FROM ledger_accounts WHERE code = '4100'), 0, c.amount
+FROM journal_entries je JOIN contributions c ON je.source = 'contribution' AND je.source_id = c.id
+UNION ALL
+SELECT je.id, (SELECT id FROM ledger_accounts WHERE code = '5100'), e.amount, 0
+FROM journal_entries je JOIN expenses e ON je.source = 'expense' AND je.source_id = e.id
+UNION ALL
+SELECT je.id, (SELECT id FROM ledger_accounts WHERE code = '1100'), 0, e.amount
+FROM journal_entries je JOIN expenses e ON je.source = 'expense' AND je.source_id = e.id;
+
+-- +goose Down
+DROP TABLE IF EXISTS journal_postings;
+DROP TABLE IF EXISTS journal_entries;
+DROP TABLE IF EXISTS ledger_accounts;
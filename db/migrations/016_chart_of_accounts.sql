@@ -0,0 +1,54 @@
+-- +goose Up
+
+-- 1. Hierarchy, nature and SAT código agrupador on ledger accounts
+ALTER TABLE ledger_accounts ADD COLUMN parent_id BIGINT REFERENCES ledger_accounts(id);
+ALTER TABLE ledger_accounts ADD COLUMN nature VARCHAR(10);
+ALTER TABLE ledger_accounts ADD COLUMN sat_code VARCHAR(10) NOT NULL DEFAULT '';
+
+UPDATE ledger_accounts
+SET nature = CASE WHEN type IN ('asset', 'expense') THEN 'debit' ELSE 'credit' END;
+
+ALTER TABLE ledger_accounts ALTER COLUMN nature SET NOT NULL;
+ALTER TABLE ledger_accounts ADD CONSTRAINT ledger_accounts_nature_check CHECK (nature IN ('debit', 'credit'));
+
+CREATE INDEX idx_ledger_accounts_parent ON ledger_accounts(parent_id);
+
+-- 2. Top-level grouping accounts
+INSERT INTO ledger_accounts (code, name, type, nature, sat_code) VALUES
+    ('1000', 'Activo',          'asset',     'debit',  '100'),
+    ('2000', 'Pasivo',          'liability', 'credit', '200'),
+    ('3000', 'Capital contable', 'equity',   'credit', '300'),
+    ('4000', 'Ingresos',        'income',    'credit', '400'),
+    ('5000', 'Gastos',          'expense',   'debit',  '600')
+ON CONFLICT (code) DO NOTHING;
+
+-- 3. Hang the seeded accounts under their group and map them to the SAT catalog
+UPDATE ledger_accounts a
+SET parent_id = g.id, sat_code = m.sat_code
+FROM (VALUES
+    ('1100', '1000', '101.01'),
+    ('1200', '1000', '105.01'),
+    ('2100', '2000', '201.01'),
+    ('3100', '3000', '302.01'),
+    ('3200', '3000', '304.01'),
+    ('4100', '4000', '401.01'),
+    ('5100', '5000', '601.84')
+) AS m(code, parent_code, sat_code)
+JOIN ledger_accounts g ON g.code = m.parent_code
+WHERE a.code = m.code AND a.parent_id IS NULL;
+
+-- 4. Default ledger account per contribution and expense category
+ALTER TABLE contribution_categories
+    ADD COLUMN ledger_account_id BIGINT REFERENCES ledger_accounts(id) ON DELETE SET NULL;
+ALTER TABLE expense_categories
+    ADD COLUMN ledger_account_id BIGINT REFERENCES ledger_accounts(id) ON DELETE SET NULL;
+
+-- +goose Down
+ALTER TABLE expense_categories DROP COLUMN IF EXISTS ledger_account_id;
+ALTER TABLE contribution_categories DROP COLUMN IF EXISTS ledger_account_id;
+UPDATE ledger_accounts SET parent_id = NULL;
+DELETE FROM ledger_accounts WHERE code IN ('1000', '2000', '3000', '4000', '5000');
+DROP INDEX IF EXISTS idx_ledger_accounts_parent;
+ALTER TABLE ledger_accounts DROP COLUMN IF EXISTS sat_code;
+ALTER TABLE ledger_accounts DROP COLUMN IF EXISTS nature;
+ALTER TABLE ledger_accounts DROP COLUMN IF EXISTS parent_id;
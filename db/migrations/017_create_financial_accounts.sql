@@ -0,0 +1,67 @@
+-- +goose Up
+
+-- 1. Financial accounts: where the money is physically held (cash box, banks)
+CREATE TABLE financial_accounts (
+    id                BIGSERIAL      PRIMARY KEY,
+    name              VARCHAR(100)   NOT NULL UNIQUE,
+    kind              VARCHAR(20)    NOT NULL CHECK (kind IN ('cash', 'checking', 'savings', 'investment')),
+    bank_name         VARCHAR(100)   NOT NULL DEFAULT '',
+    account_number    VARCHAR(50)    NOT NULL DEFAULT '',
+    opening_balance   NUMERIC(12, 2) NOT NULL DEFAULT 0 CHECK (opening_balance >= 0),
+    ledger_account_id BIGINT         REFERENCES ledger_accounts(id) ON DELETE SET NULL,
+    is_default        BOOLEAN        NOT NULL DEFAULT FALSE,
+    is_active         BOOLEAN        NOT NULL DEFAULT TRUE,
+    user_id           BIGINT         REFERENCES users(id),
+    created_at        TIMESTAMPTZ    NOT NULL DEFAULT NOW(),
+    updated_at        TIMESTAMPTZ    NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX idx_financial_accounts_default ON financial_accounts(is_default) WHERE is_default;
+
+-- The default account takes every existing movement; its postings keep using 1100
+INSERT INTO financial_accounts (name, kind, is_default) VALUES ('Caja general', 'cash', TRUE);
+
+-- 2. Assign contributions and expenses to an account
+ALTER TABLE contributions ADD COLUMN financial_account_id BIGINT REFERENCES financial_accounts(id);
+ALTER TABLE expenses ADD COLUMN financial_account_id BIGINT REFERENCES financial_accounts(id);
+
+UPDATE contributions SET financial_account_id = (SELECT id FROM financial_accounts WHERE is_default);
+UPDATE expenses SET financial_account_id = (SELECT id FROM financial_accounts WHERE is_default);
+
+ALTER TABLE contributions ALTER COLUMN financial_account_id SET NOT NULL;
+ALTER TABLE expenses ALTER COLUMN financial_account_id SET NOT NULL;
+
+CREATE INDEX idx_contributions_financial_account ON contributions(financial_account_id);
+CREATE INDEX idx_expenses_financial_account ON expenses(financial_account_id);
+
+-- 3. Transfers between accounts (e.g. depositing collected cash)
+CREATE TABLE transfers (
+    id              BIGSERIAL      PRIMARY KEY,
+    from_account_id BIGINT         NOT NULL REFERENCES financial_accounts(id),
+    to_account_id   BIGINT         NOT NULL REFERENCES financial_accounts(id),
+    amount          NUMERIC(12, 2) NOT NULL CHECK (amount > 0),
+    date            DATE           NOT NULL,
+    description     TEXT           NOT NULL DEFAULT '',
+    user_id         BIGINT         NOT NULL REFERENCES users(id),
+    created_at      TIMESTAMPTZ    NOT NULL DEFAULT NOW(),
+
+    CHECK (from_account_id <> to_account_id)
+);
+
+CREATE INDEX idx_transfers_from ON transfers(from_account_id, date);
+CREATE INDEX idx_transfers_to ON transfers(to_account_id, date);
+
+-- 4. Transfers generate journal entries
+ALTER TABLE journal_entries DROP CONSTRAINT journal_entries_source_check;
+ALTER TABLE journal_entries ADD CONSTRAINT journal_entries_source_check
+    CHECK (source IN ('manual', 'contribution', 'expense', 'transfer'));
+
+-- +goose Down
+DELETE FROM journal_entries WHERE source = 'transfer';
+ALTER TABLE journal_entries DROP CONSTRAINT journal_entries_source_check;
+ALTER TABLE journal_entries ADD CONSTRAINT journal_entries_source_check
+    CHECK (source IN ('manual', 'contribution', 'expense'));
+DROP TABLE IF EXISTS transfers;
+ALTER TABLE expenses DROP COLUMN IF EXISTS financial_account_id;
+ALTER TABLE contributions DROP COLUMN IF EXISTS financial_account_id;
+DROP TABLE IF EXISTS financial_accounts;
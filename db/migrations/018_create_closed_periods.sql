@@ -0,0 +1,22 @@
+-- +goose Up
+
+-- 1. Closed accounting periods; month 0 closes the whole fiscal year.
+-- Reopened rows are kept as history of who unlocked what and why.
+CREATE TABLE closed_periods (
+    id            BIGSERIAL   PRIMARY KEY,
+    year          INT         NOT NULL CHECK (year >= 2000),
+    month         INT         NOT NULL CHECK (month BETWEEN 0 AND 12),
+    closed_by     BIGINT      NOT NULL REFERENCES users(id),
+    closed_at     TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    reopened_by   BIGINT      REFERENCES users(id),
+    reopened_at   TIMESTAMPTZ,
+    reopen_reason TEXT        NOT NULL DEFAULT '',
+
+    CHECK ((reopened_at IS NULL) = (reopened_by IS NULL))
+);
+
+-- 2. At most one active lock per period
+CREATE UNIQUE INDEX idx_closed_periods_locked ON closed_periods(year, month) WHERE reopened_at IS NULL;
+
+-- +goose Down
+DROP TABLE IF EXISTS closed_periods;
@@ -0,0 +1,51 @@
+-- +goose Up
+
+-- 1. Annual budget per category. Income lines point at a contribution
+-- category and expense lines at an expense category, never both.
+CREATE TABLE budget_lines (
+    id                       BIGSERIAL     PRIMARY KEY,
+    year                     INT           NOT NULL CHECK (year >= 2000),
+    kind                     VARCHAR(10)   NOT NULL CHECK (kind IN ('income', 'expense')),
+    contribution_category_id BIGINT        REFERENCES contribution_categories(id),
+    expense_category_id      BIGINT        REFERENCES expense_categories(id),
+    amount                   NUMERIC(12,2) NOT NULL CHECK (amount >= 0),
+    user_id                  BIGINT        NOT NULL REFERENCES users(id),
+    updated_at               TIMESTAMPTZ   NOT NULL DEFAULT NOW(),
+
+    CHECK ((kind = 'income') = (contribution_category_id IS NOT NULL)),
+    CHECK ((kind = 'expense') = (expense_category_id IS NOT NULL))
+);
+
+-- 2. One line per category and year
+CREATE UNIQUE INDEX idx_budget_lines_income ON budget_lines(year, contribution_category_id) WHERE kind = 'income';
+CREATE UNIQUE INDEX idx_budget_lines_expense ON budget_lines(year, expense_category_id) WHERE kind = 'expense';
+
+-- 3. Per-year atomic sequence counter for annual report folios
+CREATE TABLE annual_report_counters (
+    year INT PRIMARY KEY,
+    last_seq INT NOT NULL DEFAULT 0
+);
+
+-- 4. Every signed annual treasury report. canonical_json is the exact signed
+-- content, so the PDF can be re-rendered and the signature checked later.
+CREATE TABLE annual_reports (
+    id             BIGSERIAL    PRIMARY KEY,
+    folio          VARCHAR(30)  NOT NULL UNIQUE,
+    year_issued    INT          NOT NULL,
+    seq_number     INT          NOT NULL,
+    uuid_suffix    VARCHAR(8)   NOT NULL,
+    report_year    INT          NOT NULL CHECK (report_year >= 2000),
+    signer_name    VARCHAR(200) NOT NULL,
+    user_id        BIGINT       NOT NULL REFERENCES users(id),
+    canonical_json BYTEA        NOT NULL,
+    signature      BYTEA        NOT NULL,
+    certificate    BYTEA        NOT NULL,
+    signed_at      TIMESTAMPTZ  NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_annual_reports_report_year ON annual_reports(report_year);
+
+-- +goose Down
+DROP TABLE IF EXISTS annual_reports;
+DROP TABLE IF EXISTS annual_report_counters;
+DROP TABLE IF EXISTS budget_lines;
@@ -0,0 +1,38 @@
+-- +goose Up
+
+-- 1. Receipts may cover a single contribution, a month range or one category
+-- of the receipt year instead of the whole year. Existing receipts are yearly.
+ALTER TABLE receipt_folios
+    ADD COLUMN scope_kind VARCHAR(16) NOT NULL DEFAULT 'year'
+        CHECK (scope_kind IN ('year', 'contribution', 'months', 'category')),
+    ADD COLUMN scope_contribution_id BIGINT,
+    ADD COLUMN scope_from_month INT CHECK (scope_from_month BETWEEN 1 AND 12),
+    ADD COLUMN scope_to_month INT CHECK (scope_to_month BETWEEN 1 AND 12),
+    ADD COLUMN scope_category_id BIGINT REFERENCES contribution_categories(id);
+
+-- 2. Each kind carries exactly the fields it needs. The contribution is kept
+-- as a plain id: the signed receipt outlives a deleted payment.
+ALTER TABLE receipt_folios ADD CONSTRAINT chk_receipt_folios_scope CHECK (
+    (scope_kind = 'year'
+        AND scope_contribution_id IS NULL AND scope_from_month IS NULL AND scope_to_month IS NULL AND scope_category_id IS NULL)
+    OR (scope_kind = 'contribution'
+        AND scope_contribution_id IS NOT NULL AND scope_from_month IS NULL AND scope_to_month IS NULL AND scope_category_id IS NULL)
+    OR (scope_kind = 'months'
+        AND scope_contribution_id IS NULL AND scope_from_month IS NOT NULL AND scope_to_month IS NOT NULL
+        AND scope_from_month <= scope_to_month AND scope_category_id IS NULL)
+    OR (scope_kind = 'category'
+        AND scope_contribution_id IS NULL AND scope_from_month IS NULL AND scope_to_month IS NULL AND scope_category_id IS NOT NULL)
+);
+
+CREATE INDEX idx_receipt_folios_scope_contribution ON receipt_folios (scope_contribution_id)
+    WHERE scope_contribution_id IS NOT NULL;
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_receipt_folios_scope_contribution;
+ALTER TABLE receipt_folios
+    DROP CONSTRAINT IF EXISTS chk_receipt_folios_scope,
+    DROP COLUMN IF EXISTS scope_category_id,
+    DROP COLUMN IF EXISTS scope_to_month,
+    DROP COLUMN IF EXISTS scope_from_month,
+    DROP COLUMN IF EXISTS scope_contribution_id,
+    DROP COLUMN IF EXISTS scope_kind;
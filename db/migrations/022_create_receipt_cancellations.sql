@@ -0,0 +1,22 @@
+-- +goose Up
+
+-- 1. A cancelled receipt keeps its row in receipt_folios; the cancellation is
+-- a separate signed record, at most one per receipt
+CREATE TABLE receipt_cancellations (
+    id                   BIGSERIAL    PRIMARY KEY,
+    receipt_folio_id     BIGINT       NOT NULL UNIQUE REFERENCES receipt_folios(id),
+    reason               TEXT         NOT NULL CHECK (reason <> ''),
+    user_id              BIGINT       NOT NULL REFERENCES users(id),
+    replacement_folio_id BIGINT       REFERENCES receipt_folios(id),
+    canonical_json       BYTEA        NOT NULL,
+    signature            BYTEA        NOT NULL,
+    certificate          BYTEA        NOT NULL,
+    cancelled_at         TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    CHECK (replacement_folio_id IS DISTINCT FROM receipt_folio_id)
+);
+
+CREATE INDEX idx_receipt_cancellations_replacement ON receipt_cancellations (replacement_folio_id)
+    WHERE replacement_folio_id IS NOT NULL;
+
+-- +goose Down
+DROP TABLE IF EXISTS receipt_cancellations;
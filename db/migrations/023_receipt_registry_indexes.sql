@@ -0,0 +1,11 @@
+-- +goose Up
+
+-- Receipt registry search: newest first, by signing date, receipt year or issuing user
+CREATE INDEX idx_receipt_folios_signed_at ON receipt_folios (signed_at DESC, id DESC);
+CREATE INDEX idx_receipt_folios_receipt_year ON receipt_folios (receipt_year);
+CREATE INDEX idx_receipt_folios_user ON receipt_folios (user_id);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_receipt_folios_user;
+DROP INDEX IF EXISTS idx_receipt_folios_receipt_year;
+DROP INDEX IF EXISTS idx_receipt_folios_signed_at;
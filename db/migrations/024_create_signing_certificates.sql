@@ -0,0 +1,30 @@
+-- +goose Up
+
+-- 1. Per-user e.firma certificates. The private key is stored exactly as
+-- uploaded (encrypted PKCS#8); only the holder's password decrypts it.
+-- Rows are deactivated, never deleted. Receipts embed their own copy of the
+-- certificate, so nothing references this table.
+CREATE TABLE signing_certificates (
+    id              BIGSERIAL    PRIMARY KEY,
+    user_id         BIGINT       NOT NULL REFERENCES users(id),
+    label           TEXT         NOT NULL DEFAULT '',
+    serial          TEXT         NOT NULL,
+    holder          TEXT         NOT NULL DEFAULT '',
+    certificate     BYTEA        NOT NULL,
+    encrypted_key   BYTEA        NOT NULL,
+    not_before      TIMESTAMPTZ  NOT NULL,
+    not_after       TIMESTAMPTZ  NOT NULL,
+    uploaded_by     BIGINT       NOT NULL REFERENCES users(id),
+    uploaded_at     TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    deactivated_by  BIGINT       REFERENCES users(id),
+    deactivated_at  TIMESTAMPTZ,
+
+    CHECK ((deactivated_at IS NULL) = (deactivated_by IS NULL))
+);
+
+-- 2. A certificate is active at most once per user
+CREATE UNIQUE INDEX idx_signing_certificates_active ON signing_certificates(user_id, serial)
+    WHERE deactivated_at IS NULL;
+
+-- +goose Down
+DROP TABLE IF EXISTS signing_certificates;
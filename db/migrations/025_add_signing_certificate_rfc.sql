@@ -0,0 +1,8 @@
+-- +goose Up
+
+-- 1. Holder RFC taken from the certificate subject (x500UniqueIdentifier);
+-- empty for certificates that carry none
+ALTER TABLE signing_certificates ADD COLUMN rfc TEXT NOT NULL DEFAULT '';
+
+-- +goose Down
+ALTER TABLE signing_certificates DROP COLUMN IF EXISTS rfc;
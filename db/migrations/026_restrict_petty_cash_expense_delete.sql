@@ -0,0 +1,12 @@
+-- +goose Up
+
+-- 1. An expense paid from petty cash cannot be deleted while its charge
+-- exists: cascading would drop the charge even after a replenishment claimed it
+ALTER TABLE petty_cash_charges DROP CONSTRAINT petty_cash_charges_expense_id_fkey;
+ALTER TABLE petty_cash_charges ADD CONSTRAINT petty_cash_charges_expense_id_fkey
+    FOREIGN KEY (expense_id) REFERENCES expenses(id) ON DELETE RESTRICT;
+
+-- +goose Down
+ALTER TABLE petty_cash_charges DROP CONSTRAINT petty_cash_charges_expense_id_fkey;
+ALTER TABLE petty_cash_charges ADD CONSTRAINT petty_cash_charges_expense_id_fkey
+    FOREIGN KEY (expense_id) REFERENCES expenses(id) ON DELETE CASCADE;
@@ -0,0 +1,13 @@
+-- +goose Up
+
+-- 1. Each fund is held in a financial account (its cash box); charges are
+-- paid out of it. Existing funds keep the default account they were charged
+-- against until now
+ALTER TABLE petty_cash_funds ADD COLUMN financial_account_id BIGINT REFERENCES financial_accounts(id);
+
+UPDATE petty_cash_funds SET financial_account_id = (SELECT id FROM financial_accounts WHERE is_default);
+
+ALTER TABLE petty_cash_funds ALTER COLUMN financial_account_id SET NOT NULL;
+
+-- +goose Down
+ALTER TABLE petty_cash_funds DROP COLUMN IF EXISTS financial_account_id;
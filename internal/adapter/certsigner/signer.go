@@ -8,22 +8,36 @@ import (
 	"encoding/pem"
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/youmark/pkcs8"
+
+	"github.com/ivsanmendez/ControlDeContabilidad/internal/domain/receipt"
+	"github.com/ivsanmendez/ControlDeContabilidad/internal/domain/signingcert"
 )
 
 // Signer loads a .cer (X.509 certificate) and .key (DER-encoded encrypted PKCS#8
 // private key, as used by Mexican SAT certificates) pair.
 // The private key is decrypted on each Sign call using the provided password.
+// The certificate is validated when loaded and again before every signature,
+// so an e.firma that expires while the server runs stops signing. The key is
+// only readable with the password, so it is matched against the certificate
+// each time it is decrypted.
 type Signer struct {
 	encryptedKey []byte // raw encrypted key bytes (DER or PEM)
 	certDER      []byte // DER-encoded certificate
+	publicKey    crypto.PublicKey
+	trust        *signingcert.TrustStore
+	identity     *signingcert.Identity
 }
 
 // New creates a Signer from the given certificate and key file paths.
 // If both paths are empty, it returns a no-op signer (Available() == false).
 // The private key is NOT decrypted at startup — a password is required per Sign call.
-func New(certPath, keyPath string) (*Signer, error) {
+// A nil trust store skips only the SAT chain check.
+func New(certPath, keyPath string, trust *signingcert.TrustStore) (*Signer, error) {
 	if certPath == "" && keyPath == "" {
 		return &Signer{}, nil
 	}
@@ -41,7 +55,56 @@ func New(certPath, keyPath string) (*Signer, error) {
 		return nil, fmt.Errorf("certsigner: read key file: %w", err)
 	}
 
-	return &Signer{encryptedKey: keyBytes, certDER: certDER}, nil
+	return Open(certDER, keyBytes, trust)
+}
+
+// Open creates a Signer from a DER certificate and its still-encrypted key,
+// as kept by the signing certificate registry.
+func Open(certDER, encryptedKey []byte, trust *signingcert.TrustStore) (*Signer, error) {
+	id, err := signingcert.Validate(certDER, trust, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("certsigner: %w", err)
+	}
+	cert, err := x509.ParseCertificate(certDER)
+	if err != nil {
+		return nil, fmt.Errorf("certsigner: %w", err)
+	}
+	return &Signer{encryptedKey: encryptedKey, certDER: certDER, publicKey: cert.PublicKey, trust: trust, identity: id}, nil
+}
+
+// LoadTrustStore reads every .cer, .crt and .pem file in dir as a SAT root
+// or intermediate certificate. An empty dir returns a nil store.
+func LoadTrustStore(dir string) (*signingcert.TrustStore, error) {
+	if dir == "" {
+		return nil, nil
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("certsigner: read trust store: %w", err)
+	}
+
+	var certs [][]byte
+	for _, e := range entries {
+		switch strings.ToLower(filepath.Ext(e.Name())) {
+		case ".cer", ".crt", ".pem":
+		default:
+			continue
+		}
+		raw, err := os.ReadFile(filepath.Join(dir, e.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("certsigner: read trust store: %w", err)
+		}
+		certs = append(certs, raw)
+	}
+	if len(certs) == 0 {
+		return nil, fmt.Errorf("certsigner: trust store %s holds no certificates", dir)
+	}
+
+	store, err := signingcert.NewTrustStore(certs...)
+	if err != nil {
+		return nil, fmt.Errorf("certsigner: %w", err)
+	}
+	return store, nil
 }
 
 // Sign decrypts the private key with password, then computes SHA-256 hash of data
@@ -50,16 +113,70 @@ func (s *Signer) Sign(data []byte, password string) ([]byte, error) {
 	if len(s.encryptedKey) == 0 {
 		return nil, fmt.Errorf("certsigner: signer not configured")
 	}
+	if err := s.validate(); err != nil {
+		return nil, err
+	}
 
-	key, err := decryptPrivateKey(s.encryptedKey, password)
+	key, err := s.decrypt(password)
 	if err != nil {
-		return nil, fmt.Errorf("certsigner: decrypt key: %w", err)
+		return nil, err
 	}
 
 	hash := sha256.Sum256(data)
 	return rsa.SignPKCS1v15(nil, key, crypto.SHA256, hash[:])
 }
 
+// Unlock decrypts the private key once and returns a function that signs with
+// it, so a batch of documents needs a single password entry and decryption.
+func (s *Signer) Unlock(password string) (func(data []byte) ([]byte, error), error) {
+	if len(s.encryptedKey) == 0 {
+		return nil, fmt.Errorf("certsigner: signer not configured")
+	}
+	if err := s.validate(); err != nil {
+		return nil, err
+	}
+
+	key, err := s.decrypt(password)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(data []byte) ([]byte, error) {
+		if err := s.validate(); err != nil {
+			return nil, err
+		}
+		hash := sha256.Sum256(data)
+		return rsa.SignPKCS1v15(nil, key, crypto.SHA256, hash[:])
+	}, nil
+}
+
+// decrypt reads the private key with password and checks that it is the
+// certificate's key, so a mismatched upload never produces signatures that
+// fail verification.
+func (s *Signer) decrypt(password string) (*rsa.PrivateKey, error) {
+	key, err := decryptPrivateKey(s.encryptedKey, password)
+	if err != nil {
+		return nil, fmt.Errorf("certsigner: decrypt key: %w", err)
+	}
+	if !key.PublicKey.Equal(s.publicKey) {
+		return nil, fmt.Errorf("certsigner: %w", signingcert.ErrKeyMismatch)
+	}
+	return key, nil
+}
+
+// validate re-checks the certificate at the moment of signing.
+func (s *Signer) validate() error {
+	if _, err := signingcert.Validate(s.certDER, s.trust, time.Now()); err != nil {
+		return fmt.Errorf("certsigner: %w", err)
+	}
+	return nil
+}
+
+// Identity returns the holder's RFC and name, or nil when not configured.
+func (s *Signer) Identity() *signingcert.Identity {
+	return s.identity
+}
+
 // Certificate returns the DER-encoded X.509 certificate.
 func (s *Signer) Certificate() []byte {
 	return s.certDER
@@ -92,7 +209,8 @@ func loadCertDER(path string) ([]byte, error) {
 
 // decryptPrivateKey tries to decrypt an encrypted private key using the password.
 // It handles: DER-encoded encrypted PKCS#8 (SAT .key), PEM-wrapped encrypted PKCS#8,
-// and falls back to unencrypted PKCS#8/PKCS#1 (PEM or DER).
+// and falls back to unencrypted PKCS#8/PKCS#1 (PEM or DER). A key that none
+// of them read fails with receipt.ErrInvalidPassword.
 func decryptPrivateKey(raw []byte, password string) (*rsa.PrivateKey, error) {
 	derBytes := raw
 
@@ -127,5 +245,5 @@ func decryptPrivateKey(raw []byte, password string) (*rsa.PrivateKey, error) {
 		return rsaKey, nil
 	}
 
-	return nil, fmt.Errorf("failed to decrypt/parse key (encrypted PKCS#8: %v)", err)
+	return nil, fmt.Errorf("%w: failed to decrypt/parse key (encrypted PKCS#8: %v)", receipt.ErrInvalidPassword, err)
 }
@@ -0,0 +1,74 @@
+package certsigner_test
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/youmark/pkcs8"
+
+	"github.com/ivsanmendez/ControlDeContabilidad/internal/adapter/certsigner"
+	"github.com/ivsanmendez/ControlDeContabilidad/internal/domain/receipt"
+	"github.com/ivsanmendez/ControlDeContabilidad/internal/domain/signingcert"
+)
+
+// efirma returns a self-signed signing certificate and its key encrypted
+// with password, the way SAT hands them out.
+func efirma(t *testing.T, password string) ([]byte, []byte) {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "MARIA GOMEZ LOPEZ"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	encrypted, err := pkcs8.MarshalPrivateKey(key, []byte(password), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return der, encrypted
+}
+
+func TestSigner_KeyMustMatchCertificate(t *testing.T) {
+	cert, key := efirma(t, "secret")
+	_, otherKey := efirma(t, "secret")
+
+	tests := []struct {
+		name     string
+		key      []byte
+		password string
+		want     error
+	}{
+		{"matching key", key, "secret", nil},
+		{"wrong password", key, "nope", receipt.ErrInvalidPassword},
+		{"key of another certificate", otherKey, "secret", signingcert.ErrKeyMismatch},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := certsigner.Open(cert, tt.key, nil)
+			if err != nil {
+				t.Fatalf("open: %v", err)
+			}
+			if _, err := s.Sign([]byte("content"), tt.password); !errors.Is(err, tt.want) {
+				t.Errorf("Sign: expected %v, got %v", tt.want, err)
+			}
+			if _, err := s.Unlock(tt.password); !errors.Is(err, tt.want) {
+				t.Errorf("Unlock: expected %v, got %v", tt.want, err)
+			}
+		})
+	}
+}
@@ -2,37 +2,70 @@ package eventbus
 
 import (
 	"context"
-	"log"
+	"errors"
 	"sync"
 
+	"github.com/ivsanmendez/ControlDeContabilidad/internal/domain/contribution"
 	"github.com/ivsanmendez/ControlDeContabilidad/internal/domain/expense"
-	"github.com/ivsanmendez/ControlDeContabilidad/internal/port"
+	"github.com/ivsanmendez/ControlDeContabilidad/internal/domain/treasury"
 )
 
-// InMemBus is a synchronous in-memory event bus.
-// Implements expense.EventPublisher.
-type InMemBus struct {
-	mu          sync.RWMutex
-	subscribers []port.EventSubscriber
+// Subscriber reacts to events of type E.
+type Subscriber[E any] interface {
+	Handle(ctx context.Context, event E) error
 }
 
-func New() *InMemBus {
-	return &InMemBus{}
+// SubscriberFunc adapts a plain function to a Subscriber.
+type SubscriberFunc[E any] func(ctx context.Context, event E) error
+
+func (f SubscriberFunc[E]) Handle(ctx context.Context, event E) error {
+	return f(ctx, event)
 }
 
-func (b *InMemBus) Subscribe(sub port.EventSubscriber) {
+// Bus is a synchronous in-memory event bus for one event type.
+type Bus[E any] struct {
+	mu          sync.RWMutex
+	subscribers []Subscriber[E]
+}
+
+func (b *Bus[E]) Subscribe(sub Subscriber[E]) {
 	b.mu.Lock()
 	defer b.mu.Unlock()
 	b.subscribers = append(b.subscribers, sub)
 }
 
-func (b *InMemBus) Publish(ctx context.Context, event expense.Event) error {
+// Publish hands event to every subscriber in turn. A failing subscriber does
+// not stop the others; their errors are joined and returned to the caller.
+func (b *Bus[E]) Publish(ctx context.Context, event E) error {
 	b.mu.RLock()
 	defer b.mu.RUnlock()
+	var errs []error
 	for _, sub := range b.subscribers {
 		if err := sub.Handle(ctx, event); err != nil {
-			log.Printf("event subscriber error: %v", err)
+			errs = append(errs, err)
 		}
 	}
-	return nil
-}
\ No newline at end of file
+	return errors.Join(errs...)
+}
+
+// InMemBus is a synchronous in-memory event bus.
+// Implements expense.EventPublisher.
+type InMemBus = Bus[expense.Event]
+
+func New() *InMemBus {
+	return &InMemBus{}
+}
+
+// ContributionBus implements contribution.EventPublisher.
+type ContributionBus = Bus[contribution.Event]
+
+func NewContributionBus() *ContributionBus {
+	return &ContributionBus{}
+}
+
+// TreasuryBus implements treasury.EventPublisher.
+type TreasuryBus = Bus[treasury.Event]
+
+func NewTreasuryBus() *TreasuryBus {
+	return &TreasuryBus{}
+}
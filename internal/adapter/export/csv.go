@@ -0,0 +1,65 @@
+package export
+
+import (
+	"encoding/csv"
+	"io"
+	"strings"
+)
+
+// utf8BOM lets spreadsheet applications detect UTF-8 and show accents correctly.
+const utf8BOM = "\uFEFF"
+
+// escapeFormula keeps spreadsheet applications from evaluating text that
+// starts like a formula, such as an expense description "=HYPERLINK(...)".
+// Only text is escaped: amounts are written by formatCell and stay numeric.
+func escapeFormula(s string) string {
+	if s != "" && strings.ContainsRune("=+-@", rune(s[0])) {
+		return "'" + s
+	}
+	return s
+}
+
+func writeCSV(w io.Writer, d *Document) error {
+	if _, err := io.WriteString(w, utf8BOM); err != nil {
+		return err
+	}
+	cw := csv.NewWriter(w)
+
+	for _, l := range d.headerLines() {
+		cw.Write([]string{escapeFormula(l)})
+	}
+	cw.Write([]string{d.generatedLine()})
+
+	for _, t := range d.Tables {
+		cw.Write(nil)
+		if t.Title != "" {
+			cw.Write([]string{escapeFormula(t.Title)})
+		}
+		labels := make([]string, len(t.Columns))
+		for i, c := range t.Columns {
+			labels[i] = escapeFormula(c.Label)
+		}
+		cw.Write(labels)
+		for _, row := range t.Rows {
+			record := make([]string, len(row.Cells))
+			for i, v := range row.Cells {
+				if text, ok := v.(string); ok {
+					record[i] = escapeFormula(text)
+					continue
+				}
+				record[i] = formatCell(v, false)
+			}
+			cw.Write(record)
+		}
+	}
+
+	if len(d.Notes) > 0 {
+		cw.Write(nil)
+		for _, n := range d.Notes {
+			cw.Write([]string{escapeFormula(n)})
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
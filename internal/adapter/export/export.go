@@ -0,0 +1,172 @@
+// Package export renders report tables as CSV, XLSX workbooks and PDF
+// documents for download.
+package export
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"strings"
+	"time"
+)
+
+var ErrUnsupportedFormat = errors.New("unsupported export format")
+
+// Format is a report output format.
+type Format string
+
+const (
+	FormatJSON Format = "json"
+	FormatCSV  Format = "csv"
+	FormatXLSX Format = "xlsx"
+	FormatPDF  Format = "pdf"
+)
+
+var contentTypes = map[Format]string{
+	FormatJSON: "application/json",
+	FormatCSV:  "text/csv; charset=utf-8",
+	FormatXLSX: "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+	FormatPDF:  "application/pdf",
+}
+
+// ContentType is the MIME type served for the format.
+func (f Format) ContentType() string {
+	return contentTypes[f]
+}
+
+// Negotiate picks the output format. An explicit format parameter wins;
+// otherwise the first Accept media type naming a supported export format is
+// used. Anything else, including an empty or wildcard Accept, is JSON.
+func Negotiate(format, accept string) (Format, error) {
+	if format != "" {
+		f := Format(strings.ToLower(format))
+		if _, ok := contentTypes[f]; !ok {
+			return "", ErrUnsupportedFormat
+		}
+		return f, nil
+	}
+	for _, part := range strings.Split(accept, ",") {
+		mt, _, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		for f, ct := range contentTypes {
+			if base, _, _ := mime.ParseMediaType(ct); base == mt && f != FormatJSON {
+				return f, nil
+			}
+		}
+	}
+	return FormatJSON, nil
+}
+
+// Header identifies the community on every exported document.
+type Header struct {
+	Community string
+	Address   string
+}
+
+// Document is a titled set of tables ready to be rendered. GeneratedLabel
+// and PageLabel are the localized captions for the generation timestamp and
+// the PDF page numbers. Notes are free-text paragraphs printed after the
+// tables, such as a signature block; in PDFs a non-empty QR is encoded as a
+// QR code beside them.
+type Document struct {
+	Header
+	Title          string
+	Subtitle       string
+	GeneratedAt    time.Time
+	GeneratedLabel string
+	PageLabel      string
+	Tables         []Table
+	Notes          []string
+	QR             string
+}
+
+// Column describes a table column. Numeric columns are right-aligned and
+// formatted as amounts.
+type Column struct {
+	Label   string
+	Numeric bool
+}
+
+// Row is one table row. Cells hold strings, ints or float64 amounts; Total
+// rows are emphasized.
+type Row struct {
+	Cells []any
+	Total bool
+}
+
+// Table is a titled grid of rows.
+type Table struct {
+	Title   string
+	Columns []Column
+	Rows    []Row
+}
+
+// Write renders d in format f. JSON is not rendered here: callers encode the
+// report itself.
+func Write(w io.Writer, f Format, d *Document) error {
+	switch f {
+	case FormatCSV:
+		return writeCSV(w, d)
+	case FormatXLSX:
+		return writeXLSX(w, d)
+	case FormatPDF:
+		return writePDF(w, d)
+	}
+	return ErrUnsupportedFormat
+}
+
+// headerLines are the lines printed above the tables in every format.
+func (d *Document) headerLines() []string {
+	var lines []string
+	for _, l := range []string{d.Community, d.Address, d.Title, d.Subtitle} {
+		if l != "" {
+			lines = append(lines, l)
+		}
+	}
+	return lines
+}
+
+func (d *Document) generatedLine() string {
+	return fmt.Sprintf("%s: %s", d.GeneratedLabel, d.GeneratedAt.Format("2006-01-02 15:04 MST"))
+}
+
+// formatCell renders a cell as text. Amounts keep two decimals; grouping is
+// only added for display formats.
+func formatCell(v any, grouped bool) string {
+	switch x := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return x
+	case float64:
+		if grouped {
+			return groupThousands(x)
+		}
+		return fmt.Sprintf("%.2f", x)
+	default:
+		return fmt.Sprint(x)
+	}
+}
+
+func groupThousands(v float64) string {
+	s := fmt.Sprintf("%.2f", v)
+	neg := strings.HasPrefix(s, "-")
+	s = strings.TrimPrefix(s, "-")
+	intPart, frac := s[:len(s)-3], s[len(s)-3:]
+
+	var b strings.Builder
+	for i, r := range intPart {
+		if i > 0 && (len(intPart)-i)%3 == 0 {
+			b.WriteByte(',')
+		}
+		b.WriteRune(r)
+	}
+	out := b.String() + frac
+	if neg {
+		out = "-" + out
+	}
+	return out
+}
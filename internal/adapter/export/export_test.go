@@ -0,0 +1,118 @@
+package export
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNegotiate(t *testing.T) {
+	tests := []struct {
+		name    string
+		format  string
+		accept  string
+		want    Format
+		wantErr error
+	}{
+		{"explicit format", "csv", "", FormatCSV, nil},
+		{"explicit format is case-insensitive", "XLSX", "", FormatXLSX, nil},
+		{"explicit format wins over Accept", "pdf", "text/csv", FormatPDF, nil},
+		{"explicit json", "json", "", FormatJSON, nil},
+		{"unsupported format", "docx", "", "", ErrUnsupportedFormat},
+		{"no format or Accept", "", "", FormatJSON, nil},
+		{"wildcard Accept", "", "*/*", FormatJSON, nil},
+		{"Accept with parameters", "", "text/csv; charset=utf-8", FormatCSV, nil},
+		{"first supported Accept type", "", "text/html, application/pdf, text/csv", FormatPDF, nil},
+		{"unparseable Accept part is skipped", "", ";;, application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", FormatXLSX, nil},
+		{"json Accept", "", "application/json", FormatJSON, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Negotiate(tt.format, tt.accept)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+			if got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestGroupThousands(t *testing.T) {
+	tests := []struct {
+		in   float64
+		want string
+	}{
+		{0, "0.00"},
+		{5.5, "5.50"},
+		{999.99, "999.99"},
+		{1000, "1,000.00"},
+		{123456.789, "123,456.79"},
+		{1234567, "1,234,567.00"},
+		{-1234.5, "-1,234.50"},
+		{-999, "-999.00"},
+	}
+	for _, tt := range tests {
+		if got := groupThousands(tt.in); got != tt.want {
+			t.Errorf("groupThousands(%v) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestWriteCSV_RoundTrip(t *testing.T) {
+	d := &Document{
+		Header:         Header{Community: "Residencial Los Pinos", Address: "Calle 1, Colima"},
+		Title:          "Gastos",
+		GeneratedAt:    time.Date(2026, 10, 19, 9, 30, 0, 0, time.UTC),
+		GeneratedLabel: "Generado",
+		Tables: []Table{{
+			Title:   "Octubre",
+			Columns: []Column{{Label: "Descripción"}, {Label: "Casas"}, {Label: "Monto", Numeric: true}},
+			Rows: []Row{
+				{Cells: []any{"Poda, jardín \"norte\"", 3, 1234.5}},
+				{Cells: []any{"=HYPERLINK(\"http://x\")", nil, -50.0}},
+				{Cells: []any{"@SUM(A1)", 0, 0.0}},
+				{Cells: []any{"Total", nil, 1184.5}, Total: true},
+			},
+		}},
+		Notes: []string{"+52 312 000 0000"},
+	}
+
+	var buf bytes.Buffer
+	if err := writeCSV(&buf, d); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	body, ok := strings.CutPrefix(buf.String(), utf8BOM)
+	if !ok {
+		t.Fatal("expected a UTF-8 byte order mark")
+	}
+
+	r := csv.NewReader(strings.NewReader(body))
+	r.FieldsPerRecord = -1
+	got, err := r.ReadAll()
+	if err != nil {
+		t.Fatalf("read back: %v", err)
+	}
+	// encoding/csv skips the blank separator lines on read.
+	want := [][]string{
+		{"Residencial Los Pinos"},
+		{"Calle 1, Colima"},
+		{"Gastos"},
+		{"Generado: 2026-10-19 09:30 UTC"},
+		{"Octubre"},
+		{"Descripción", "Casas", "Monto"},
+		{"Poda, jardín \"norte\"", "3", "1234.50"},
+		{"'=HYPERLINK(\"http://x\")", "", "-50.00"},
+		{"'@SUM(A1)", "0", "0.00"},
+		{"Total", "", "1184.50"},
+		{"'+52 312 000 0000"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected records:\n got %q\nwant %q", got, want)
+	}
+}
@@ -0,0 +1,170 @@
+package export
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+
+	"github.com/go-pdf/fpdf"
+	"github.com/skip2/go-qrcode"
+)
+
+const (
+	pdfMargin    = 12.0
+	pdfRowHeight = 6.0
+	// pdfLandscapeColumns is the column count from which pages turn landscape.
+	pdfLandscapeColumns = 7
+	// pdfQRSize is the printed side of the QR code in mm.
+	pdfQRSize = 32.0
+)
+
+// writePDF renders a paginated A4 document. The community header is repeated
+// on every page, the column header on every page a table continues on, and
+// the footer carries the generation timestamp and page numbers. The output
+// depends only on d: metadata dates are GeneratedAt and resources are written
+// in a fixed order, so the same document yields the same bytes.
+func writePDF(w io.Writer, d *Document) error {
+	orientation := "P"
+	for _, t := range d.Tables {
+		if len(t.Columns) >= pdfLandscapeColumns {
+			orientation = "L"
+		}
+	}
+
+	pdf := fpdf.New(orientation, "mm", "A4", "")
+	pdf.SetCatalogSort(true)
+	pdf.SetCreationDate(d.GeneratedAt)
+	pdf.SetModificationDate(d.GeneratedAt)
+	pdf.SetMargins(pdfMargin, pdfMargin, pdfMargin)
+	pdf.SetAutoPageBreak(false, pdfMargin)
+	pdf.AliasNbPages("{nb}")
+	// Core fonts are cp1252; translate so accents and ñ print correctly.
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+
+	pdf.SetHeaderFunc(func() {
+		for i, l := range d.headerLines() {
+			if i == 0 {
+				pdf.SetFont("Helvetica", "B", 13)
+			} else {
+				pdf.SetFont("Helvetica", "", 10)
+			}
+			pdf.CellFormat(0, 6, tr(l), "", 1, "C", false, 0, "")
+		}
+		pdf.Ln(3)
+	})
+	pdf.SetFooterFunc(func() {
+		pdf.SetY(-pdfMargin)
+		pdf.SetFont("Helvetica", "I", 8)
+		pdf.CellFormat(0, 5, tr(d.generatedLine()), "", 0, "L", false, 0, "")
+		pdf.CellFormat(0, 5, tr(fmt.Sprintf("%s %d/{nb}", d.PageLabel, pdf.PageNo())), "", 0, "R", false, 0, "")
+	})
+
+	pdf.AddPage()
+	pageW, pageH := pdf.GetPageSize()
+	usable := pageW - 2*pdfMargin
+	bottom := pageH - 2*pdfMargin
+
+	for _, t := range d.Tables {
+		widths := columnWidths(t.Columns, usable)
+		drawHeader := func() {
+			pdf.SetFont("Helvetica", "B", 8)
+			pdf.SetFillColor(48, 84, 150)
+			pdf.SetTextColor(255, 255, 255)
+			for i, c := range t.Columns {
+				pdf.CellFormat(widths[i], pdfRowHeight, tr(c.Label), "1", 0, "C", true, 0, "")
+			}
+			pdf.Ln(-1)
+			pdf.SetTextColor(0, 0, 0)
+		}
+
+		if pdf.GetY()+3*pdfRowHeight > bottom {
+			pdf.AddPage()
+		}
+		if t.Title != "" && t.Title != d.Title {
+			pdf.SetFont("Helvetica", "B", 10)
+			pdf.CellFormat(0, 7, tr(t.Title), "", 1, "L", false, 0, "")
+		}
+		drawHeader()
+
+		for n, r := range t.Rows {
+			if pdf.GetY()+pdfRowHeight > bottom {
+				pdf.AddPage()
+				drawHeader()
+			}
+			style := ""
+			if r.Total {
+				style = "B"
+			}
+			pdf.SetFont("Helvetica", style, 8)
+			fill := n%2 == 1 && !r.Total
+			pdf.SetFillColor(242, 242, 242)
+			for i, v := range r.Cells {
+				if i >= len(widths) {
+					break
+				}
+				align := "L"
+				if t.Columns[i].Numeric {
+					align = "R"
+				}
+				if x, ok := v.(float64); ok && x < 0 {
+					pdf.SetTextColor(192, 0, 0)
+				}
+				pdf.CellFormat(widths[i], pdfRowHeight, tr(formatCell(v, true)), "1", 0, align, fill, 0, "")
+				pdf.SetTextColor(0, 0, 0)
+			}
+			pdf.Ln(-1)
+		}
+		pdf.Ln(4)
+	}
+
+	notesW := usable
+	if d.QR != "" {
+		png, err := qrcode.Encode(d.QR, qrcode.Medium, 256)
+		if err != nil {
+			return fmt.Errorf("encode qr: %w", err)
+		}
+		if pdf.GetY()+pdfQRSize > bottom {
+			pdf.AddPage()
+		}
+		pdf.RegisterImageOptionsReader("qr", fpdf.ImageOptions{ImageType: "PNG"}, bytes.NewReader(png))
+		pdf.ImageOptions("qr", pageW-pdfMargin-pdfQRSize, pdf.GetY(), pdfQRSize, pdfQRSize, false, fpdf.ImageOptions{ImageType: "PNG"}, 0, "")
+		notesW = usable - pdfQRSize - 4
+	}
+	qrBottom := pdf.GetY() + pdfQRSize
+
+	pdf.SetFont("Helvetica", "", 8)
+	for _, n := range d.Notes {
+		lines := pdf.SplitText(n, notesW)
+		if pdf.GetY()+float64(len(lines))*4 > bottom {
+			pdf.AddPage()
+		}
+		pdf.MultiCell(notesW, 4, tr(n), "", "L", false)
+		pdf.Ln(1)
+	}
+	if d.QR != "" && pdf.GetY() < qrBottom {
+		pdf.SetY(qrBottom)
+	}
+
+	return pdf.Output(w)
+}
+
+// columnWidths gives text columns twice the width of numeric ones.
+func columnWidths(cols []Column, usable float64) []float64 {
+	var units float64
+	for _, c := range cols {
+		if c.Numeric {
+			units++
+		} else {
+			units += 2
+		}
+	}
+	widths := make([]float64, len(cols))
+	for i, c := range cols {
+		u := 2.0
+		if c.Numeric {
+			u = 1
+		}
+		widths[i] = usable * u / units
+	}
+	return widths
+}
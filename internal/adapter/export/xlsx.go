@@ -0,0 +1,186 @@
+package export
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// maxSheetName is Excel's limit on worksheet name length.
+const maxSheetName = 31
+
+// writeXLSX renders each table on its own worksheet, under the document header.
+func writeXLSX(w io.Writer, d *Document) error {
+	f := excelize.NewFile()
+	defer f.Close()
+
+	styles, err := newXLSXStyles(f)
+	if err != nil {
+		return err
+	}
+
+	tables := d.Tables
+	if len(tables) == 0 {
+		tables = []Table{{Title: d.Title}}
+	}
+	used := make(map[string]bool)
+	for i, t := range tables {
+		name := sheetName(t.Title, i, used)
+		if i == 0 {
+			if err := f.SetSheetName("Sheet1", name); err != nil {
+				return err
+			}
+		} else if _, err := f.NewSheet(name); err != nil {
+			return err
+		}
+		if err := writeSheet(f, name, d, t, styles, i == len(tables)-1); err != nil {
+			return err
+		}
+	}
+
+	_, err = f.WriteTo(w)
+	return err
+}
+
+type xlsxStyles struct {
+	title, header, money, totalText, totalMoney int
+}
+
+func newXLSXStyles(f *excelize.File) (xlsxStyles, error) {
+	var s xlsxStyles
+	var err error
+	numFmt := "#,##0.00;[Red]-#,##0.00"
+	defs := []struct {
+		dst   *int
+		style *excelize.Style
+	}{
+		{&s.title, &excelize.Style{Font: &excelize.Font{Bold: true, Size: 14}}},
+		{&s.header, &excelize.Style{
+			Font:      &excelize.Font{Bold: true, Color: "FFFFFF"},
+			Fill:      excelize.Fill{Type: "pattern", Pattern: 1, Color: []string{"305496"}},
+			Alignment: &excelize.Alignment{Horizontal: "center", WrapText: true},
+		}},
+		{&s.money, &excelize.Style{CustomNumFmt: &numFmt}},
+		{&s.totalText, &excelize.Style{
+			Font:   &excelize.Font{Bold: true},
+			Border: []excelize.Border{{Type: "top", Color: "000000", Style: 1}},
+		}},
+		{&s.totalMoney, &excelize.Style{
+			Font:         &excelize.Font{Bold: true},
+			Border:       []excelize.Border{{Type: "top", Color: "000000", Style: 1}},
+			CustomNumFmt: &numFmt,
+		}},
+	}
+	for _, def := range defs {
+		if *def.dst, err = f.NewStyle(def.style); err != nil {
+			return s, err
+		}
+	}
+	return s, nil
+}
+
+// writeSheet lays out one table; the document notes go under the last one.
+func writeSheet(f *excelize.File, sheet string, d *Document, t Table, st xlsxStyles, last bool) error {
+	row := 1
+	for i, l := range d.headerLines() {
+		cell, _ := excelize.CoordinatesToCellName(1, row)
+		f.SetCellValue(sheet, cell, l)
+		if i == 0 {
+			f.SetCellStyle(sheet, cell, cell, st.title)
+		}
+		row++
+	}
+	cell, _ := excelize.CoordinatesToCellName(1, row)
+	f.SetCellValue(sheet, cell, d.generatedLine())
+	row += 2
+
+	if t.Title != "" && t.Title != d.Title {
+		cell, _ := excelize.CoordinatesToCellName(1, row)
+		f.SetCellValue(sheet, cell, t.Title)
+		f.SetCellStyle(sheet, cell, cell, st.title)
+		row++
+	}
+
+	headerRow := row
+	for c, col := range t.Columns {
+		cell, _ := excelize.CoordinatesToCellName(c+1, row)
+		f.SetCellValue(sheet, cell, col.Label)
+		f.SetCellStyle(sheet, cell, cell, st.header)
+	}
+	row++
+
+	for _, r := range t.Rows {
+		for c, v := range r.Cells {
+			cell, _ := excelize.CoordinatesToCellName(c+1, row)
+			if err := f.SetCellValue(sheet, cell, v); err != nil {
+				return fmt.Errorf("xlsx cell %s: %w", cell, err)
+			}
+			_, isAmount := v.(float64)
+			switch {
+			case r.Total && isAmount:
+				f.SetCellStyle(sheet, cell, cell, st.totalMoney)
+			case r.Total:
+				f.SetCellStyle(sheet, cell, cell, st.totalText)
+			case isAmount:
+				f.SetCellStyle(sheet, cell, cell, st.money)
+			}
+		}
+		row++
+	}
+	if last {
+		row++
+		for _, n := range d.Notes {
+			cell, _ := excelize.CoordinatesToCellName(1, row)
+			f.SetCellValue(sheet, cell, n)
+			row++
+		}
+	}
+
+	for c, col := range t.Columns {
+		name, _ := excelize.ColumnNumberToName(c + 1)
+		width := 16.0
+		if !col.Numeric {
+			width = 28
+		}
+		f.SetColWidth(sheet, name, name, width)
+	}
+	if len(t.Columns) > 0 {
+		cell, _ := excelize.CoordinatesToCellName(1, headerRow+1)
+		f.SetPanes(sheet, &excelize.Panes{
+			Freeze:      true,
+			YSplit:      headerRow,
+			TopLeftCell: cell,
+			ActivePane:  "bottomLeft",
+		})
+	}
+	return nil
+}
+
+// sheetName derives a unique, valid worksheet name from a table title.
+func sheetName(title string, i int, used map[string]bool) string {
+	name := strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`[]:*?/\`, r) {
+			return '-'
+		}
+		return r
+	}, title)
+	if name == "" {
+		name = fmt.Sprintf("%d", i+1)
+	}
+	if r := []rune(name); len(r) > maxSheetName {
+		name = string(r[:maxSheetName])
+	}
+	base := name
+	for n := 2; used[name]; n++ {
+		suffix := fmt.Sprintf(" (%d)", n)
+		r := []rune(base)
+		if len(r)+len(suffix) > maxSheetName {
+			r = r[:maxSheetName-len(suffix)]
+		}
+		name = string(r) + suffix
+	}
+	used[name] = true
+	return name
+}
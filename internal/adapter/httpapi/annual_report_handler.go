@@ -0,0 +1,186 @@
+package httpapi
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/ivsanmendez/ControlDeContabilidad/internal/adapter/export"
+	"github.com/ivsanmendez/ControlDeContabilidad/internal/adapter/i18n"
+	"github.com/ivsanmendez/ControlDeContabilidad/internal/domain/annualreport"
+	"github.com/ivsanmendez/ControlDeContabilidad/internal/port"
+)
+
+// AnnualReportHandler serves the signed annual treasury reports.
+type AnnualReportHandler struct {
+	svc     port.AnnualReportService
+	certSvc port.SigningCertificateService
+	signer  port.ReceiptSigner
+	tr      *i18n.Translator
+	header  export.Header
+}
+
+type annualReportRequest struct {
+	Year          int    `json:"year"`
+	Password      string `json:"password"`
+	SignerName    string `json:"signer_name"`
+	CertificateID int64  `json:"certificate_id"`
+}
+
+type annualReportSummary struct {
+	Folio      string    `json:"folio"`
+	ReportYear int       `json:"report_year"`
+	SignerName string    `json:"signer_name"`
+	SignedAt   time.Time `json:"signed_at"`
+}
+
+type annualReportResponse struct {
+	Folio       string                `json:"folio"`
+	Data        *annualreport.Content `json:"data"`
+	Signature   string                `json:"signature"`
+	Certificate string                `json:"certificate"`
+}
+
+// Issue handles POST /annual-reports.
+func (h *AnnualReportHandler) Issue(w http.ResponseWriter, r *http.Request) {
+	claims, ok := ClaimsFromContext(r.Context())
+	if !ok {
+		writeErrorT(w, r, h.tr, http.StatusUnauthorized, "no_claims_in_context")
+		return
+	}
+
+	var req annualReportRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeErrorT(w, r, h.tr, http.StatusBadRequest, "invalid_request_body")
+		return
+	}
+
+	signer, err := signerFor(r.Context(), h.certSvc, h.signer, claims.UserID, req.CertificateID)
+	if err != nil {
+		writeCertificateErr(w, r, h.tr, err)
+		return
+	}
+
+	d, err := h.svc.Issue(r.Context(), signer, claims.UserID, req.Year, req.SignerName, req.Password)
+	if err != nil {
+		h.writeErr(w, r, err)
+		return
+	}
+	content, err := d.Content()
+	if err != nil {
+		writeErrorT(w, r, h.tr, http.StatusInternalServerError, "annual_report_query_failed")
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, annualReportResponse{
+		Folio:       d.Folio,
+		Data:        content,
+		Signature:   base64.StdEncoding.EncodeToString(d.Signature),
+		Certificate: base64.StdEncoding.EncodeToString(d.Certificate),
+	})
+}
+
+// List handles GET /annual-reports?year=YYYY.
+func (h *AnnualReportHandler) List(w http.ResponseWriter, r *http.Request) {
+	year, err := strconv.Atoi(r.URL.Query().Get("year"))
+	if err != nil {
+		writeErrorT(w, r, h.tr, http.StatusBadRequest, "invalid_year")
+		return
+	}
+
+	docs, err := h.svc.ListReports(r.Context(), year)
+	if err != nil {
+		h.writeErr(w, r, err)
+		return
+	}
+	result := make([]annualReportSummary, len(docs))
+	for i, d := range docs {
+		result[i] = annualReportSummary{Folio: d.Folio, ReportYear: d.ReportYear, SignerName: d.SignerName, SignedAt: d.SignedAt}
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+// Verify handles GET /annual-reports/{folio}, returning the exact signed
+// content with its signature and certificate, and the verdict of checking
+// that seal.
+func (h *AnnualReportHandler) Verify(w http.ResponseWriter, r *http.Request) {
+	folio := r.PathValue("folio")
+	if folio == "" {
+		writeErrorT(w, r, h.tr, http.StatusBadRequest, "folio_required")
+		return
+	}
+
+	d, verdict, err := h.svc.VerifyReport(r.Context(), folio)
+	if err != nil {
+		h.writeErr(w, r, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"folio":          d.Folio,
+		"report_year":    d.ReportYear,
+		"signer_name":    d.SignerName,
+		"signed_at":      d.SignedAt,
+		"canonical_json": base64.StdEncoding.EncodeToString(d.CanonicalJSON),
+		"signature":      base64.StdEncoding.EncodeToString(d.Signature),
+		"certificate":    base64.StdEncoding.EncodeToString(d.Certificate),
+		"verdict":        verdict,
+	})
+}
+
+// PDF handles GET /annual-reports/{folio}/pdf, rendering the stored content
+// rather than current figures so the document always matches its signature.
+func (h *AnnualReportHandler) PDF(w http.ResponseWriter, r *http.Request) {
+	d, ok := h.find(w, r)
+	if !ok {
+		return
+	}
+	content, err := d.Content()
+	if err != nil {
+		writeErrorT(w, r, h.tr, http.StatusInternalServerError, "report_export_failed")
+		return
+	}
+
+	writeDocument(w, r, h.tr, h.header, export.FormatPDF, d.Folio, annualReportDocument(d, content))
+}
+
+func (h *AnnualReportHandler) find(w http.ResponseWriter, r *http.Request) (*annualreport.Document, bool) {
+	folio := r.PathValue("folio")
+	if folio == "" {
+		writeErrorT(w, r, h.tr, http.StatusBadRequest, "folio_required")
+		return nil, false
+	}
+
+	d, err := h.svc.GetReport(r.Context(), folio)
+	if err != nil {
+		h.writeErr(w, r, err)
+		return nil, false
+	}
+	return d, true
+}
+
+func (h *AnnualReportHandler) writeErr(w http.ResponseWriter, r *http.Request, err error) {
+	if key, ok := certificateValidityKey(err); ok {
+		writeErrorT(w, r, h.tr, http.StatusUnprocessableEntity, key)
+		return
+	}
+	switch {
+	case errors.Is(err, annualreport.ErrNotFound):
+		writeErrorT(w, r, h.tr, http.StatusNotFound, "annual_report_not_found")
+	case errors.Is(err, annualreport.ErrInvalidYear):
+		writeErrorT(w, r, h.tr, http.StatusBadRequest, "invalid_year")
+	case errors.Is(err, annualreport.ErrSignerNameRequired):
+		writeErrorT(w, r, h.tr, http.StatusBadRequest, "signer_name_required")
+	case errors.Is(err, annualreport.ErrPasswordRequired):
+		writeErrorT(w, r, h.tr, http.StatusBadRequest, "password_required")
+	case errors.Is(err, annualreport.ErrSigningUnavailable):
+		writeErrorT(w, r, h.tr, http.StatusServiceUnavailable, "receipt_signing_not_configured")
+	case errors.Is(err, annualreport.ErrInvalidPassword):
+		writeErrorT(w, r, h.tr, http.StatusUnauthorized, "invalid_certificate_password")
+	default:
+		writeErrorT(w, r, h.tr, http.StatusInternalServerError, "annual_report_query_failed")
+	}
+}
@@ -0,0 +1,94 @@
+package httpapi
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/ivsanmendez/ControlDeContabilidad/internal/adapter/i18n"
+	"github.com/ivsanmendez/ControlDeContabilidad/internal/domain/budget"
+	"github.com/ivsanmendez/ControlDeContabilidad/internal/port"
+)
+
+// BudgetHandler serves the annual budget per category.
+type BudgetHandler struct {
+	svc port.BudgetService
+	tr  *i18n.Translator
+}
+
+type budgetLineRequest struct {
+	Kind       budget.Kind `json:"kind"`
+	CategoryID int64       `json:"category_id"`
+	Amount     float64     `json:"amount"`
+}
+
+type budgetRequest struct {
+	Lines []budgetLineRequest `json:"lines"`
+}
+
+// Get handles GET /budgets?year=YYYY.
+func (h *BudgetHandler) Get(w http.ResponseWriter, r *http.Request) {
+	year, err := strconv.Atoi(r.URL.Query().Get("year"))
+	if err != nil {
+		writeErrorT(w, r, h.tr, http.StatusBadRequest, "invalid_year")
+		return
+	}
+
+	lines, err := h.svc.GetBudget(r.Context(), year)
+	if err != nil {
+		h.writeErr(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, lines)
+}
+
+// Set handles PUT /budgets/{year}. The lines replace the whole year's budget.
+func (h *BudgetHandler) Set(w http.ResponseWriter, r *http.Request) {
+	claims, ok := ClaimsFromContext(r.Context())
+	if !ok {
+		writeErrorT(w, r, h.tr, http.StatusUnauthorized, "no_claims_in_context")
+		return
+	}
+
+	year, err := strconv.Atoi(r.PathValue("year"))
+	if err != nil {
+		writeErrorT(w, r, h.tr, http.StatusBadRequest, "invalid_year")
+		return
+	}
+
+	var req budgetRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeErrorT(w, r, h.tr, http.StatusBadRequest, "invalid_request_body")
+		return
+	}
+
+	inputs := make([]budget.LineInput, len(req.Lines))
+	for i, l := range req.Lines {
+		inputs[i] = budget.LineInput{Kind: l.Kind, CategoryID: l.CategoryID, Amount: l.Amount}
+	}
+
+	lines, err := h.svc.SetBudget(r.Context(), claims.UserID, year, inputs)
+	if err != nil {
+		h.writeErr(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, lines)
+}
+
+func (h *BudgetHandler) writeErr(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, budget.ErrInvalidYear):
+		writeErrorT(w, r, h.tr, http.StatusBadRequest, "invalid_year")
+	case errors.Is(err, budget.ErrInvalidKind):
+		writeErrorT(w, r, h.tr, http.StatusBadRequest, "invalid_budget_kind")
+	case errors.Is(err, budget.ErrInvalidCategory), errors.Is(err, budget.ErrUnknownCategory):
+		writeErrorT(w, r, h.tr, http.StatusBadRequest, "invalid_budget_category")
+	case errors.Is(err, budget.ErrNegativeAmount):
+		writeErrorT(w, r, h.tr, http.StatusBadRequest, "negative_budget_amount")
+	case errors.Is(err, budget.ErrDuplicateLine):
+		writeErrorT(w, r, h.tr, http.StatusConflict, "duplicate_budget_line")
+	default:
+		writeError(w, http.StatusUnprocessableEntity, err.Error())
+	}
+}
@@ -0,0 +1,223 @@
+package httpapi
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/ivsanmendez/ControlDeContabilidad/internal/adapter/i18n"
+	"github.com/ivsanmendez/ControlDeContabilidad/internal/domain/signingcert"
+	"github.com/ivsanmendez/ControlDeContabilidad/internal/port"
+)
+
+// CertificateHandler serves the per-user signing certificate registry.
+type CertificateHandler struct {
+	svc port.SigningCertificateService
+	tr  *i18n.Translator
+}
+
+// certificateUploadRequest carries the .cer and .key files base64-encoded.
+type certificateUploadRequest struct {
+	UserID      int64  `json:"user_id"`
+	Label       string `json:"label"`
+	Certificate []byte `json:"certificate"`
+	Key         []byte `json:"key"`
+}
+
+// certificateResponse never includes the encrypted key.
+type certificateResponse struct {
+	ID            int64      `json:"id"`
+	UserID        int64      `json:"user_id"`
+	Label         string     `json:"label"`
+	Serial        string     `json:"serial"`
+	RFC           string     `json:"rfc"`
+	Holder        string     `json:"holder"`
+	NotBefore     time.Time  `json:"not_before"`
+	NotAfter      time.Time  `json:"not_after"`
+	Active        bool       `json:"active"`
+	UploadedBy    int64      `json:"uploaded_by"`
+	UploadedAt    time.Time  `json:"uploaded_at"`
+	DeactivatedBy *int64     `json:"deactivated_by,omitempty"`
+	DeactivatedAt *time.Time `json:"deactivated_at,omitempty"`
+	Certificate   string     `json:"certificate"`
+}
+
+func toCertificateResponse(c *signingcert.Certificate) certificateResponse {
+	return certificateResponse{
+		ID:            c.ID,
+		UserID:        c.UserID,
+		Label:         c.Label,
+		Serial:        c.Serial,
+		RFC:           c.RFC,
+		Holder:        c.Holder,
+		NotBefore:     c.NotBefore,
+		NotAfter:      c.NotAfter,
+		Active:        c.Active(),
+		UploadedBy:    c.UploadedBy,
+		UploadedAt:    c.UploadedAt,
+		DeactivatedBy: c.DeactivatedBy,
+		DeactivatedAt: c.DeactivatedAt,
+		Certificate:   base64.StdEncoding.EncodeToString(c.CertificateDER),
+	}
+}
+
+func toCertificateResponses(certs []signingcert.Certificate) []certificateResponse {
+	out := make([]certificateResponse, len(certs))
+	for i := range certs {
+		out[i] = toCertificateResponse(&certs[i])
+	}
+	return out
+}
+
+// Upload handles POST /certificates, registering a certificate for a user.
+func (h *CertificateHandler) Upload(w http.ResponseWriter, r *http.Request) {
+	claims, ok := ClaimsFromContext(r.Context())
+	if !ok {
+		writeErrorT(w, r, h.tr, http.StatusUnauthorized, "no_claims_in_context")
+		return
+	}
+
+	var req certificateUploadRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeErrorT(w, r, h.tr, http.StatusBadRequest, "invalid_request_body")
+		return
+	}
+
+	c, err := h.svc.Upload(r.Context(), claims.UserID, req.UserID, req.Label, req.Certificate, req.Key, auditInfoFromRequest(r))
+	if err != nil {
+		writeCertificateErr(w, r, h.tr, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, toCertificateResponse(c))
+}
+
+// List handles GET /certificates?user_id=, deactivated certificates included.
+func (h *CertificateHandler) List(w http.ResponseWriter, r *http.Request) {
+	var userID int64
+	if v := r.URL.Query().Get("user_id"); v != "" {
+		id, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			writeErrorT(w, r, h.tr, http.StatusBadRequest, "invalid_user_id")
+			return
+		}
+		userID = id
+	}
+
+	certs, err := h.svc.ListCertificates(r.Context(), userID)
+	if err != nil {
+		writeCertificateErr(w, r, h.tr, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toCertificateResponses(certs))
+}
+
+// Mine handles GET /certificates/mine: the caller's certificates, to choose
+// one at signing time.
+func (h *CertificateHandler) Mine(w http.ResponseWriter, r *http.Request) {
+	claims, ok := ClaimsFromContext(r.Context())
+	if !ok {
+		writeErrorT(w, r, h.tr, http.StatusUnauthorized, "no_claims_in_context")
+		return
+	}
+
+	certs, err := h.svc.ListCertificates(r.Context(), claims.UserID)
+	if err != nil {
+		writeCertificateErr(w, r, h.tr, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toCertificateResponses(certs))
+}
+
+// Deactivate handles POST /certificates/deactivate/{id}. Receipts already
+// signed with the certificate remain valid.
+func (h *CertificateHandler) Deactivate(w http.ResponseWriter, r *http.Request) {
+	claims, ok := ClaimsFromContext(r.Context())
+	if !ok {
+		writeErrorT(w, r, h.tr, http.StatusUnauthorized, "no_claims_in_context")
+		return
+	}
+
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		writeErrorT(w, r, h.tr, http.StatusBadRequest, "invalid_id")
+		return
+	}
+
+	c, err := h.svc.Deactivate(r.Context(), claims.UserID, id, auditInfoFromRequest(r))
+	if err != nil {
+		writeCertificateErr(w, r, h.tr, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toCertificateResponse(c))
+}
+
+// writeCertificateErr maps signing certificate errors; the receipt handlers
+// share it for certificate selection at signing time.
+func writeCertificateErr(w http.ResponseWriter, r *http.Request, tr *i18n.Translator, err error) {
+	if key, ok := certificateValidityKey(err); ok {
+		writeErrorT(w, r, tr, http.StatusUnprocessableEntity, key)
+		return
+	}
+	switch {
+	case errors.Is(err, signingcert.ErrNotFound):
+		writeErrorT(w, r, tr, http.StatusNotFound, "signing_certificate_not_found")
+	case errors.Is(err, signingcert.ErrDuplicate):
+		writeErrorT(w, r, tr, http.StatusConflict, "signing_certificate_duplicate")
+	case errors.Is(err, signingcert.ErrInactive):
+		writeErrorT(w, r, tr, http.StatusConflict, "signing_certificate_inactive")
+	case errors.Is(err, signingcert.ErrNotHolder):
+		writeErrorT(w, r, tr, http.StatusForbidden, "signing_certificate_not_holder")
+	case errors.Is(err, signingcert.ErrSelectionRequired):
+		writeErrorT(w, r, tr, http.StatusUnprocessableEntity, "signing_certificate_selection_required")
+	case errors.Is(err, signingcert.ErrInvalidUserID):
+		writeErrorT(w, r, tr, http.StatusUnprocessableEntity, "invalid_user_id")
+	case errors.Is(err, signingcert.ErrCertificateRequired):
+		writeErrorT(w, r, tr, http.StatusUnprocessableEntity, "signing_certificate_required")
+	case errors.Is(err, signingcert.ErrKeyRequired):
+		writeErrorT(w, r, tr, http.StatusUnprocessableEntity, "signing_key_required")
+	case errors.Is(err, signingcert.ErrInvalidCertificate):
+		writeErrorT(w, r, tr, http.StatusUnprocessableEntity, "signing_certificate_invalid")
+	case errors.Is(err, signingcert.ErrKeyNotEncrypted):
+		writeErrorT(w, r, tr, http.StatusUnprocessableEntity, "signing_key_not_encrypted")
+	default:
+		writeErrorT(w, r, tr, http.StatusInternalServerError, "signing_certificate_query_failed")
+	}
+}
+
+// certificateValidityKey maps a certificate refused by validation (expired,
+// not yet valid, wrong key usage, untrusted, key not its own) to its message
+// key. Every signing path checks it so the refusal reads the same everywhere.
+func certificateValidityKey(err error) (string, bool) {
+	switch {
+	case errors.Is(err, signingcert.ErrCertificateExpired):
+		return "signing_certificate_expired", true
+	case errors.Is(err, signingcert.ErrCertificateNotYetValid):
+		return "signing_certificate_not_yet_valid", true
+	case errors.Is(err, signingcert.ErrCertificateKeyUsage):
+		return "signing_certificate_key_usage", true
+	case errors.Is(err, signingcert.ErrCertificateUntrusted):
+		return "signing_certificate_untrusted", true
+	case errors.Is(err, signingcert.ErrKeyMismatch):
+		return "signing_key_mismatch", true
+	default:
+		return "", false
+	}
+}
+
+// signerFor resolves the certificate the caller signs with: the one chosen,
+// else their only active certificate, else the community certificate
+// configured at startup.
+func signerFor(ctx context.Context, certSvc port.SigningCertificateService, community port.ReceiptSigner, userID, certID int64) (port.ReceiptSigner, error) {
+	signer, err := certSvc.SignerFor(ctx, userID, certID)
+	if errors.Is(err, signingcert.ErrNoCertificate) {
+		return community, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return signer, nil
+}
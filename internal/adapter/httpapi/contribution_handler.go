@@ -9,6 +9,7 @@ import (
 
 	"github.com/ivsanmendez/ControlDeContabilidad/internal/adapter/i18n"
 	"github.com/ivsanmendez/ControlDeContabilidad/internal/domain/contribution"
+	"github.com/ivsanmendez/ControlDeContabilidad/internal/domain/period"
 	"github.com/ivsanmendez/ControlDeContabilidad/internal/port"
 )
 
@@ -18,13 +19,14 @@ type ContributionHandler struct {
 }
 
 type createContributionRequest struct {
-	ContributorID int64                      `json:"contributor_id"`
-	CategoryID    int64                      `json:"category_id"`
-	Amount        float64                    `json:"amount"`
-	Month         int                        `json:"month"`
-	Year          int                        `json:"year"`
-	PaymentDate   string                     `json:"payment_date"`
-	PaymentMethod contribution.PaymentMethod `json:"payment_method"`
+	ContributorID      int64                      `json:"contributor_id"`
+	CategoryID         int64                      `json:"category_id"`
+	Amount             float64                    `json:"amount"`
+	Month              int                        `json:"month"`
+	Year               int                        `json:"year"`
+	PaymentDate        string                     `json:"payment_date"`
+	PaymentMethod      contribution.PaymentMethod `json:"payment_method"`
+	FinancialAccountID int64                      `json:"financial_account_id"`
 }
 
 func (h *ContributionHandler) Create(w http.ResponseWriter, r *http.Request) {
@@ -56,10 +58,15 @@ func (h *ContributionHandler) Create(w http.ResponseWriter, r *http.Request) {
 		req.Year,
 		paymentDate,
 		req.PaymentMethod,
+		req.FinancialAccountID,
 	)
 	if err != nil {
 		if errors.Is(err, contribution.ErrDuplicate) {
 			writeError(w, http.StatusConflict, err.Error())
+		} else if errors.Is(err, period.ErrClosed) {
+			writeErrorT(w, r, h.tr, http.StatusConflict, "period_closed")
+		} else if errors.Is(err, contribution.ErrNotPosted) {
+			writeNotPosted(w, r, h.tr, err)
 		} else {
 			writeError(w, http.StatusUnprocessableEntity, err.Error())
 		}
@@ -120,13 +127,14 @@ func (h *ContributionHandler) GetByID(w http.ResponseWriter, r *http.Request) {
 }
 
 type updateContributionRequest struct {
-	ContributorID int64                      `json:"contributor_id"`
-	CategoryID    int64                      `json:"category_id"`
-	Amount        float64                    `json:"amount"`
-	Month         int                        `json:"month"`
-	Year          int                        `json:"year"`
-	PaymentDate   string                     `json:"payment_date"`
-	PaymentMethod contribution.PaymentMethod `json:"payment_method"`
+	ContributorID      int64                      `json:"contributor_id"`
+	CategoryID         int64                      `json:"category_id"`
+	Amount             float64                    `json:"amount"`
+	Month              int                        `json:"month"`
+	Year               int                        `json:"year"`
+	PaymentDate        string                     `json:"payment_date"`
+	PaymentMethod      contribution.PaymentMethod `json:"payment_method"`
+	FinancialAccountID int64                      `json:"financial_account_id"`
 }
 
 func (h *ContributionHandler) Update(w http.ResponseWriter, r *http.Request) {
@@ -158,12 +166,17 @@ func (h *ContributionHandler) Update(w http.ResponseWriter, r *http.Request) {
 		req.Year,
 		paymentDate,
 		req.PaymentMethod,
+		req.FinancialAccountID,
 	)
 	if err != nil {
 		if errors.Is(err, contribution.ErrNotFound) {
 			writeErrorT(w, r, h.tr, http.StatusNotFound, "contribution_not_found")
 		} else if errors.Is(err, contribution.ErrDuplicate) {
 			writeError(w, http.StatusConflict, err.Error())
+		} else if errors.Is(err, period.ErrClosed) {
+			writeErrorT(w, r, h.tr, http.StatusConflict, "period_closed")
+		} else if errors.Is(err, contribution.ErrNotPosted) {
+			writeNotPosted(w, r, h.tr, err)
 		} else {
 			writeError(w, http.StatusUnprocessableEntity, err.Error())
 		}
@@ -182,6 +195,10 @@ func (h *ContributionHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	if err := h.svc.DeleteContribution(r.Context(), id); err != nil {
 		if errors.Is(err, contribution.ErrNotFound) {
 			writeErrorT(w, r, h.tr, http.StatusNotFound, "contribution_not_found")
+		} else if errors.Is(err, period.ErrClosed) {
+			writeErrorT(w, r, h.tr, http.StatusConflict, "period_closed")
+		} else if errors.Is(err, contribution.ErrNotPosted) {
+			writeNotPosted(w, r, h.tr, err)
 		} else {
 			writeError(w, http.StatusInternalServerError, err.Error())
 		}
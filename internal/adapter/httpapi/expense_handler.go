@@ -9,6 +9,7 @@ import (
 
 	"github.com/ivsanmendez/ControlDeContabilidad/internal/adapter/i18n"
 	"github.com/ivsanmendez/ControlDeContabilidad/internal/domain/expense"
+	"github.com/ivsanmendez/ControlDeContabilidad/internal/domain/period"
 	"github.com/ivsanmendez/ControlDeContabilidad/internal/port"
 )
 
@@ -17,11 +18,33 @@ type ExpenseHandler struct {
 	tr  *i18n.Translator
 }
 
+type expenseLineRequest struct {
+	CategoryID  int64   `json:"category_id"`
+	Description string  `json:"description"`
+	Amount      float64 `json:"amount"`
+}
+
 type createExpenseRequest struct {
-	Description string    `json:"description"`
-	Amount      float64   `json:"amount"`
-	CategoryID  int64     `json:"category_id"`
-	Date        time.Time `json:"date"`
+	Description        string               `json:"description"`
+	Amount             float64              `json:"amount"`
+	CategoryID         int64                `json:"category_id"`
+	SupplierID         *int64               `json:"supplier_id"`
+	FinancialAccountID int64                `json:"financial_account_id"`
+	Lines              []expenseLineRequest `json:"lines"`
+	Date               time.Time            `json:"date"`
+}
+
+// toExpenseLines keeps an omitted "lines" field nil so an update can tell it
+// apart from an explicit empty list.
+func toExpenseLines(reqs []expenseLineRequest) []expense.Line {
+	if reqs == nil {
+		return nil
+	}
+	lines := make([]expense.Line, len(reqs))
+	for i, l := range reqs {
+		lines[i] = expense.Line{CategoryID: l.CategoryID, Description: l.Description, Amount: l.Amount}
+	}
+	return lines
 }
 
 func (h *ExpenseHandler) Create(w http.ResponseWriter, r *http.Request) {
@@ -36,9 +59,15 @@ func (h *ExpenseHandler) Create(w http.ResponseWriter, r *http.Request) {
 		writeErrorT(w, r, h.tr, http.StatusBadRequest, "invalid_request_body")
 		return
 	}
-	e, err := h.svc.CreateExpense(r.Context(), claims.UserID, req.Description, req.Amount, req.CategoryID, req.Date)
+	e, err := h.svc.CreateExpense(r.Context(), claims.UserID, req.Description, req.Amount, req.CategoryID, req.SupplierID, req.FinancialAccountID, toExpenseLines(req.Lines), req.Date)
 	if err != nil {
-		writeError(w, http.StatusUnprocessableEntity, err.Error())
+		if errors.Is(err, period.ErrClosed) {
+			writeErrorT(w, r, h.tr, http.StatusConflict, "period_closed")
+		} else if errors.Is(err, expense.ErrNotPosted) {
+			writeNotPosted(w, r, h.tr, err)
+		} else {
+			writeError(w, http.StatusUnprocessableEntity, err.Error())
+		}
 		return
 	}
 	writeJSON(w, http.StatusCreated, e)
@@ -84,10 +113,13 @@ func (h *ExpenseHandler) GetByID(w http.ResponseWriter, r *http.Request) {
 }
 
 type updateExpenseRequest struct {
-	Description string    `json:"description"`
-	Amount      float64   `json:"amount"`
-	CategoryID  int64     `json:"category_id"`
-	Date        time.Time `json:"date"`
+	Description        string               `json:"description"`
+	Amount             float64              `json:"amount"`
+	CategoryID         int64                `json:"category_id"`
+	SupplierID         *int64               `json:"supplier_id"`
+	FinancialAccountID int64                `json:"financial_account_id"`
+	Lines              []expenseLineRequest `json:"lines"`
+	Date               time.Time            `json:"date"`
 }
 
 func (h *ExpenseHandler) Update(w http.ResponseWriter, r *http.Request) {
@@ -109,12 +141,18 @@ func (h *ExpenseHandler) Update(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	e, err := h.svc.UpdateExpense(r.Context(), claims.UserID, claims.Role, id, req.Description, req.Amount, req.CategoryID, req.Date)
+	e, err := h.svc.UpdateExpense(r.Context(), claims.UserID, claims.Role, id, req.Description, req.Amount, req.CategoryID, req.SupplierID, req.FinancialAccountID, toExpenseLines(req.Lines), req.Date)
 	if err != nil {
 		if errors.Is(err, expense.ErrForbidden) {
 			writeError(w, http.StatusForbidden, err.Error())
 		} else if errors.Is(err, expense.ErrNotFound) {
 			writeErrorT(w, r, h.tr, http.StatusNotFound, "expense_not_found")
+		} else if errors.Is(err, period.ErrClosed) {
+			writeErrorT(w, r, h.tr, http.StatusConflict, "period_closed")
+		} else if errors.Is(err, expense.ErrPettyCashCharge) {
+			writeErrorT(w, r, h.tr, http.StatusConflict, "expense_petty_cash_charge")
+		} else if errors.Is(err, expense.ErrNotPosted) {
+			writeNotPosted(w, r, h.tr, err)
 		} else {
 			writeError(w, http.StatusUnprocessableEntity, err.Error())
 		}
@@ -138,6 +176,12 @@ func (h *ExpenseHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	if err := h.svc.DeleteExpense(r.Context(), claims.UserID, claims.Role, id); err != nil {
 		if errors.Is(err, expense.ErrForbidden) {
 			writeError(w, http.StatusForbidden, err.Error())
+		} else if errors.Is(err, period.ErrClosed) {
+			writeErrorT(w, r, h.tr, http.StatusConflict, "period_closed")
+		} else if errors.Is(err, expense.ErrPettyCashCharge) {
+			writeErrorT(w, r, h.tr, http.StatusConflict, "expense_petty_cash_charge")
+		} else if errors.Is(err, expense.ErrNotPosted) {
+			writeNotPosted(w, r, h.tr, err)
 		} else {
 			writeError(w, http.StatusNotFound, err.Error())
 		}
@@ -0,0 +1,324 @@
+package httpapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/ivsanmendez/ControlDeContabilidad/internal/adapter/export"
+	"github.com/ivsanmendez/ControlDeContabilidad/internal/adapter/i18n"
+	"github.com/ivsanmendez/ControlDeContabilidad/internal/domain/ledger"
+	"github.com/ivsanmendez/ControlDeContabilidad/internal/port"
+)
+
+// LedgerHandler serves the chart of accounts, journal entries and the
+// financial statements built from them.
+type LedgerHandler struct {
+	svc    port.LedgerService
+	tr     *i18n.Translator
+	header export.Header
+}
+
+type accountRequest struct {
+	Code     string             `json:"code"`
+	Name     string             `json:"name"`
+	Type     ledger.AccountType `json:"type"`
+	Nature   ledger.Nature      `json:"nature"`
+	ParentID *int64             `json:"parent_id"`
+	SATCode  string             `json:"sat_code"`
+	IsActive bool               `json:"is_active"`
+}
+
+type categoryAccountRequest struct {
+	AccountID *int64 `json:"account_id"`
+}
+
+type postingRequest struct {
+	AccountID int64   `json:"account_id"`
+	Debit     float64 `json:"debit"`
+	Credit    float64 `json:"credit"`
+	Memo      string  `json:"memo"`
+}
+
+type entryRequest struct {
+	Date        string           `json:"date"`
+	Description string           `json:"description"`
+	Postings    []postingRequest `json:"postings"`
+}
+
+func (h *LedgerHandler) CreateAccount(w http.ResponseWriter, r *http.Request) {
+	var req accountRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeErrorT(w, r, h.tr, http.StatusBadRequest, "invalid_request_body")
+		return
+	}
+
+	a, err := h.svc.CreateAccount(r.Context(), req.Code, req.Name, req.Type, req.Nature, req.ParentID, req.SATCode)
+	if err != nil {
+		h.writeErr(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, a)
+}
+
+func (h *LedgerHandler) ListAccounts(w http.ResponseWriter, r *http.Request) {
+	accounts, err := h.svc.ListAccounts(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, accounts)
+}
+
+func (h *LedgerHandler) UpdateAccount(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		writeErrorT(w, r, h.tr, http.StatusBadRequest, "invalid_id")
+		return
+	}
+
+	var req accountRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeErrorT(w, r, h.tr, http.StatusBadRequest, "invalid_request_body")
+		return
+	}
+
+	a, err := h.svc.UpdateAccount(r.Context(), id, req.Name, req.ParentID, req.SATCode, req.IsActive)
+	if err != nil {
+		h.writeErr(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, a)
+}
+
+func (h *LedgerHandler) ListCategoryAccounts(w http.ResponseWriter, r *http.Request) {
+	mappings, err := h.svc.ListCategoryAccounts(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, mappings)
+}
+
+// SetCategoryAccount handles PUT /ledger/category-accounts/{kind}/{id}.
+// A null account_id restores the default account for the category.
+func (h *LedgerHandler) SetCategoryAccount(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		writeErrorT(w, r, h.tr, http.StatusBadRequest, "invalid_id")
+		return
+	}
+
+	var req categoryAccountRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeErrorT(w, r, h.tr, http.StatusBadRequest, "invalid_request_body")
+		return
+	}
+
+	kind := ledger.CategoryKind(r.PathValue("kind"))
+	if err := h.svc.SetCategoryAccount(r.Context(), kind, id, req.AccountID); err != nil {
+		h.writeErr(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// CreateEntry handles POST /ledger/entries for manual adjusting entries.
+func (h *LedgerHandler) CreateEntry(w http.ResponseWriter, r *http.Request) {
+	claims, ok := ClaimsFromContext(r.Context())
+	if !ok {
+		writeErrorT(w, r, h.tr, http.StatusUnauthorized, "no_claims_in_context")
+		return
+	}
+
+	var req entryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeErrorT(w, r, h.tr, http.StatusBadRequest, "invalid_request_body")
+		return
+	}
+
+	date, err := time.Parse("2006-01-02", req.Date)
+	if err != nil {
+		writeErrorT(w, r, h.tr, http.StatusBadRequest, "invalid_date_format")
+		return
+	}
+
+	postings := make([]ledger.Posting, len(req.Postings))
+	for i, p := range req.Postings {
+		postings[i] = ledger.Posting{AccountID: p.AccountID, Debit: p.Debit, Credit: p.Credit, Memo: p.Memo}
+	}
+
+	e, err := h.svc.CreateManualEntry(r.Context(), claims.UserID, date, req.Description, postings)
+	if err != nil {
+		h.writeErr(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, e)
+}
+
+// ListEntries handles GET /ledger/entries?from=YYYY-MM-DD&to=YYYY-MM-DD (both optional).
+func (h *LedgerHandler) ListEntries(w http.ResponseWriter, r *http.Request) {
+	from, ok := h.dateParam(w, r, "from")
+	if !ok {
+		return
+	}
+	to, ok := h.dateParam(w, r, "to")
+	if !ok {
+		return
+	}
+
+	entries, err := h.svc.ListEntries(r.Context(), from, to)
+	if err != nil {
+		h.writeErr(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, entries)
+}
+
+func (h *LedgerHandler) GetEntry(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		writeErrorT(w, r, h.tr, http.StatusBadRequest, "invalid_id")
+		return
+	}
+
+	e, err := h.svc.GetEntry(r.Context(), id)
+	if err != nil {
+		h.writeErr(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, e)
+}
+
+// Rebuild handles POST /ledger/rebuild: it regenerates every generated entry
+// from its contribution, expense or transfer.
+func (h *LedgerHandler) Rebuild(w http.ResponseWriter, r *http.Request) {
+	res, err := h.svc.Rebuild(r.Context())
+	if err != nil {
+		log.Printf("rebuild ledger: %v", err)
+		writeErrorT(w, r, h.tr, http.StatusInternalServerError, "ledger_rebuild_failed")
+		return
+	}
+	writeJSON(w, http.StatusOK, res)
+}
+
+// TrialBalance handles GET /reports/trial-balance?as_of=YYYY-MM-DD (default today).
+func (h *LedgerHandler) TrialBalance(w http.ResponseWriter, r *http.Request) {
+	asOf, ok := h.asOfParam(w, r)
+	if !ok {
+		return
+	}
+
+	tb, err := h.svc.GetTrialBalance(r.Context(), asOf)
+	if err != nil {
+		writeErrorT(w, r, h.tr, http.StatusInternalServerError, "report_query_failed")
+		return
+	}
+	writeReport(w, r, h.tr, h.header, fmt.Sprintf("trial-balance-%s", asOf.Format("20060102")), tb, trialBalanceDocument(tb))
+}
+
+// BalanceSheet handles GET /reports/balance-sheet?as_of=YYYY-MM-DD (default today).
+func (h *LedgerHandler) BalanceSheet(w http.ResponseWriter, r *http.Request) {
+	asOf, ok := h.asOfParam(w, r)
+	if !ok {
+		return
+	}
+
+	bs, err := h.svc.GetBalanceSheet(r.Context(), asOf)
+	if err != nil {
+		writeErrorT(w, r, h.tr, http.StatusInternalServerError, "report_query_failed")
+		return
+	}
+	writeReport(w, r, h.tr, h.header, fmt.Sprintf("balance-sheet-%s", asOf.Format("20060102")), bs, balanceSheetDocument(bs))
+}
+
+// IncomeStatement handles GET /reports/income-statement?from=YYYY-MM-DD&to=YYYY-MM-DD.
+func (h *LedgerHandler) IncomeStatement(w http.ResponseWriter, r *http.Request) {
+	from, ok := h.dateParam(w, r, "from")
+	if !ok {
+		return
+	}
+	to, ok := h.dateParam(w, r, "to")
+	if !ok {
+		return
+	}
+	if from.IsZero() || to.IsZero() {
+		writeErrorT(w, r, h.tr, http.StatusBadRequest, "invalid_date_format")
+		return
+	}
+
+	is, err := h.svc.GetIncomeStatement(r.Context(), from, to)
+	if err != nil {
+		if errors.Is(err, ledger.ErrInvalidDateRange) {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		writeErrorT(w, r, h.tr, http.StatusInternalServerError, "report_query_failed")
+		return
+	}
+	writeReport(w, r, h.tr, h.header, fmt.Sprintf("income-statement-%s-%s", from.Format("20060102"), to.Format("20060102")), is, incomeStatementDocument(is))
+}
+
+// ChartBalance handles GET /reports/chart-balance?as_of=YYYY-MM-DD (default today).
+func (h *LedgerHandler) ChartBalance(w http.ResponseWriter, r *http.Request) {
+	asOf, ok := h.asOfParam(w, r)
+	if !ok {
+		return
+	}
+
+	cb, err := h.svc.GetChartBalance(r.Context(), asOf)
+	if err != nil {
+		writeErrorT(w, r, h.tr, http.StatusInternalServerError, "report_query_failed")
+		return
+	}
+	writeReport(w, r, h.tr, h.header, fmt.Sprintf("chart-balance-%s", asOf.Format("20060102")), cb, chartBalanceDocument(cb))
+}
+
+// dateParam parses an optional YYYY-MM-DD query parameter; absent yields the zero time.
+func (h *LedgerHandler) dateParam(w http.ResponseWriter, r *http.Request, name string) (time.Time, bool) {
+	v := r.URL.Query().Get(name)
+	if v == "" {
+		return time.Time{}, true
+	}
+	t, err := time.Parse("2006-01-02", v)
+	if err != nil {
+		writeErrorT(w, r, h.tr, http.StatusBadRequest, "invalid_date_format")
+		return time.Time{}, false
+	}
+	return t, true
+}
+
+func (h *LedgerHandler) asOfParam(w http.ResponseWriter, r *http.Request) (time.Time, bool) {
+	asOf, ok := h.dateParam(w, r, "as_of")
+	if ok && asOf.IsZero() {
+		now := time.Now()
+		asOf = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	}
+	return asOf, ok
+}
+
+// writeNotPosted answers a change that was saved but whose generated ledger
+// entry could not be written; POST /ledger/rebuild repairs the ledger.
+func writeNotPosted(w http.ResponseWriter, r *http.Request, tr *i18n.Translator, err error) {
+	log.Printf("ledger: %v", err)
+	writeErrorT(w, r, tr, http.StatusInternalServerError, "ledger_not_posted")
+}
+
+func (h *LedgerHandler) writeErr(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, ledger.ErrAccountNotFound):
+		writeErrorT(w, r, h.tr, http.StatusNotFound, "ledger_account_not_found")
+	case errors.Is(err, ledger.ErrCategoryNotFound):
+		writeErrorT(w, r, h.tr, http.StatusNotFound, "ledger_category_not_found")
+	case errors.Is(err, ledger.ErrEntryNotFound):
+		writeErrorT(w, r, h.tr, http.StatusNotFound, "journal_entry_not_found")
+	case errors.Is(err, ledger.ErrDuplicateAccount):
+		writeError(w, http.StatusConflict, err.Error())
+	default:
+		writeError(w, http.StatusUnprocessableEntity, err.Error())
+	}
+}
@@ -0,0 +1,95 @@
+package httpapi
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/ivsanmendez/ControlDeContabilidad/internal/adapter/i18n"
+	"github.com/ivsanmendez/ControlDeContabilidad/internal/domain/period"
+	"github.com/ivsanmendez/ControlDeContabilidad/internal/port"
+)
+
+// PeriodHandler serves closing and reopening of accounting periods.
+type PeriodHandler struct {
+	svc port.PeriodService
+	tr  *i18n.Translator
+}
+
+type periodRequest struct {
+	Year   int    `json:"year"`
+	Month  int    `json:"month"`
+	Reason string `json:"reason"`
+}
+
+// Close handles POST /periods/close. Month 0 closes the whole fiscal year.
+func (h *PeriodHandler) Close(w http.ResponseWriter, r *http.Request) {
+	claims, ok := ClaimsFromContext(r.Context())
+	if !ok {
+		writeErrorT(w, r, h.tr, http.StatusUnauthorized, "no_claims_in_context")
+		return
+	}
+
+	var req periodRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeErrorT(w, r, h.tr, http.StatusBadRequest, "invalid_request_body")
+		return
+	}
+
+	p, err := h.svc.Close(r.Context(), claims.UserID, req.Year, req.Month, auditInfoFromRequest(r))
+	if err != nil {
+		h.writeErr(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, p)
+}
+
+// Reopen handles POST /periods/reopen; a reason is mandatory and audited.
+func (h *PeriodHandler) Reopen(w http.ResponseWriter, r *http.Request) {
+	claims, ok := ClaimsFromContext(r.Context())
+	if !ok {
+		writeErrorT(w, r, h.tr, http.StatusUnauthorized, "no_claims_in_context")
+		return
+	}
+
+	var req periodRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeErrorT(w, r, h.tr, http.StatusBadRequest, "invalid_request_body")
+		return
+	}
+
+	p, err := h.svc.Reopen(r.Context(), claims.UserID, req.Year, req.Month, req.Reason, auditInfoFromRequest(r))
+	if err != nil {
+		h.writeErr(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, p)
+}
+
+// List handles GET /periods?year=YYYY, including reopened periods.
+func (h *PeriodHandler) List(w http.ResponseWriter, r *http.Request) {
+	year, err := strconv.Atoi(r.URL.Query().Get("year"))
+	if err != nil {
+		writeErrorT(w, r, h.tr, http.StatusBadRequest, "invalid_year")
+		return
+	}
+
+	periods, err := h.svc.ListPeriods(r.Context(), year)
+	if err != nil {
+		h.writeErr(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, periods)
+}
+
+func (h *PeriodHandler) writeErr(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, period.ErrAlreadyClosed):
+		writeErrorT(w, r, h.tr, http.StatusConflict, "period_already_closed")
+	case errors.Is(err, period.ErrNotClosed):
+		writeErrorT(w, r, h.tr, http.StatusConflict, "period_not_closed")
+	default:
+		writeError(w, http.StatusUnprocessableEntity, err.Error())
+	}
+}
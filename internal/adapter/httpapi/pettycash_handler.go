@@ -0,0 +1,272 @@
+package httpapi
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/ivsanmendez/ControlDeContabilidad/internal/adapter/i18n"
+	"github.com/ivsanmendez/ControlDeContabilidad/internal/domain/expense"
+	"github.com/ivsanmendez/ControlDeContabilidad/internal/domain/period"
+	"github.com/ivsanmendez/ControlDeContabilidad/internal/domain/pettycash"
+	"github.com/ivsanmendez/ControlDeContabilidad/internal/domain/treasury"
+	"github.com/ivsanmendez/ControlDeContabilidad/internal/port"
+)
+
+// PettyCashHandler serves petty cash (caja chica) funds, charges,
+// replenishments and reconciliations.
+type PettyCashHandler struct {
+	svc port.PettyCashService
+	tr  *i18n.Translator
+}
+
+type fundRequest struct {
+	Name               string  `json:"name"`
+	CustodianUserID    int64   `json:"custodian_user_id"`
+	FloatAmount        float64 `json:"float_amount"`
+	FinancialAccountID int64   `json:"financial_account_id"`
+	IsActive           bool    `json:"is_active"`
+}
+
+type pettyCashChargeRequest struct {
+	Description string    `json:"description"`
+	Amount      float64   `json:"amount"`
+	CategoryID  int64     `json:"category_id"`
+	SupplierID  *int64    `json:"supplier_id"`
+	Date        time.Time `json:"date"`
+}
+
+type reconciliationRequest struct {
+	CountedAmount float64 `json:"counted_amount"`
+	Notes         string  `json:"notes"`
+}
+
+func (h *PettyCashHandler) CreateFund(w http.ResponseWriter, r *http.Request) {
+	claims, ok := ClaimsFromContext(r.Context())
+	if !ok {
+		writeErrorT(w, r, h.tr, http.StatusUnauthorized, "no_claims_in_context")
+		return
+	}
+
+	var req fundRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeErrorT(w, r, h.tr, http.StatusBadRequest, "invalid_request_body")
+		return
+	}
+
+	f, err := h.svc.CreateFund(r.Context(), claims.UserID, req.Name, req.CustodianUserID, req.FloatAmount, req.FinancialAccountID)
+	if err != nil {
+		writeError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusCreated, f)
+}
+
+func (h *PettyCashHandler) ListFunds(w http.ResponseWriter, r *http.Request) {
+	funds, err := h.svc.ListFunds(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, funds)
+}
+
+// GetFund handles GET /petty-cash/funds/{id} and includes the book balance.
+func (h *PettyCashHandler) GetFund(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.pathID(w, r)
+	if !ok {
+		return
+	}
+
+	st, err := h.svc.GetFundStatus(r.Context(), id)
+	if err != nil {
+		h.writeErr(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, st)
+}
+
+func (h *PettyCashHandler) UpdateFund(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.pathID(w, r)
+	if !ok {
+		return
+	}
+
+	var req fundRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeErrorT(w, r, h.tr, http.StatusBadRequest, "invalid_request_body")
+		return
+	}
+
+	f, err := h.svc.UpdateFund(r.Context(), id, req.Name, req.CustodianUserID, req.FloatAmount, req.FinancialAccountID, req.IsActive)
+	if err != nil {
+		h.writeErr(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, f)
+}
+
+func (h *PettyCashHandler) Charge(w http.ResponseWriter, r *http.Request) {
+	claims, ok := ClaimsFromContext(r.Context())
+	if !ok {
+		writeErrorT(w, r, h.tr, http.StatusUnauthorized, "no_claims_in_context")
+		return
+	}
+	id, ok := h.pathID(w, r)
+	if !ok {
+		return
+	}
+
+	var req pettyCashChargeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeErrorT(w, r, h.tr, http.StatusBadRequest, "invalid_request_body")
+		return
+	}
+
+	c, err := h.svc.ChargeExpense(r.Context(), claims.UserID, claims.Role, id, req.Description, req.Amount, req.CategoryID, req.SupplierID, req.Date)
+	if err != nil {
+		h.writeErr(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, c)
+}
+
+func (h *PettyCashHandler) ListCharges(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.pathID(w, r)
+	if !ok {
+		return
+	}
+
+	charges, err := h.svc.ListCharges(r.Context(), id)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, charges)
+}
+
+func (h *PettyCashHandler) RequestReplenishment(w http.ResponseWriter, r *http.Request) {
+	claims, ok := ClaimsFromContext(r.Context())
+	if !ok {
+		writeErrorT(w, r, h.tr, http.StatusUnauthorized, "no_claims_in_context")
+		return
+	}
+	id, ok := h.pathID(w, r)
+	if !ok {
+		return
+	}
+
+	rp, err := h.svc.RequestReplenishment(r.Context(), claims.UserID, claims.Role, id)
+	if err != nil {
+		h.writeErr(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, rp)
+}
+
+func (h *PettyCashHandler) ListReplenishments(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.pathID(w, r)
+	if !ok {
+		return
+	}
+
+	rps, err := h.svc.ListReplenishments(r.Context(), id)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, rps)
+}
+
+// CompleteReplenishment handles POST /petty-cash/replenishments/{id}/complete.
+func (h *PettyCashHandler) CompleteReplenishment(w http.ResponseWriter, r *http.Request) {
+	claims, ok := ClaimsFromContext(r.Context())
+	if !ok {
+		writeErrorT(w, r, h.tr, http.StatusUnauthorized, "no_claims_in_context")
+		return
+	}
+	id, ok := h.pathID(w, r)
+	if !ok {
+		return
+	}
+
+	rp, err := h.svc.CompleteReplenishment(r.Context(), claims.UserID, id)
+	if err != nil {
+		h.writeErr(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, rp)
+}
+
+func (h *PettyCashHandler) Reconcile(w http.ResponseWriter, r *http.Request) {
+	claims, ok := ClaimsFromContext(r.Context())
+	if !ok {
+		writeErrorT(w, r, h.tr, http.StatusUnauthorized, "no_claims_in_context")
+		return
+	}
+	id, ok := h.pathID(w, r)
+	if !ok {
+		return
+	}
+
+	var req reconciliationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeErrorT(w, r, h.tr, http.StatusBadRequest, "invalid_request_body")
+		return
+	}
+
+	rec, err := h.svc.Reconcile(r.Context(), claims.UserID, claims.Role, id, req.CountedAmount, req.Notes)
+	if err != nil {
+		h.writeErr(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, rec)
+}
+
+func (h *PettyCashHandler) ListReconciliations(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.pathID(w, r)
+	if !ok {
+		return
+	}
+
+	recs, err := h.svc.ListReconciliations(r.Context(), id)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, recs)
+}
+
+func (h *PettyCashHandler) pathID(w http.ResponseWriter, r *http.Request) (int64, bool) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		writeErrorT(w, r, h.tr, http.StatusBadRequest, "invalid_id")
+		return 0, false
+	}
+	return id, true
+}
+
+func (h *PettyCashHandler) writeErr(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, pettycash.ErrNotFound):
+		writeErrorT(w, r, h.tr, http.StatusNotFound, "petty_cash_fund_not_found")
+	case errors.Is(err, pettycash.ErrReplenishmentNotFound):
+		writeErrorT(w, r, h.tr, http.StatusNotFound, "petty_cash_replenishment_not_found")
+	case errors.Is(err, pettycash.ErrForbidden):
+		writeError(w, http.StatusForbidden, err.Error())
+	case errors.Is(err, period.ErrClosed):
+		writeErrorT(w, r, h.tr, http.StatusConflict, "period_closed")
+	case errors.Is(err, pettycash.ErrInsufficientFunds),
+		errors.Is(err, treasury.ErrInsufficientFunds),
+		errors.Is(err, pettycash.ErrNoOpenCharges),
+		errors.Is(err, pettycash.ErrNotPending),
+		errors.Is(err, pettycash.ErrFundInactive):
+		writeError(w, http.StatusConflict, err.Error())
+	case errors.Is(err, expense.ErrNotPosted):
+		writeNotPosted(w, r, h.tr, err)
+	default:
+		writeError(w, http.StatusUnprocessableEntity, err.Error())
+	}
+}
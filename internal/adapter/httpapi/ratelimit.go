@@ -0,0 +1,79 @@
+package httpapi
+
+import (
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/ivsanmendez/ControlDeContabilidad/internal/adapter/i18n"
+)
+
+// RateLimit returns middleware that allows each client IP at most limit
+// requests per fixed window. It guards the public, unauthenticated routes.
+func RateLimit(limit int, window time.Duration, tr *i18n.Translator) func(http.Handler) http.Handler {
+	l := &rateLimiter{limit: limit, window: window, clients: map[string]*rateWindow{}, now: time.Now}
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if retry, ok := l.allow(clientIP(r)); !ok {
+				w.Header().Set("Retry-After", strconv.Itoa(int(retry.Seconds())+1))
+				writeErrorT(w, r, tr, http.StatusTooManyRequests, "rate_limit_exceeded")
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+type rateWindow struct {
+	start time.Time
+	count int
+}
+
+type rateLimiter struct {
+	mu        sync.Mutex
+	limit     int
+	window    time.Duration
+	clients   map[string]*rateWindow
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+// allow counts a request for key and reports whether it fits in the current
+// window; when it does not, it also returns how long until the window resets.
+func (l *rateLimiter) allow(key string) (time.Duration, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	if now.Sub(l.lastSweep) >= l.window {
+		for k, c := range l.clients {
+			if now.Sub(c.start) >= l.window {
+				delete(l.clients, k)
+			}
+		}
+		l.lastSweep = now
+	}
+
+	c, ok := l.clients[key]
+	if !ok || now.Sub(c.start) >= l.window {
+		l.clients[key] = &rateWindow{start: now, count: 1}
+		return 0, true
+	}
+	if c.count >= l.limit {
+		return c.start.Add(l.window).Sub(now), false
+	}
+	c.count++
+	return 0, true
+}
+
+// clientIP is the remote address without its port. Forwarding headers are
+// ignored since any client can set them.
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
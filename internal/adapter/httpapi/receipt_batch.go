@@ -0,0 +1,266 @@
+package httpapi
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/ivsanmendez/ControlDeContabilidad/internal/adapter/export"
+	"github.com/ivsanmendez/ControlDeContabilidad/internal/adapter/i18n"
+	"github.com/ivsanmendez/ControlDeContabilidad/internal/domain/receipt"
+)
+
+// batchRetention is how long a finished batch stays available for download.
+const batchRetention = 24 * time.Hour
+
+// batchStore keeps receipt batches in memory while they run and for
+// batchRetention afterwards. The receipts themselves are persisted as they
+// are signed, so losing the store on restart loses only the progress view.
+type batchStore struct {
+	mu      sync.Mutex
+	batches map[string]*receipt.Batch
+	now     func() time.Time
+}
+
+func newBatchStore() *batchStore {
+	return &batchStore{batches: make(map[string]*receipt.Batch), now: time.Now}
+}
+
+func (s *batchStore) add(id string, b *receipt.Batch) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cutoff := s.now().Add(-batchRetention)
+	for k, old := range s.batches {
+		if old.FinishedBefore(cutoff) {
+			delete(s.batches, k)
+		}
+	}
+	s.batches[id] = b
+}
+
+func (s *batchStore) get(id string) (*receipt.Batch, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	b, ok := s.batches[id]
+	return b, ok
+}
+
+type receiptBatchRequest struct {
+	ContributorIDs []int64              `json:"contributor_ids"` // empty: every contributor
+	Year           int                  `json:"year"`
+	Scope          *receiptScopeRequest `json:"scope"`
+	Password       string               `json:"password"`
+	SignerName     string               `json:"signer_name"`
+	CertificateID  int64                `json:"certificate_id"`
+}
+
+// StartReceiptBatch handles POST /receipts/batch. The key is decrypted once
+// up front, so a wrong password fails the request instead of every receipt;
+// signing then runs in the background and is polled via ReceiptBatch.
+func (h *ReceiptHandler) StartReceiptBatch(w http.ResponseWriter, r *http.Request) {
+	claims, ok := ClaimsFromContext(r.Context())
+	if !ok {
+		writeErrorT(w, r, h.tr, http.StatusUnauthorized, "no_claims_in_context")
+		return
+	}
+
+	var req receiptBatchRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeErrorT(w, r, h.tr, http.StatusBadRequest, "invalid_request_body")
+		return
+	}
+	if req.Password == "" {
+		writeErrorT(w, r, h.tr, http.StatusBadRequest, "password_required")
+		return
+	}
+	if req.SignerName == "" {
+		writeErrorT(w, r, h.tr, http.StatusBadRequest, "signer_name_required")
+		return
+	}
+
+	scope := receipt.YearScope()
+	if req.Scope != nil {
+		var err error
+		scope, err = receipt.NewScope(req.Scope.Kind, req.Scope.ContributionID, req.Scope.FromMonth, req.Scope.ToMonth, req.Scope.CategoryID)
+		if err != nil {
+			writeErrorT(w, r, h.tr, http.StatusUnprocessableEntity, receiptScopeKey(err))
+			return
+		}
+	}
+
+	ids := req.ContributorIDs
+	if len(ids) == 0 {
+		contributors, err := h.contributorSvc.ListContributors(r.Context())
+		if err != nil {
+			writeErrorT(w, r, h.tr, http.StatusInternalServerError, "failed_to_list_contributors")
+			return
+		}
+		for _, c := range contributors {
+			ids = append(ids, c.ID)
+		}
+	}
+
+	id := rand.Text()
+	batch, err := receipt.NewBatch(id, claims.UserID, req.Year, scope, ids)
+	if err != nil {
+		writeErrorT(w, r, h.tr, http.StatusUnprocessableEntity, receiptBatchKey(err))
+		return
+	}
+
+	signer, err := h.signerFor(r.Context(), claims.UserID, req.CertificateID)
+	if err != nil {
+		writeCertificateErr(w, r, h.tr, err)
+		return
+	}
+	if !signer.Available() {
+		writeErrorT(w, r, h.tr, http.StatusServiceUnavailable, "receipt_signing_not_configured")
+		return
+	}
+
+	sign, err := signer.Unlock(req.Password)
+	if err != nil {
+		if key, ok := certificateValidityKey(err); ok {
+			writeErrorT(w, r, h.tr, http.StatusUnprocessableEntity, key)
+			return
+		}
+		if errors.Is(err, receipt.ErrInvalidPassword) {
+			writeErrorT(w, r, h.tr, http.StatusUnauthorized, "invalid_certificate_password")
+			return
+		}
+		writeErrorT(w, r, h.tr, http.StatusInternalServerError, "failed_to_sign_receipt")
+		return
+	}
+
+	h.batches.add(id, batch)
+	lang := i18n.LangFromRequest(r)
+	go batch.Run(context.Background(), func(ctx context.Context, contributorID int64) (string, error) {
+		rf, _, err := h.issueReceipt(ctx, sign, signer.Certificate(), claims.UserID, contributorID, req.Year, scope, req.SignerName, true)
+		if err != nil {
+			var ie *issueError
+			if errors.As(err, &ie) && ie.key != "" {
+				log.Printf("receipt batch %s: contributor %d: %v", id, contributorID, ie.err)
+				return "", errors.New(h.tr.T(lang, ie.key))
+			}
+			return "", err
+		}
+		return rf.Folio, nil
+	})
+
+	w.Header().Set("Location", "/receipts/batch/"+id)
+	writeJSON(w, http.StatusAccepted, batch.Progress())
+}
+
+// ReceiptBatch handles GET /receipts/batch/{id}, reporting per-contributor
+// progress. Only the user who started the batch can see it.
+func (h *ReceiptHandler) ReceiptBatch(w http.ResponseWriter, r *http.Request) {
+	batch, ok := h.ownBatch(w, r)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, batch.Progress())
+}
+
+// ReceiptBatchZIP handles GET /receipts/batch/{id}/zip: one PDF per signed
+// receipt, rendered from the stored canonical JSON like ReceiptPDF.
+func (h *ReceiptHandler) ReceiptBatchZIP(w http.ResponseWriter, r *http.Request) {
+	batch, ok := h.ownBatch(w, r)
+	if !ok {
+		return
+	}
+	folios, err := batch.Folios()
+	if err != nil {
+		writeErrorT(w, r, h.tr, http.StatusConflict, "receipt_batch_running")
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := h.writeBatchZIP(r.Context(), &buf, i18n.LangFromRequest(r), folios); err != nil {
+		log.Printf("receipt batch %s: build zip: %v", r.PathValue("id"), err)
+		writeErrorT(w, r, h.tr, http.StatusInternalServerError, "report_export_failed")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="receipts-%d.zip"`, batch.Progress().Year))
+	w.WriteHeader(http.StatusOK)
+	w.Write(buf.Bytes())
+}
+
+func (h *ReceiptHandler) ownBatch(w http.ResponseWriter, r *http.Request) (*receipt.Batch, bool) {
+	claims, ok := ClaimsFromContext(r.Context())
+	if !ok {
+		writeErrorT(w, r, h.tr, http.StatusUnauthorized, "no_claims_in_context")
+		return nil, false
+	}
+	batch, ok := h.batches.get(r.PathValue("id"))
+	if !ok || batch.Progress().UserID != claims.UserID {
+		writeErrorT(w, r, h.tr, http.StatusNotFound, "receipt_batch_not_found")
+		return nil, false
+	}
+	return batch, true
+}
+
+func (h *ReceiptHandler) writeBatchZIP(ctx context.Context, dst *bytes.Buffer, lang string, folios []string) error {
+	zw := zip.NewWriter(dst)
+	for _, folio := range folios {
+		rf, err := h.receiptSvc.VerifyFolio(ctx, folio)
+		if err != nil {
+			return fmt.Errorf("load receipt %s: %w", folio, err)
+		}
+		var data receiptData
+		if err := json.Unmarshal(rf.CanonicalJSON, &data); err != nil {
+			return fmt.Errorf("decode receipt %s: %w", folio, err)
+		}
+		f, err := zw.Create(folio + ".pdf")
+		if err != nil {
+			return err
+		}
+		if err := renderDocument(f, h.tr, lang, h.header, export.FormatPDF, receiptDocument(rf, &data, h.verifyURL(folio))); err != nil {
+			return fmt.Errorf("render receipt %s: %w", folio, err)
+		}
+	}
+	return zw.Close()
+}
+
+// receiptScopeKey maps a rejected receipt scope to its message key.
+func receiptScopeKey(err error) string {
+	switch {
+	case errors.Is(err, receipt.ErrScopeContributionRequired):
+		return "receipt_scope_contribution_required"
+	case errors.Is(err, receipt.ErrScopeCategoryRequired):
+		return "receipt_scope_category_required"
+	case errors.Is(err, receipt.ErrInvalidScopeMonths):
+		return "invalid_receipt_scope_months"
+	default:
+		return "invalid_receipt_scope_kind"
+	}
+}
+
+// receiptBatchKey maps a batch refused by receipt.NewBatch to its message key.
+func receiptBatchKey(err error) string {
+	switch {
+	case errors.Is(err, receipt.ErrBatchEmpty):
+		return "receipt_batch_empty"
+	case errors.Is(err, receipt.ErrBatchTooLarge):
+		return "receipt_batch_too_large"
+	case errors.Is(err, receipt.ErrInvalidContributorID):
+		return "invalid_contributor_id"
+	case errors.Is(err, receipt.ErrInvalidUserID):
+		return "invalid_user_id"
+	case errors.Is(err, receipt.ErrInvalidReceiptYear):
+		return "invalid_year"
+	case errors.Is(err, receipt.ErrBatchScopeContribution):
+		return "receipt_batch_scope_contribution"
+	default:
+		return "receipt_query_failed"
+	}
+}
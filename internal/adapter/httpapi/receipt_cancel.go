@@ -0,0 +1,93 @@
+package httpapi
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/ivsanmendez/ControlDeContabilidad/internal/domain/receipt"
+)
+
+type receiptCancelRequest struct {
+	Reason           string `json:"reason"`
+	ReplacementFolio string `json:"replacement_folio"`
+	Password         string `json:"password"`
+	CertificateID    int64  `json:"certificate_id"`
+}
+
+// CancelReceipt handles POST /receipts/cancel/{folio}. The receipt is kept;
+// a signed cancellation record is added and audited.
+func (h *ReceiptHandler) CancelReceipt(w http.ResponseWriter, r *http.Request) {
+	claims, ok := ClaimsFromContext(r.Context())
+	if !ok {
+		writeErrorT(w, r, h.tr, http.StatusUnauthorized, "no_claims_in_context")
+		return
+	}
+
+	var req receiptCancelRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeErrorT(w, r, h.tr, http.StatusBadRequest, "invalid_request_body")
+		return
+	}
+
+	signer, err := h.signerFor(r.Context(), claims.UserID, req.CertificateID)
+	if err != nil {
+		writeCertificateErr(w, r, h.tr, err)
+		return
+	}
+
+	c, err := h.receiptSvc.Cancel(r.Context(), signer, claims.UserID, r.PathValue("folio"), req.Reason, req.ReplacementFolio, req.Password, auditInfoFromRequest(r))
+	if err != nil {
+		h.writeErr(w, r, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, map[string]any{
+		"folio":             c.Folio,
+		"reason":            c.Reason,
+		"replacement_folio": c.ReplacementFolio,
+		"cancelled_by":      c.UserID,
+		"cancelled_at":      c.CancelledAt,
+		"canonical_json":    base64.StdEncoding.EncodeToString(c.CanonicalJSON),
+		"signature":         base64.StdEncoding.EncodeToString(c.Signature),
+		"certificate":       base64.StdEncoding.EncodeToString(c.Certificate),
+	})
+}
+
+func (h *ReceiptHandler) writeErr(w http.ResponseWriter, r *http.Request, err error) {
+	if key, ok := certificateValidityKey(err); ok {
+		writeErrorT(w, r, h.tr, http.StatusUnprocessableEntity, key)
+		return
+	}
+	switch {
+	case errors.Is(err, receipt.ErrNotFound):
+		writeErrorT(w, r, h.tr, http.StatusNotFound, "receipt_folio_not_found")
+	case errors.Is(err, receipt.ErrReasonRequired):
+		writeErrorT(w, r, h.tr, http.StatusBadRequest, "cancellation_reason_required")
+	case errors.Is(err, receipt.ErrPasswordRequired):
+		writeErrorT(w, r, h.tr, http.StatusBadRequest, "password_required")
+	case errors.Is(err, receipt.ErrSigningUnavailable):
+		writeErrorT(w, r, h.tr, http.StatusServiceUnavailable, "receipt_signing_not_configured")
+	case errors.Is(err, receipt.ErrInvalidPassword):
+		writeErrorT(w, r, h.tr, http.StatusUnauthorized, "invalid_certificate_password")
+	case errors.Is(err, receipt.ErrAlreadyCancelled):
+		writeErrorT(w, r, h.tr, http.StatusConflict, "receipt_already_cancelled")
+	case errors.Is(err, receipt.ErrInvalidPage):
+		writeErrorT(w, r, h.tr, http.StatusBadRequest, "invalid_pagination")
+	case errors.Is(err, receipt.ErrInvalidDateRange):
+		writeErrorT(w, r, h.tr, http.StatusBadRequest, "invalid_date_range")
+	case errors.Is(err, receipt.ErrReplacementNotFound):
+		writeErrorT(w, r, h.tr, http.StatusUnprocessableEntity, "replacement_receipt_not_found")
+	case errors.Is(err, receipt.ErrReplacementIsSelf):
+		writeErrorT(w, r, h.tr, http.StatusUnprocessableEntity, "replacement_receipt_is_self")
+	case errors.Is(err, receipt.ErrReplacementMismatch):
+		writeErrorT(w, r, h.tr, http.StatusUnprocessableEntity, "replacement_receipt_mismatch")
+	case errors.Is(err, receipt.ErrReplacementCancelled):
+		writeErrorT(w, r, h.tr, http.StatusUnprocessableEntity, "replacement_receipt_cancelled")
+	case errors.Is(err, receipt.ErrInvalidUserID):
+		writeErrorT(w, r, h.tr, http.StatusUnprocessableEntity, "invalid_user_id")
+	default:
+		writeErrorT(w, r, h.tr, http.StatusInternalServerError, "receipt_query_failed")
+	}
+}
@@ -1,15 +1,17 @@
 package httpapi
 
 import (
+	"context"
 	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"log"
 	"net/http"
-	"strings"
 	"time"
 
+	"github.com/ivsanmendez/ControlDeContabilidad/internal/adapter/export"
 	"github.com/ivsanmendez/ControlDeContabilidad/internal/adapter/i18n"
+	"github.com/ivsanmendez/ControlDeContabilidad/internal/domain/contribution"
 	"github.com/ivsanmendez/ControlDeContabilidad/internal/domain/receipt"
 	"github.com/ivsanmendez/ControlDeContabilidad/internal/port"
 )
@@ -21,13 +23,29 @@ type ReceiptHandler struct {
 	receiptSvc     port.ReceiptFolioService
 	signer         port.ReceiptSigner
 	tr             *i18n.Translator
+	header         export.Header
+	publicURL      string
+	batches        *batchStore
+	certSvc        port.SigningCertificateService
 }
 
 type receiptSignRequest struct {
-	ContributorID int64  `json:"contributor_id"`
-	Year          int    `json:"year"`
-	Password      string `json:"password"`
-	SignerName    string `json:"signer_name"`
+	ContributorID int64                `json:"contributor_id"`
+	Year          int                  `json:"year"`
+	Scope         *receiptScopeRequest `json:"scope"`
+	Password      string               `json:"password"`
+	SignerName    string               `json:"signer_name"`
+	CertificateID int64                `json:"certificate_id"` // optional: see signerFor
+}
+
+// receiptScopeRequest narrows a receipt to part of the year; omitted, the
+// receipt covers every contribution of the year.
+type receiptScopeRequest struct {
+	Kind           receipt.ScopeKind `json:"kind"`
+	ContributionID *int64            `json:"contribution_id"`
+	FromMonth      int               `json:"from_month"`
+	ToMonth        int               `json:"to_month"`
+	CategoryID     *int64            `json:"category_id"`
 }
 
 type receiptPayment struct {
@@ -42,6 +60,7 @@ type receiptData struct {
 	HouseNumber     string           `json:"house_number"`
 	ContributorName string           `json:"contributor_name"`
 	Year            int              `json:"year"`
+	Scope           receipt.Scope    `json:"scope"`
 	Payments        []receiptPayment `json:"payments"`
 	Total           float64          `json:"total"`
 	SignerName      string           `json:"signer_name"`
@@ -57,11 +76,6 @@ type receiptSignatureResponse struct {
 
 // ReceiptSignature handles POST /contributions/receipt-signature.
 func (h *ReceiptHandler) ReceiptSignature(w http.ResponseWriter, r *http.Request) {
-	if !h.signer.Available() {
-		writeErrorT(w, r, h.tr, http.StatusServiceUnavailable, "receipt_signing_not_configured")
-		return
-	}
-
 	var req receiptSignRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		writeErrorT(w, r, h.tr, http.StatusBadRequest, "invalid_request_body")
@@ -81,69 +95,145 @@ func (h *ReceiptHandler) ReceiptSignature(w http.ResponseWriter, r *http.Request
 		return
 	}
 
+	scope := receipt.YearScope()
+	if req.Scope != nil {
+		var err error
+		scope, err = receipt.NewScope(req.Scope.Kind, req.Scope.ContributionID, req.Scope.FromMonth, req.Scope.ToMonth, req.Scope.CategoryID)
+		if err != nil {
+			writeErrorT(w, r, h.tr, http.StatusUnprocessableEntity, receiptScopeKey(err))
+			return
+		}
+	}
+
 	claims, ok := ClaimsFromContext(r.Context())
 	if !ok {
 		writeErrorT(w, r, h.tr, http.StatusUnauthorized, "no_claims_in_context")
 		return
 	}
 
-	// Fetch contributor info
-	contrib, err := h.contributorSvc.GetContributor(r.Context(), req.ContributorID)
+	signer, err := h.signerFor(r.Context(), claims.UserID, req.CertificateID)
 	if err != nil {
-		writeErrorT(w, r, h.tr, http.StatusNotFound, "contributor_not_found")
+		writeCertificateErr(w, r, h.tr, err)
+		return
+	}
+	if !signer.Available() {
+		writeErrorT(w, r, h.tr, http.StatusServiceUnavailable, "receipt_signing_not_configured")
 		return
 	}
 
-	// Fetch contributions for that year
-	contributions, err := h.contribSvc.ListContributions(r.Context(), req.ContributorID, req.Year)
+	sign := func(data []byte) ([]byte, error) { return signer.Sign(data, req.Password) }
+	rf, data, err := h.issueReceipt(r.Context(), sign, signer.Certificate(), claims.UserID, req.ContributorID, req.Year, scope, req.SignerName, false)
 	if err != nil {
-		writeErrorT(w, r, h.tr, http.StatusInternalServerError, "failed_to_load_contributions")
+		var ie *issueError
+		if errors.As(err, &ie) && ie.key != "" {
+			writeErrorT(w, r, h.tr, ie.status, ie.key)
+			return
+		}
+		writeErrorT(w, r, h.tr, http.StatusUnprocessableEntity, "receipt_scope_empty")
 		return
 	}
 
+	resp := receiptSignatureResponse{
+		Folio:       rf.Folio,
+		Data:        *data,
+		Signature:   base64.StdEncoding.EncodeToString(rf.Signature),
+		Certificate: base64.StdEncoding.EncodeToString(rf.Certificate),
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// issueError is a failed receipt issue together with the response it maps
+// to. Only receipt.ErrEmptyScope has no key: batches skip the contributor
+// rather than report a failure.
+type issueError struct {
+	status int
+	key    string
+	err    error
+}
+
+func (e *issueError) Error() string {
+	if e.err != nil {
+		return e.err.Error()
+	}
+	return e.key
+}
+
+func (e *issueError) Unwrap() error { return e.err }
+
+func (h *ReceiptHandler) signerFor(ctx context.Context, userID, certID int64) (port.ReceiptSigner, error) {
+	return signerFor(ctx, h.certSvc, h.signer, userID, certID)
+}
+
+// issueReceipt builds, signs and stores the receipt of one contributor. Only
+// a year receipt may have no payments, unless requireCovered is set.
+func (h *ReceiptHandler) issueReceipt(ctx context.Context, sign func([]byte) ([]byte, error), certificate []byte, userID, contributorID int64, year int, scope receipt.Scope, signerName string, requireCovered bool) (*receipt.ReceiptFolio, *receiptData, error) {
+	// Fetch contributor info
+	contrib, err := h.contributorSvc.GetContributor(ctx, contributorID)
+	if err != nil {
+		return nil, nil, &issueError{http.StatusNotFound, "contributor_not_found", err}
+	}
+
+	// Fetch contributions for that year
+	contributions, err := h.contribSvc.ListContributions(ctx, contributorID, year)
+	if err != nil {
+		return nil, nil, &issueError{http.StatusInternalServerError, "failed_to_load_contributions", err}
+	}
+
+	// Keep only the contributions the receipt covers
+	var covered []contribution.ContributionDetail
+	for _, c := range contributions {
+		if scope.Includes(c.ID, c.CategoryID, c.Month) {
+			covered = append(covered, c)
+		}
+	}
+	if (requireCovered || scope.Kind != receipt.ScopeYear) && len(covered) == 0 {
+		return nil, nil, &issueError{http.StatusUnprocessableEntity, "", receipt.ErrEmptyScope}
+	}
+
 	// Generate security folio
 	now := time.Now().UTC()
-	folio, seq, suffix, err := h.receiptSvc.GenerateNewFolio(r.Context(), now.Year())
+	folio, seq, suffix, err := h.receiptSvc.GenerateNewFolio(ctx, now.Year())
 	if err != nil {
-		writeErrorT(w, r, h.tr, http.StatusInternalServerError, "failed_to_generate_folio")
-		return
+		return nil, nil, &issueError{http.StatusInternalServerError, "failed_to_generate_folio", err}
 	}
 
 	// Build receipt data (folio included in canonical JSON)
 	var payments []receiptPayment
 	var total float64
-	for _, c := range contributions {
+	for _, c := range covered {
 		payments = append(payments, receiptPayment{Month: c.Month, Amount: c.Amount, CategoryName: c.CategoryName})
 		total += c.Amount
 	}
 
 	data := receiptData{
 		Folio:           folio,
-		ContributorID:   req.ContributorID,
+		ContributorID:   contributorID,
 		HouseNumber:     contrib.HouseNumber,
 		ContributorName: contrib.Name,
-		Year:            req.Year,
+		Year:            year,
+		Scope:           scope,
 		Payments:        payments,
 		Total:           total,
-		SignerName:      req.SignerName,
+		SignerName:      signerName,
 		GeneratedAt:     now,
 	}
 
 	// Build canonical JSON for signing
 	canonical, err := json.Marshal(data)
 	if err != nil {
-		writeErrorT(w, r, h.tr, http.StatusInternalServerError, "failed_to_serialize_receipt_data")
-		return
+		return nil, nil, &issueError{http.StatusInternalServerError, "failed_to_serialize_receipt_data", err}
 	}
 
-	sig, err := h.signer.Sign(canonical, req.Password)
+	sig, err := sign(canonical)
 	if err != nil {
-		if strings.Contains(err.Error(), "decrypt") {
-			writeErrorT(w, r, h.tr, http.StatusUnauthorized, "invalid_certificate_password")
-			return
+		if key, ok := certificateValidityKey(err); ok {
+			return nil, nil, &issueError{http.StatusUnprocessableEntity, key, err}
 		}
-		writeErrorT(w, r, h.tr, http.StatusInternalServerError, "failed_to_sign_receipt")
-		return
+		if errors.Is(err, receipt.ErrInvalidPassword) {
+			return nil, nil, &issueError{http.StatusUnauthorized, "invalid_certificate_password", err}
+		}
+		return nil, nil, &issueError{http.StatusInternalServerError, "failed_to_sign_receipt", err}
 	}
 
 	// Persist the signed receipt folio
@@ -152,32 +242,25 @@ func (h *ReceiptHandler) ReceiptSignature(w http.ResponseWriter, r *http.Request
 		YearIssued:    now.Year(),
 		SeqNumber:     seq,
 		UUIDSuffix:    suffix,
-		ContributorID: req.ContributorID,
-		ReceiptYear:   req.Year,
-		SignerName:    req.SignerName,
-		UserID:        claims.UserID,
+		ContributorID: contributorID,
+		ReceiptYear:   year,
+		Scope:         scope,
+		SignerName:    signerName,
+		UserID:        userID,
 		CanonicalJSON: canonical,
 		Signature:     sig,
-		Certificate:   h.signer.Certificate(),
+		Certificate:   certificate,
 		SignedAt:      now,
 	}
-	if err := h.receiptSvc.SaveFolio(r.Context(), &rf); err != nil {
+	if err := h.receiptSvc.SaveFolio(ctx, &rf); err != nil {
 		log.Printf("WARNING: receipt signed but failed to persist folio %s: %v", folio, err)
-		writeErrorT(w, r, h.tr, http.StatusInternalServerError, "failed_to_save_receipt")
-		return
+		return nil, nil, &issueError{http.StatusInternalServerError, "failed_to_save_receipt", err}
 	}
-
-	resp := receiptSignatureResponse{
-		Folio:       folio,
-		Data:        data,
-		Signature:   base64.StdEncoding.EncodeToString(sig),
-		Certificate: base64.StdEncoding.EncodeToString(h.signer.Certificate()),
-	}
-
-	writeJSON(w, http.StatusOK, resp)
+	return &rf, &data, nil
 }
 
-// VerifyReceipt handles GET /receipts/verify/{folio}.
+// VerifyReceipt handles GET /receipts/verify/{folio}. Besides the stored
+// blobs it returns the verdict of checking the signature and certificate.
 func (h *ReceiptHandler) VerifyReceipt(w http.ResponseWriter, r *http.Request) {
 	folio := r.PathValue("folio")
 	if folio == "" {
@@ -185,7 +268,7 @@ func (h *ReceiptHandler) VerifyReceipt(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	rf, err := h.receiptSvc.VerifyFolio(r.Context(), folio)
+	rf, verdict, err := h.receiptSvc.VerifySignature(r.Context(), folio)
 	if err != nil {
 		if errors.Is(err, receipt.ErrNotFound) {
 			writeErrorT(w, r, h.tr, http.StatusNotFound, "receipt_folio_not_found")
@@ -199,10 +282,12 @@ func (h *ReceiptHandler) VerifyReceipt(w http.ResponseWriter, r *http.Request) {
 		"folio":          rf.Folio,
 		"contributor_id": rf.ContributorID,
 		"receipt_year":   rf.ReceiptYear,
+		"scope":          rf.Scope,
 		"signer_name":   rf.SignerName,
 		"signed_at":     rf.SignedAt,
 		"canonical_json": base64.StdEncoding.EncodeToString(rf.CanonicalJSON),
 		"signature":      base64.StdEncoding.EncodeToString(rf.Signature),
 		"certificate":    base64.StdEncoding.EncodeToString(rf.Certificate),
+		"verdict":        verdict,
 	})
 }
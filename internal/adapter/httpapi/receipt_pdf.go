@@ -0,0 +1,113 @@
+package httpapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/ivsanmendez/ControlDeContabilidad/internal/adapter/export"
+	"github.com/ivsanmendez/ControlDeContabilidad/internal/domain/receipt"
+)
+
+// ReceiptPDF handles GET /receipts/pdf/{folio}. The receipt is rendered from
+// its stored canonical JSON rather than current contributions, so every
+// reprint matches what was signed byte for byte. The folio comes last because
+// /receipts/{folio}/pdf would overlap GET /receipts/batch/{id}.
+func (h *ReceiptHandler) ReceiptPDF(w http.ResponseWriter, r *http.Request) {
+	folio := r.PathValue("folio")
+	if folio == "" {
+		writeErrorT(w, r, h.tr, http.StatusBadRequest, "folio_required")
+		return
+	}
+
+	rf, err := h.receiptSvc.VerifyFolio(r.Context(), folio)
+	if err != nil {
+		if errors.Is(err, receipt.ErrNotFound) {
+			writeErrorT(w, r, h.tr, http.StatusNotFound, "receipt_folio_not_found")
+			return
+		}
+		log.Printf("receipt %s pdf: %v", folio, err)
+		writeErrorT(w, r, h.tr, http.StatusInternalServerError, "receipt_query_failed")
+		return
+	}
+
+	var data receiptData
+	if err := json.Unmarshal(rf.CanonicalJSON, &data); err != nil {
+		writeErrorT(w, r, h.tr, http.StatusInternalServerError, "report_export_failed")
+		return
+	}
+
+	writeDocument(w, r, h.tr, h.header, export.FormatPDF, rf.Folio, receiptDocument(rf, &data, h.verifyURL(rf.Folio)))
+}
+
+// verifyURL is the public verification address printed in the receipt QR
+// code. Without a public base URL the QR carries just the folio.
+func (h *ReceiptHandler) verifyURL(folio string) string {
+	if h.publicURL == "" {
+		return folio
+	}
+	return strings.TrimSuffix(h.publicURL, "/") + "/public/receipts/" + folio
+}
+
+func receiptDocument(rf *receipt.ReceiptFolio, data *receiptData, qr string) func(t translate) export.Document {
+	return func(t translate) export.Document {
+		contributor := export.Table{Columns: cols(t, "col_house_number", "col_contributor", "#col_receipt_year", "col_scope")}
+		contributor.Rows = append(contributor.Rows, export.Row{Cells: []any{data.HouseNumber, data.ContributorName, data.Year, scopeLabel(t, data)}})
+
+		payments := export.Table{
+			Title:   t("export_payments"),
+			Columns: cols(t, "col_month", "col_category", "#col_amount"),
+		}
+		for _, p := range data.Payments {
+			payments.Rows = append(payments.Rows, export.Row{Cells: []any{monthName(t, p.Month), p.CategoryName, p.Amount}})
+		}
+		payments.Rows = append(payments.Rows, export.Row{Cells: []any{t("col_total"), nil, data.Total}, Total: true})
+
+		d := export.Document{
+			Title:       fmt.Sprintf("%s %d", t("export_receipt"), data.Year),
+			Subtitle:    fmt.Sprintf("%s: %s", t("sig_folio"), rf.Folio),
+			GeneratedAt: data.GeneratedAt,
+			Tables:      []export.Table{contributor, payments},
+			Notes: signatureNotes(t, rf.Folio, data.SignerName, rf.SignedAt, rf.CanonicalJSON, rf.Signature, rf.Certificate,
+				"/public/receipts/"+rf.Folio),
+			QR: qr,
+		}
+		if c := rf.Cancellation; c != nil {
+			d.Subtitle += " · " + t("sig_cancelled")
+			notes := []string{
+				fmt.Sprintf("%s: %s", t("sig_cancelled_at"), c.CancelledAt.UTC().Format("2006-01-02 15:04 MST")),
+				fmt.Sprintf("%s: %s", t("sig_cancel_reason"), c.Reason),
+			}
+			if c.ReplacementFolio != "" {
+				notes = append(notes, fmt.Sprintf("%s: %s", t("sig_replacement"), c.ReplacementFolio))
+			}
+			d.Notes = append(notes, d.Notes...)
+		}
+		return d
+	}
+}
+
+// scopeLabel describes which payments of the year a receipt covers. Receipts
+// signed before scopes existed carry none and covered the whole year.
+func scopeLabel(t translate, data *receiptData) string {
+	switch data.Scope.Kind {
+	case receipt.ScopeContribution:
+		if len(data.Payments) == 1 {
+			p := data.Payments[0]
+			return fmt.Sprintf("%s · %s", monthName(t, p.Month), p.CategoryName)
+		}
+	case receipt.ScopeMonths:
+		if data.Scope.FromMonth == data.Scope.ToMonth {
+			return monthName(t, data.Scope.FromMonth)
+		}
+		return fmt.Sprintf("%s – %s", monthName(t, data.Scope.FromMonth), monthName(t, data.Scope.ToMonth))
+	case receipt.ScopeCategory:
+		if len(data.Payments) > 0 {
+			return data.Payments[0].CategoryName
+		}
+	}
+	return t("receipt_scope_year")
+}
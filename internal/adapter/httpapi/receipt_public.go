@@ -0,0 +1,176 @@
+package httpapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html/template"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ivsanmendez/ControlDeContabilidad/internal/adapter/i18n"
+	"github.com/ivsanmendez/ControlDeContabilidad/internal/domain/receipt"
+)
+
+// publicReceipt is what anyone holding a folio may see. It deliberately
+// leaves out the contributor's name, phone and payment breakdown, and the
+// reason of a cancellation.
+type publicReceipt struct {
+	Folio          string    `json:"folio"`
+	HouseNumber    string    `json:"house_number"`
+	ReceiptYear    int       `json:"receipt_year"`
+	Total          float64   `json:"total"`
+	SignerName     string    `json:"signer_name"`
+	SignedAt       time.Time `json:"signed_at"`
+	SignatureValid bool      `json:"signature_valid"`
+
+	Cancelled        bool       `json:"cancelled"`
+	CancelledAt      *time.Time `json:"cancelled_at,omitempty"`
+	ReplacementFolio string     `json:"replacement_folio,omitempty"`
+}
+
+// PublicVerify handles GET /public/receipts/{folio}, the address encoded in
+// the receipt QR code. It needs no login; browsers asking for HTML get a
+// page, anything else gets JSON.
+func (h *ReceiptHandler) PublicVerify(w http.ResponseWriter, r *http.Request) {
+	asHTML := strings.Contains(r.Header.Get("Accept"), "text/html")
+
+	fail := func(status int) {
+		if asHTML {
+			h.writeVerifyPage(w, r, status, nil)
+			return
+		}
+		writeErrorT(w, r, h.tr, status, verifyFailureKey(status))
+	}
+
+	rf, verdict, err := h.receiptSvc.VerifySignature(r.Context(), r.PathValue("folio"))
+	if err != nil {
+		if errors.Is(err, receipt.ErrNotFound) {
+			fail(http.StatusNotFound)
+			return
+		}
+		log.Printf("public receipt verification: %v", err)
+		fail(http.StatusInternalServerError)
+		return
+	}
+
+	var data receiptData
+	if err := json.Unmarshal(rf.CanonicalJSON, &data); err != nil {
+		log.Printf("public receipt verification: decode %s: %v", rf.Folio, err)
+		fail(http.StatusInternalServerError)
+		return
+	}
+	// Valid is false for any problem found: a bad seal, a cancellation, or a
+	// cancellation record whose own seal does not match.
+	pr := publicReceipt{
+		Folio:          rf.Folio,
+		HouseNumber:    data.HouseNumber,
+		ReceiptYear:    rf.ReceiptYear,
+		Total:          data.Total,
+		SignerName:     rf.SignerName,
+		SignedAt:       rf.SignedAt,
+		SignatureValid: verdict.Valid,
+
+		Cancelled:        verdict.Cancelled,
+		CancelledAt:      verdict.CancelledAt,
+		ReplacementFolio: verdict.ReplacementFolio,
+	}
+
+	if asHTML {
+		h.writeVerifyPage(w, r, http.StatusOK, &pr)
+		return
+	}
+	writeJSON(w, http.StatusOK, pr)
+}
+
+// verifyFailureKey is the message for a verification that found no receipt
+// to show: the folio is unknown, or looking it up failed.
+func verifyFailureKey(status int) string {
+	if status == http.StatusNotFound {
+		return "receipt_folio_not_found"
+	}
+	return "receipt_query_failed"
+}
+
+type verifyField struct {
+	Label string
+	Value string
+}
+
+type verifyPage struct {
+	Lang    string
+	Title   string
+	Found   bool
+	Valid   bool
+	Message string
+	Fields  []verifyField
+}
+
+var verifyTemplate = template.Must(template.New("verify").Parse(`<!DOCTYPE html>
+<html lang="{{.Lang}}">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Title}}</title>
+<style>
+body{font-family:system-ui,sans-serif;max-width:32rem;margin:2rem auto;padding:0 1rem;color:#222}
+.status{padding:.75rem 1rem;border-radius:.5rem;margin:1rem 0}
+.ok{background:#e6f4ea;color:#1e6b34}
+.bad{background:#fdecea;color:#8a1c12}
+dl{display:grid;grid-template-columns:max-content 1fr;gap:.4rem 1rem}
+dt{font-weight:600}
+dd{margin:0;word-break:break-all}
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+<p class="status {{if .Valid}}ok{{else}}bad{{end}}">{{.Message}}</p>
+{{if .Found}}<dl>
+{{range .Fields}}<dt>{{.Label}}</dt><dd>{{.Value}}</dd>
+{{end}}</dl>{{end}}
+</body>
+</html>
+`))
+
+// writeVerifyPage renders the public verification page; a nil receipt means
+// the folio does not exist.
+func (h *ReceiptHandler) writeVerifyPage(w http.ResponseWriter, r *http.Request, status int, pr *publicReceipt) {
+	lang := i18n.LangFromRequest(r)
+	t := func(key string) string { return h.tr.T(lang, key) }
+
+	page := verifyPage{Lang: lang, Title: t("receipt_verification_title"), Message: t(verifyFailureKey(status))}
+	if pr != nil {
+		page.Found = true
+		page.Valid = pr.SignatureValid && !pr.Cancelled
+		switch {
+		case pr.Cancelled:
+			page.Message = t("receipt_cancelled_notice")
+		case pr.SignatureValid:
+			page.Message = t("receipt_signature_valid")
+		default:
+			page.Message = t("receipt_signature_invalid")
+		}
+		page.Fields = []verifyField{
+			{t("sig_folio"), pr.Folio},
+			{t("col_house_number"), pr.HouseNumber},
+			{t("col_receipt_year"), fmt.Sprint(pr.ReceiptYear)},
+			{t("col_total"), fmt.Sprintf("%.2f", pr.Total)},
+			{t("sig_signer"), pr.SignerName},
+			{t("sig_signed_at"), pr.SignedAt.UTC().Format("2006-01-02 15:04 MST")},
+		}
+		if pr.CancelledAt != nil {
+			page.Fields = append(page.Fields, verifyField{t("sig_cancelled_at"), pr.CancelledAt.UTC().Format("2006-01-02 15:04 MST")})
+		}
+		if pr.ReplacementFolio != "" {
+			page.Fields = append(page.Fields, verifyField{t("sig_replacement"), pr.ReplacementFolio})
+		}
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(status)
+	if err := verifyTemplate.Execute(w, page); err != nil {
+		log.Printf("render verification page: %v", err)
+	}
+}
@@ -0,0 +1,101 @@
+package httpapi
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/ivsanmendez/ControlDeContabilidad/internal/domain/receipt"
+)
+
+// ListReceipts handles GET /receipts?contributor_id=&year=&signer=&user_id=&from=&to=&limit=&offset=.
+func (h *ReceiptHandler) ListReceipts(w http.ResponseWriter, r *http.Request) {
+	f, ok := h.registryFilter(w, r)
+	if !ok {
+		return
+	}
+	h.writePage(w, r, f)
+}
+
+// ListContributorReceipts handles GET /contributors/{id}/receipts, accepting
+// the same filters and pagination as the registry.
+func (h *ReceiptHandler) ListContributorReceipts(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		writeErrorT(w, r, h.tr, http.StatusBadRequest, "invalid_id")
+		return
+	}
+	f, ok := h.registryFilter(w, r)
+	if !ok {
+		return
+	}
+	f.ContributorID = id
+	h.writePage(w, r, f)
+}
+
+func (h *ReceiptHandler) writePage(w http.ResponseWriter, r *http.Request, f receipt.Filter) {
+	page, err := h.receiptSvc.ListFolios(r.Context(), f)
+	if err != nil {
+		h.writeErr(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, page)
+}
+
+// registryFilter parses the registry query string, writing a 400 on any
+// malformed parameter.
+func (h *ReceiptHandler) registryFilter(w http.ResponseWriter, r *http.Request) (receipt.Filter, bool) {
+	f := receipt.Filter{SignerName: r.URL.Query().Get("signer")}
+	ok := h.idParam(w, r, "contributor_id", "invalid_contributor_id", &f.ContributorID) &&
+		h.idParam(w, r, "user_id", "invalid_user_id", &f.UserID) &&
+		h.intParam(w, r, "year", "invalid_year", &f.ReceiptYear) &&
+		h.intParam(w, r, "limit", "invalid_pagination", &f.Limit) &&
+		h.intParam(w, r, "offset", "invalid_pagination", &f.Offset) &&
+		h.dateParam(w, r, "from", &f.From) &&
+		h.dateParam(w, r, "to", &f.To)
+	return f, ok
+}
+
+// The param helpers leave dst untouched when the parameter is absent.
+
+func (h *ReceiptHandler) intParam(w http.ResponseWriter, r *http.Request, name, errKey string, dst *int) bool {
+	v := r.URL.Query().Get(name)
+	if v == "" {
+		return true
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		writeErrorT(w, r, h.tr, http.StatusBadRequest, errKey)
+		return false
+	}
+	*dst = n
+	return true
+}
+
+func (h *ReceiptHandler) idParam(w http.ResponseWriter, r *http.Request, name, errKey string, dst *int64) bool {
+	v := r.URL.Query().Get(name)
+	if v == "" {
+		return true
+	}
+	n, err := strconv.ParseInt(v, 10, 64)
+	if err != nil {
+		writeErrorT(w, r, h.tr, http.StatusBadRequest, errKey)
+		return false
+	}
+	*dst = n
+	return true
+}
+
+func (h *ReceiptHandler) dateParam(w http.ResponseWriter, r *http.Request, name string, dst *time.Time) bool {
+	v := r.URL.Query().Get(name)
+	if v == "" {
+		return true
+	}
+	t, err := time.Parse("2006-01-02", v)
+	if err != nil {
+		writeErrorT(w, r, h.tr, http.StatusBadRequest, "invalid_date_format")
+		return false
+	}
+	*dst = t
+	return true
+}
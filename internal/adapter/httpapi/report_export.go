@@ -0,0 +1,470 @@
+package httpapi
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ivsanmendez/ControlDeContabilidad/internal/adapter/export"
+	"github.com/ivsanmendez/ControlDeContabilidad/internal/adapter/i18n"
+	"github.com/ivsanmendez/ControlDeContabilidad/internal/domain/annualreport"
+	"github.com/ivsanmendez/ControlDeContabilidad/internal/domain/ledger"
+	"github.com/ivsanmendez/ControlDeContabilidad/internal/domain/receipt"
+	"github.com/ivsanmendez/ControlDeContabilidad/internal/domain/report"
+)
+
+// translate returns the message for key in the language of the request.
+type translate func(key string) string
+
+// writeReport sends v as JSON, or as the CSV, XLSX or PDF requested through
+// ?format= or the Accept header. build lays the report out as tables; it is
+// only called for file exports. filename has no extension.
+func writeReport(w http.ResponseWriter, r *http.Request, tr *i18n.Translator, header export.Header, filename string, v any, build func(t translate) export.Document) {
+	format, err := export.Negotiate(r.URL.Query().Get("format"), r.Header.Get("Accept"))
+	if err != nil {
+		writeErrorT(w, r, tr, http.StatusBadRequest, "invalid_export_format")
+		return
+	}
+	if format == export.FormatJSON {
+		writeJSON(w, http.StatusOK, v)
+		return
+	}
+	writeDocument(w, r, tr, header, format, filename, build)
+}
+
+// writeDocument renders the document built by build as a file download.
+// Documents that set GeneratedAt keep it, so re-rendering stored content
+// gives the same file.
+func writeDocument(w http.ResponseWriter, r *http.Request, tr *i18n.Translator, header export.Header, format export.Format, filename string, build func(t translate) export.Document) {
+	var buf bytes.Buffer
+	if err := renderDocument(&buf, tr, i18n.LangFromRequest(r), header, format, build); err != nil {
+		writeErrorT(w, r, tr, http.StatusInternalServerError, "report_export_failed")
+		return
+	}
+	w.Header().Set("Content-Type", format.ContentType())
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.%s"`, filename, format))
+	w.WriteHeader(http.StatusOK)
+	w.Write(buf.Bytes())
+}
+
+// renderDocument builds the document in lang and writes it in format.
+func renderDocument(dst io.Writer, tr *i18n.Translator, lang string, header export.Header, format export.Format, build func(t translate) export.Document) error {
+	t := func(key string) string { return tr.T(lang, key) }
+	doc := build(t)
+	doc.Header = header
+	if doc.GeneratedAt.IsZero() {
+		doc.GeneratedAt = time.Now()
+	}
+	doc.GeneratedLabel = t("export_generated_at")
+	doc.PageLabel = t("export_page")
+	return export.Write(dst, format, &doc)
+}
+
+func monthName(t translate, m int) string {
+	return t(fmt.Sprintf("month_%d", m))
+}
+
+func cols(t translate, keys ...string) []export.Column {
+	out := make([]export.Column, len(keys))
+	for i, k := range keys {
+		// Keys prefixed with "#" are numeric columns.
+		out[i] = export.Column{Label: t(strings.TrimPrefix(k, "#")), Numeric: strings.HasPrefix(k, "#")}
+	}
+	return out
+}
+
+// --- Report documents ---
+
+func monthlyBalanceDocument(rpt *report.MonthlyBalanceReport) func(t translate) export.Document {
+	return func(t translate) export.Document {
+		summary := export.Table{
+			Title:   t("export_summary"),
+			Columns: cols(t, "col_month", "#col_income", "#col_expenses", "#col_balance", "#col_cumulative_balance"),
+		}
+		summary.Rows = append(summary.Rows, export.Row{Cells: []any{t("col_opening_balance"), nil, nil, nil, rpt.OpeningBalance}})
+		for _, m := range rpt.Months {
+			summary.Rows = append(summary.Rows, export.Row{Cells: []any{monthName(t, m.Month), m.Income, m.Expenses, m.Balance, m.CumulativeBalance}})
+		}
+		summary.Rows = append(summary.Rows, export.Row{
+			Cells: []any{t("col_total"), rpt.TotalIncome, rpt.TotalExpenses, rpt.TotalBalance, rpt.ClosingBalance},
+			Total: true,
+		})
+
+		accounts := export.Table{
+			Title:   t("export_by_account"),
+			Columns: cols(t, "col_account", "#col_opening_balance", "#col_income", "#col_expenses", "#col_transfers_in", "#col_transfers_out", "#col_closing_balance"),
+		}
+		for _, a := range rpt.Accounts {
+			var inc, exp, in, out float64
+			for _, m := range a.Months {
+				inc += m.Income
+				exp += m.Expenses
+				in += m.TransfersIn
+				out += m.TransfersOut
+			}
+			accounts.Rows = append(accounts.Rows, export.Row{Cells: []any{a.AccountName, a.OpeningBalance, inc, exp, in, out, a.ClosingBalance}})
+		}
+
+		return export.Document{
+			Title:    fmt.Sprintf("%s %d", t("export_monthly_balance"), rpt.Year),
+			Subtitle: t("basis_" + string(rpt.Basis)),
+			Tables: []export.Table{
+				summary,
+				categoryTable(t, t("export_income_by_category"), rpt.Categories.Income),
+				categoryTable(t, t("export_expenses_by_category"), rpt.Categories.Expenses),
+				accounts,
+			},
+		}
+	}
+}
+
+func categoryTable(t translate, title string, m report.CategoryMatrix) export.Table {
+	columns := []export.Column{{Label: t("col_category")}}
+	for i := 1; i <= 12; i++ {
+		columns = append(columns, export.Column{Label: monthName(t, i), Numeric: true})
+	}
+	columns = append(columns, export.Column{Label: t("col_total"), Numeric: true})
+
+	tbl := export.Table{Title: title, Columns: columns}
+	for _, c := range m.Categories {
+		cells := []any{c.CategoryName}
+		for _, v := range c.Months {
+			cells = append(cells, v)
+		}
+		tbl.Rows = append(tbl.Rows, export.Row{Cells: append(cells, c.Total)})
+	}
+	totals := []any{t("col_total")}
+	for _, v := range m.MonthTotals {
+		totals = append(totals, v)
+	}
+	tbl.Rows = append(tbl.Rows, export.Row{Cells: append(totals, m.Total), Total: true})
+	return tbl
+}
+
+func supplierSpendingDocument(rpt *report.SupplierSpendingReport) func(t translate) export.Document {
+	return func(t translate) export.Document {
+		tbl := export.Table{Columns: cols(t, "col_supplier", "col_rfc", "#col_expense_count", "#col_total")}
+		for _, s := range rpt.Suppliers {
+			tbl.Rows = append(tbl.Rows, export.Row{Cells: []any{s.SupplierName, s.RFC, s.ExpenseCount, s.Total}})
+		}
+		tbl.Rows = append(tbl.Rows,
+			export.Row{Cells: []any{t("export_unassigned"), "", nil, rpt.Unassigned}},
+			export.Row{Cells: []any{t("col_total"), "", nil, rpt.Total}, Total: true},
+		)
+		return export.Document{
+			Title:  fmt.Sprintf("%s %d", t("export_supplier_spending"), rpt.Year),
+			Tables: []export.Table{tbl},
+		}
+	}
+}
+
+func rangeBalanceDocument(rpt *report.RangeReport) func(t translate) export.Document {
+	return func(t translate) export.Document {
+		tbl := export.Table{Columns: cols(t, "col_period", "#col_income", "#col_expenses", "#col_balance", "#col_cumulative_balance")}
+		tbl.Rows = append(tbl.Rows, export.Row{Cells: []any{t("col_opening_balance"), nil, nil, nil, rpt.OpeningBalance}})
+		for _, p := range rpt.Periods {
+			tbl.Rows = append(tbl.Rows, export.Row{Cells: []any{p.Label, p.Income, p.Expenses, p.Balance, p.CumulativeBalance}})
+		}
+		tbl.Rows = append(tbl.Rows, export.Row{
+			Cells: []any{t("col_total"), rpt.TotalIncome, rpt.TotalExpenses, rpt.TotalBalance, rpt.ClosingBalance},
+			Total: true,
+		})
+		return export.Document{
+			Title:    t("export_range_balance"),
+			Subtitle: fmt.Sprintf("%s – %s, %s", rpt.From.Format("2006-01-02"), rpt.To.Format("2006-01-02"), t("basis_"+string(rpt.Basis))),
+			Tables:   []export.Table{tbl},
+		}
+	}
+}
+
+func yearComparisonDocument(rpt *report.YearComparisonReport) func(t translate) export.Document {
+	return func(t translate) export.Document {
+		columns := []export.Column{{Label: t("col_month")}}
+		for i, y := range rpt.Years {
+			columns = append(columns,
+				export.Column{Label: fmt.Sprintf("%s %d", t("col_income"), y), Numeric: true},
+				export.Column{Label: fmt.Sprintf("%s %d", t("col_expenses"), y), Numeric: true},
+				export.Column{Label: fmt.Sprintf("%s %d", t("col_balance"), y), Numeric: true},
+			)
+			if i > 0 {
+				columns = append(columns, export.Column{Label: fmt.Sprintf("%s %d", t("col_balance_delta"), y), Numeric: true})
+			}
+		}
+
+		row := func(label string, figures []report.ComparisonFigures, total bool) export.Row {
+			cells := []any{label}
+			for i, f := range figures {
+				cells = append(cells, f.Income, f.Expenses, f.Balance)
+				if i > 0 {
+					cells = append(cells, f.BalanceDelta)
+				}
+			}
+			return export.Row{Cells: cells, Total: total}
+		}
+
+		tbl := export.Table{Columns: columns}
+		for _, m := range rpt.Months {
+			tbl.Rows = append(tbl.Rows, row(monthName(t, m.Month), m.Years, false))
+		}
+		tbl.Rows = append(tbl.Rows, row(t("col_total"), rpt.Totals, true))
+
+		return export.Document{
+			Title:    fmt.Sprintf("%s %d", t("export_year_comparison"), rpt.Years[0]),
+			Subtitle: t("basis_" + string(rpt.Basis)),
+			Tables:   []export.Table{tbl},
+		}
+	}
+}
+
+func collectionRateDocument(rpt *report.CollectionRateReport) func(t translate) export.Document {
+	return func(t translate) export.Document {
+		doc := export.Document{Title: fmt.Sprintf("%s %d", t("export_collection_rate"), rpt.Year)}
+		for _, c := range rpt.Categories {
+			tbl := export.Table{
+				Title:   c.CategoryName,
+				Columns: cols(t, "col_month", "#col_expected", "#col_paid", "#col_rate", "col_non_payers"),
+			}
+			for _, m := range c.Months {
+				houses := make([]string, len(m.NonPayers))
+				for i, h := range m.NonPayers {
+					houses[i] = h.HouseNumber
+				}
+				tbl.Rows = append(tbl.Rows, export.Row{Cells: []any{monthName(t, m.Month), m.Expected, m.Paid, m.Rate, strings.Join(houses, ", ")}})
+			}
+			tbl.Rows = append(tbl.Rows, export.Row{Cells: []any{t("col_total"), c.Expected, c.Paid, c.Rate, ""}, Total: true})
+			doc.Tables = append(doc.Tables, tbl)
+		}
+		return doc
+	}
+}
+
+func forecastDocument(rpt *report.ForecastReport) func(t translate) export.Document {
+	return func(t translate) export.Document {
+		months := export.Table{
+			Title:   t("export_summary"),
+			Columns: cols(t, "col_month", "#col_opening_balance", "#col_expected_income", "#col_recurring_expenses", "#col_one_off_expenses", "#col_closing_balance"),
+		}
+		for _, m := range rpt.Months {
+			months.Rows = append(months.Rows, export.Row{Cells: []any{
+				fmt.Sprintf("%s %d", monthName(t, m.Month), m.Year),
+				m.OpeningBalance, m.ExpectedIncome, m.RecurringExpenses, m.OneOffExpenses, m.ClosingBalance,
+			}})
+		}
+
+		income := export.Table{
+			Title:   t("export_expected_income"),
+			Columns: cols(t, "col_category", "#col_average_amount", "#col_rate", "#col_monthly_expected"),
+		}
+		for _, i := range rpt.Income {
+			income.Rows = append(income.Rows, export.Row{Cells: []any{i.CategoryName, i.AverageAmount, i.CollectionRate, i.MonthlyExpected}})
+		}
+
+		recurring := export.Table{
+			Title:   t("col_recurring_expenses"),
+			Columns: cols(t, "col_description", "#col_amount"),
+		}
+		for _, e := range rpt.RecurringExpenses {
+			recurring.Rows = append(recurring.Rows, export.Row{Cells: []any{e.Description, e.Amount}})
+		}
+
+		return export.Document{
+			Title:    t("export_forecast"),
+			Subtitle: fmt.Sprintf("%s: %.2f", t("col_current_balance"), rpt.CurrentBalance),
+			Tables:   []export.Table{months, income, recurring},
+		}
+	}
+}
+
+// --- Ledger statement documents ---
+
+func budgetVarianceDocument(rpt *report.BudgetVarianceReport) func(t translate) export.Document {
+	return func(t translate) export.Document {
+		return export.Document{
+			Title:    fmt.Sprintf("%s %d", t("export_budget_variance"), rpt.Year),
+			Subtitle: t("basis_" + string(rpt.Basis)),
+			Tables:   budgetVarianceTables(t, rpt),
+		}
+	}
+}
+
+func budgetVarianceTables(t translate, rpt *report.BudgetVarianceReport) []export.Table {
+	table := func(title string, lines []report.BudgetVariance, budgeted, actual float64) export.Table {
+		tbl := export.Table{
+			Title:   title,
+			Columns: cols(t, "col_category", "#col_budgeted", "#col_actual", "#col_variance", "#col_variance_pct"),
+		}
+		for _, v := range lines {
+			var pct any
+			if v.VariancePct != nil {
+				pct = *v.VariancePct
+			}
+			tbl.Rows = append(tbl.Rows, export.Row{Cells: []any{v.CategoryName, v.Budgeted, v.Actual, v.Variance, pct}})
+		}
+		tbl.Rows = append(tbl.Rows, export.Row{Cells: []any{t("col_total"), budgeted, actual, actual - budgeted, nil}, Total: true})
+		return tbl
+	}
+	return []export.Table{
+		table(t("export_budget_income"), rpt.Income, rpt.BudgetedIncome, rpt.ActualIncome),
+		table(t("export_budget_expenses"), rpt.Expenses, rpt.BudgetedExpenses, rpt.ActualExpenses),
+	}
+}
+
+func issuedReceiptsTable(t translate, receipts []report.IssuedReceipt) export.Table {
+	tbl := export.Table{
+		Title:   t("export_issued_receipts"),
+		Columns: cols(t, "col_folio", "col_house_number", "col_contributor", "#col_receipt_year", "col_signer", "col_signed_at", "col_status"),
+	}
+	for _, rc := range receipts {
+		var status string
+		if rc.Cancelled {
+			status = t("sig_cancelled")
+			if rc.ReplacementFolio != "" {
+				status = fmt.Sprintf("%s, %s %s", status, strings.ToLower(t("sig_replacement")), rc.ReplacementFolio)
+			}
+		}
+		tbl.Rows = append(tbl.Rows, export.Row{Cells: []any{
+			rc.Folio, rc.HouseNumber, rc.ContributorName, rc.ReceiptYear, rc.SignerName, rc.SignedAt.Format("2006-01-02"), status,
+		}})
+	}
+	tbl.Rows = append(tbl.Rows, export.Row{Cells: []any{t("col_total"), nil, nil, len(receipts), nil, nil, nil}, Total: true})
+	return tbl
+}
+
+// annualReportDocument lays out a signed annual report exactly as issued,
+// followed by the folio, signer and seal needed to verify it.
+func annualReportDocument(d *annualreport.Document, c *annualreport.Content) func(t translate) export.Document {
+	return func(t translate) export.Document {
+		balance := monthlyBalanceDocument(c.Balance)(t)
+		tables := append(balance.Tables, budgetVarianceTables(t, c.Budget)...)
+		tables = append(tables, issuedReceiptsTable(t, c.Receipts))
+
+		return export.Document{
+			Title:       fmt.Sprintf("%s %d", t("export_annual_report"), c.Year),
+			Subtitle:    fmt.Sprintf("%s: %s", t("sig_folio"), d.Folio),
+			GeneratedAt: c.GeneratedAt,
+			Tables:      tables,
+			Notes: signatureNotes(t, d.Folio, d.SignerName, d.SignedAt, d.CanonicalJSON, d.Signature, d.Certificate,
+				"/annual-reports/"+d.Folio),
+		}
+	}
+}
+
+// signatureNotes is the seal block printed under signed documents: who
+// signed and when, the certificate number, the SHA-256 of the signed content
+// and the signature itself, so a printout can be checked against the API.
+func signatureNotes(t translate, folio, signer string, signedAt time.Time, content, signature, cert []byte, verifyPath string) []string {
+	digest := sha256.Sum256(content)
+	notes := []string{
+		fmt.Sprintf("%s: %s", t("sig_folio"), folio),
+		fmt.Sprintf("%s: %s", t("sig_signer"), signer),
+		fmt.Sprintf("%s: %s", t("sig_signed_at"), signedAt.UTC().Format("2006-01-02 15:04 MST")),
+	}
+	if serial, err := receipt.CertificateSerial(cert); err == nil {
+		notes = append(notes, fmt.Sprintf("%s: %s", t("sig_certificate"), serial))
+	}
+	return append(notes,
+		fmt.Sprintf("%s: %s", t("sig_content_hash"), hex.EncodeToString(digest[:])),
+		fmt.Sprintf("%s: %s", t("sig_signature"), base64.StdEncoding.EncodeToString(signature)),
+		fmt.Sprintf("%s: %s", t("sig_verify"), verifyPath),
+	)
+}
+
+func accountRows(accounts []ledger.AccountBalance) []export.Row {
+	rows := make([]export.Row, 0, len(accounts))
+	for _, a := range accounts {
+		rows = append(rows, export.Row{Cells: []any{a.AccountCode, a.AccountName, a.SATCode, a.Debit, a.Credit, a.Balance}})
+	}
+	return rows
+}
+
+func ledgerColumns(t translate) []export.Column {
+	return cols(t, "col_code", "col_account", "col_sat_code", "#col_debit", "#col_credit", "#col_balance")
+}
+
+func trialBalanceDocument(tb *ledger.TrialBalance) func(t translate) export.Document {
+	return func(t translate) export.Document {
+		tbl := export.Table{Columns: ledgerColumns(t), Rows: accountRows(tb.Accounts)}
+		tbl.Rows = append(tbl.Rows, export.Row{Cells: []any{"", t("col_total"), "", tb.TotalDebit, tb.TotalCredit, nil}, Total: true})
+		return export.Document{
+			Title:    t("export_trial_balance"),
+			Subtitle: tb.AsOf.Format("2006-01-02"),
+			Tables:   []export.Table{tbl},
+		}
+	}
+}
+
+func balanceSheetDocument(bs *ledger.BalanceSheet) func(t translate) export.Document {
+	return func(t translate) export.Document {
+		section := func(title string, accounts []ledger.AccountBalance, extra []export.Row, total float64) export.Table {
+			tbl := export.Table{Title: title, Columns: ledgerColumns(t), Rows: accountRows(accounts)}
+			tbl.Rows = append(tbl.Rows, extra...)
+			tbl.Rows = append(tbl.Rows, export.Row{Cells: []any{"", t("col_total"), "", nil, nil, total}, Total: true})
+			return tbl
+		}
+		netIncome := []export.Row{{Cells: []any{"", t("col_net_income"), "", nil, nil, bs.NetIncome}}}
+		return export.Document{
+			Title:    t("export_balance_sheet"),
+			Subtitle: bs.AsOf.Format("2006-01-02"),
+			Tables: []export.Table{
+				section(t("export_assets"), bs.Assets, nil, bs.TotalAssets),
+				section(t("export_liabilities"), bs.Liabilities, nil, bs.TotalLiabilities),
+				section(t("export_equity"), bs.Equity, netIncome, bs.TotalEquity),
+			},
+		}
+	}
+}
+
+func incomeStatementDocument(is *ledger.IncomeStatement) func(t translate) export.Document {
+	return func(t translate) export.Document {
+		income := export.Table{Title: t("col_income"), Columns: ledgerColumns(t), Rows: accountRows(is.Income)}
+		income.Rows = append(income.Rows, export.Row{Cells: []any{"", t("col_total"), "", nil, nil, is.TotalIncome}, Total: true})
+		expenses := export.Table{Title: t("col_expenses"), Columns: ledgerColumns(t), Rows: accountRows(is.Expenses)}
+		expenses.Rows = append(expenses.Rows,
+			export.Row{Cells: []any{"", t("col_total"), "", nil, nil, is.TotalExpenses}, Total: true},
+			export.Row{Cells: []any{"", t("col_net_income"), "", nil, nil, is.NetIncome}, Total: true},
+		)
+		return export.Document{
+			Title:    t("export_income_statement"),
+			Subtitle: fmt.Sprintf("%s – %s", is.From.Format("2006-01-02"), is.To.Format("2006-01-02")),
+			Tables:   []export.Table{income, expenses},
+		}
+	}
+}
+
+func chartBalanceDocument(cb *ledger.ChartBalance) func(t translate) export.Document {
+	return func(t translate) export.Document {
+		accounts := export.Table{Title: t("export_chart_of_accounts"), Columns: ledgerColumns(t)}
+		var walk func(nodes []ledger.AccountNode)
+		walk = func(nodes []ledger.AccountNode) {
+			for _, n := range nodes {
+				name := strings.Repeat("  ", n.Level) + n.AccountName
+				accounts.Rows = append(accounts.Rows, export.Row{
+					Cells: []any{n.AccountCode, name, n.SATCode, n.Debit, n.Credit, n.Balance},
+					Total: n.Level == 0,
+				})
+				walk(n.Children)
+			}
+		}
+		walk(cb.Accounts)
+
+		sat := export.Table{
+			Title:   t("export_by_sat_code"),
+			Columns: cols(t, "col_sat_code", "col_nature", "#col_debit", "#col_credit", "#col_balance"),
+		}
+		for _, s := range cb.BySAT {
+			sat.Rows = append(sat.Rows, export.Row{Cells: []any{s.SATCode, string(s.Nature), s.Debit, s.Credit, s.Balance}})
+		}
+
+		return export.Document{
+			Title:    t("export_chart_balance"),
+			Subtitle: cb.AsOf.Format("2006-01-02"),
+			Tables:   []export.Table{accounts, sat},
+		}
+	}
+}
@@ -1,34 +1,168 @@
 package httpapi
 
 import (
+	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
+	"time"
 
+	"github.com/ivsanmendez/ControlDeContabilidad/internal/adapter/export"
 	"github.com/ivsanmendez/ControlDeContabilidad/internal/adapter/i18n"
 	"github.com/ivsanmendez/ControlDeContabilidad/internal/domain/report"
 	"github.com/ivsanmendez/ControlDeContabilidad/internal/port"
 )
 
+// ReportHandler serves the treasury reports as JSON or as CSV, XLSX and PDF
+// exports (see writeReport).
 type ReportHandler struct {
-	svc port.ReportService
-	tr  *i18n.Translator
+	svc    port.ReportService
+	tr     *i18n.Translator
+	header export.Header
 }
 
+type forecastItemRequest struct {
+	Description string  `json:"description"`
+	Amount      float64 `json:"amount"`
+	Month       string  `json:"month"`
+}
+
+type forecastRequest struct {
+	Months                 int                   `json:"months"`
+	WeightByCollectionRate bool                  `json:"weight_by_collection_rate"`
+	IncomeAdjustmentPct    float64               `json:"income_adjustment_pct"`
+	RecurringExpenses      []forecastItemRequest `json:"recurring_expenses"`
+	OneOffExpenses         []forecastItemRequest `json:"one_off_expenses"`
+}
+
+// MonthlyBalance handles GET /reports/monthly-balance?year=YYYY&basis=cash|accrual.
 func (h *ReportHandler) MonthlyBalance(w http.ResponseWriter, r *http.Request) {
-	yearStr := r.URL.Query().Get("year")
-	if yearStr == "" {
-		writeErrorT(w, r, h.tr, http.StatusBadRequest, "invalid_year")
+	year, ok := h.yearParam(w, r)
+	if !ok {
 		return
 	}
 
-	year, err := strconv.Atoi(yearStr)
+	rpt, err := h.svc.GetMonthlyBalance(r.Context(), year, report.Basis(r.URL.Query().Get("basis")))
 	if err != nil {
-		writeErrorT(w, r, h.tr, http.StatusBadRequest, "invalid_year")
+		switch {
+		case errors.Is(err, report.ErrInvalidYear):
+			writeErrorT(w, r, h.tr, http.StatusBadRequest, "invalid_year")
+		case errors.Is(err, report.ErrInvalidBasis):
+			writeErrorT(w, r, h.tr, http.StatusBadRequest, "invalid_basis")
+		default:
+			writeErrorT(w, r, h.tr, http.StatusInternalServerError, "report_query_failed")
+		}
+		return
+	}
+
+	writeReport(w, r, h.tr, h.header, fmt.Sprintf("monthly-balance-%d", year), rpt, monthlyBalanceDocument(rpt))
+}
+
+// SupplierSpending handles GET /reports/supplier-spending?year=YYYY.
+func (h *ReportHandler) SupplierSpending(w http.ResponseWriter, r *http.Request) {
+	year, ok := h.yearParam(w, r)
+	if !ok {
+		return
+	}
+
+	rpt, err := h.svc.GetSupplierSpending(r.Context(), year)
+	if err != nil {
+		if errors.Is(err, report.ErrInvalidYear) {
+			writeErrorT(w, r, h.tr, http.StatusBadRequest, "invalid_year")
+			return
+		}
+		writeErrorT(w, r, h.tr, http.StatusInternalServerError, "report_query_failed")
+		return
+	}
+
+	writeReport(w, r, h.tr, h.header, fmt.Sprintf("supplier-spending-%d", year), rpt, supplierSpendingDocument(rpt))
+}
+
+// RangeBalance handles GET /reports/range?from=YYYY-MM-DD&to=YYYY-MM-DD&group_by=month&basis=cash|accrual.
+// group_by is one of day, week, month, quarter or year and defaults to month.
+func (h *ReportHandler) RangeBalance(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	from, err := time.Parse("2006-01-02", q.Get("from"))
+	if err != nil {
+		writeErrorT(w, r, h.tr, http.StatusBadRequest, "invalid_date_format")
+		return
+	}
+	to, err := time.Parse("2006-01-02", q.Get("to"))
+	if err != nil {
+		writeErrorT(w, r, h.tr, http.StatusBadRequest, "invalid_date_format")
+		return
+	}
+
+	rpt, err := h.svc.GetRangeBalance(r.Context(), from, to, report.Granularity(q.Get("group_by")), report.Basis(q.Get("basis")))
+	if err != nil {
+		switch {
+		case errors.Is(err, report.ErrInvalidRange):
+			writeErrorT(w, r, h.tr, http.StatusBadRequest, "invalid_date_range")
+		case errors.Is(err, report.ErrInvalidGranularity):
+			writeErrorT(w, r, h.tr, http.StatusBadRequest, "invalid_group_by")
+		case errors.Is(err, report.ErrInvalidBasis):
+			writeErrorT(w, r, h.tr, http.StatusBadRequest, "invalid_basis")
+		default:
+			writeErrorT(w, r, h.tr, http.StatusInternalServerError, "report_query_failed")
+		}
+		return
+	}
+
+	writeReport(w, r, h.tr, h.header, fmt.Sprintf("balance-%s-%s", from.Format("20060102"), to.Format("20060102")), rpt, rangeBalanceDocument(rpt))
+}
+
+// YearComparison handles GET /reports/year-comparison?year=YYYY&prior_years=N&basis=cash|accrual.
+// prior_years defaults to 1, the previous year.
+func (h *ReportHandler) YearComparison(w http.ResponseWriter, r *http.Request) {
+	year, ok := h.yearParam(w, r)
+	if !ok {
+		return
+	}
+	var prior int
+	if v := r.URL.Query().Get("prior_years"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			writeErrorT(w, r, h.tr, http.StatusBadRequest, "invalid_year")
+			return
+		}
+		prior = n
+	}
+
+	rpt, err := h.svc.GetYearComparison(r.Context(), year, prior, report.Basis(r.URL.Query().Get("basis")))
+	if err != nil {
+		switch {
+		case errors.Is(err, report.ErrInvalidYear):
+			writeErrorT(w, r, h.tr, http.StatusBadRequest, "invalid_year")
+		case errors.Is(err, report.ErrInvalidBasis):
+			writeErrorT(w, r, h.tr, http.StatusBadRequest, "invalid_basis")
+		default:
+			writeErrorT(w, r, h.tr, http.StatusInternalServerError, "report_query_failed")
+		}
 		return
 	}
 
-	rpt, err := h.svc.GetMonthlyBalance(r.Context(), year)
+	writeReport(w, r, h.tr, h.header, fmt.Sprintf("year-comparison-%d", year), rpt, yearComparisonDocument(rpt))
+}
+
+// CollectionRate handles GET /reports/collection-rate?year=YYYY&category_id=N.
+// Without category_id every contribution category is included.
+func (h *ReportHandler) CollectionRate(w http.ResponseWriter, r *http.Request) {
+	year, ok := h.yearParam(w, r)
+	if !ok {
+		return
+	}
+	var categoryID int64
+	if v := r.URL.Query().Get("category_id"); v != "" {
+		id, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			writeErrorT(w, r, h.tr, http.StatusBadRequest, "invalid_id")
+			return
+		}
+		categoryID = id
+	}
+
+	rpt, err := h.svc.GetCollectionRate(r.Context(), year, categoryID)
 	if err != nil {
 		if errors.Is(err, report.ErrInvalidYear) {
 			writeErrorT(w, r, h.tr, http.StatusBadRequest, "invalid_year")
@@ -38,5 +172,88 @@ func (h *ReportHandler) MonthlyBalance(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	writeJSON(w, http.StatusOK, rpt)
+	writeReport(w, r, h.tr, h.header, fmt.Sprintf("collection-rate-%d", year), rpt, collectionRateDocument(rpt))
+}
+
+// Forecast handles POST /reports/forecast. The body is the scenario; one-off
+// expenses carry the month they fall in as "YYYY-MM". Omitting
+// recurring_expenses uses the historical monthly average per expense category.
+func (h *ReportHandler) Forecast(w http.ResponseWriter, r *http.Request) {
+	var req forecastRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeErrorT(w, r, h.tr, http.StatusBadRequest, "invalid_request_body")
+		return
+	}
+
+	sc := report.ForecastScenario{
+		Months:                 req.Months,
+		WeightByCollectionRate: req.WeightByCollectionRate,
+		IncomeAdjustmentPct:    req.IncomeAdjustmentPct,
+	}
+	if req.RecurringExpenses != nil {
+		sc.RecurringExpenses = make([]report.ForecastItem, 0, len(req.RecurringExpenses))
+		for _, it := range req.RecurringExpenses {
+			sc.RecurringExpenses = append(sc.RecurringExpenses, report.ForecastItem{Description: it.Description, Amount: it.Amount})
+		}
+	}
+	for _, it := range req.OneOffExpenses {
+		month, err := time.Parse("2006-01", it.Month)
+		if err != nil {
+			writeErrorT(w, r, h.tr, http.StatusBadRequest, "invalid_month_format")
+			return
+		}
+		sc.OneOffExpenses = append(sc.OneOffExpenses, report.ForecastItem{Description: it.Description, Amount: it.Amount, Month: month})
+	}
+
+	rpt, err := h.svc.GetForecast(r.Context(), time.Now(), sc)
+	if err != nil {
+		switch {
+		case errors.Is(err, report.ErrInvalidHorizon):
+			writeErrorT(w, r, h.tr, http.StatusUnprocessableEntity, "invalid_forecast_horizon")
+		case errors.Is(err, report.ErrInvalidItem):
+			writeErrorT(w, r, h.tr, http.StatusUnprocessableEntity, "invalid_forecast_expense")
+		case errors.Is(err, report.ErrInvalidItemMonth):
+			writeErrorT(w, r, h.tr, http.StatusUnprocessableEntity, "invalid_forecast_expense_month")
+		case errors.Is(err, report.ErrInvalidAdjustment):
+			writeErrorT(w, r, h.tr, http.StatusUnprocessableEntity, "invalid_forecast_adjustment")
+		default:
+			writeErrorT(w, r, h.tr, http.StatusInternalServerError, "report_query_failed")
+		}
+		return
+	}
+
+	writeReport(w, r, h.tr, h.header, "cash-flow-forecast", rpt, forecastDocument(rpt))
+}
+
+// BudgetVariance handles GET /reports/budget-variance?year=YYYY&basis=cash|accrual.
+func (h *ReportHandler) BudgetVariance(w http.ResponseWriter, r *http.Request) {
+	year, ok := h.yearParam(w, r)
+	if !ok {
+		return
+	}
+
+	rpt, err := h.svc.GetBudgetVariance(r.Context(), year, report.Basis(r.URL.Query().Get("basis")))
+	if err != nil {
+		switch {
+		case errors.Is(err, report.ErrInvalidYear):
+			writeErrorT(w, r, h.tr, http.StatusBadRequest, "invalid_year")
+		case errors.Is(err, report.ErrInvalidBasis):
+			writeErrorT(w, r, h.tr, http.StatusBadRequest, "invalid_basis")
+		default:
+			writeErrorT(w, r, h.tr, http.StatusInternalServerError, "report_query_failed")
+		}
+		return
+	}
+
+	writeReport(w, r, h.tr, h.header, fmt.Sprintf("budget-variance-%d", year), rpt, budgetVarianceDocument(rpt))
+}
+
+// yearParam parses the required ?year= query parameter, writing a 400 on failure.
+func (h *ReportHandler) yearParam(w http.ResponseWriter, r *http.Request) (int, bool) {
+	year, err := strconv.Atoi(r.URL.Query().Get("year"))
+	if err != nil {
+		writeErrorT(w, r, h.tr, http.StatusBadRequest, "invalid_year")
+		return 0, false
+	}
+	return year, true
 }
@@ -2,7 +2,9 @@ package httpapi
 
 import (
 	"net/http"
+	"time"
 
+	"github.com/ivsanmendez/ControlDeContabilidad/internal/adapter/export"
 	"github.com/ivsanmendez/ControlDeContabilidad/internal/adapter/i18n"
 	jwtadapter "github.com/ivsanmendez/ControlDeContabilidad/internal/adapter/jwt"
 	"github.com/ivsanmendez/ControlDeContabilidad/internal/domain/user"
@@ -10,7 +12,7 @@ import (
 )
 
 // RegisterRoutes wires all HTTP routes onto the given mux.
-func RegisterRoutes(mux *http.ServeMux, expenseSvc port.ExpenseService, authSvc port.AuthService, contribSvc port.ContributionService, contributorSvc port.ContributorService, categorySvc port.CategoryService, expCatSvc port.ExpenseCategoryService, receiptSvc port.ReceiptFolioService, reportSvc port.ReportService, jwtIssuer *jwtadapter.Issuer, signer port.ReceiptSigner, tr *i18n.Translator) {
+func RegisterRoutes(mux *http.ServeMux, expenseSvc port.ExpenseService, authSvc port.AuthService, contribSvc port.ContributionService, contributorSvc port.ContributorService, categorySvc port.CategoryService, expCatSvc port.ExpenseCategoryService, receiptSvc port.ReceiptFolioService, reportSvc port.ReportService, supplierSvc port.SupplierService, pettyCashSvc port.PettyCashService, ledgerSvc port.LedgerService, treasurySvc port.TreasuryService, periodSvc port.PeriodService, budgetSvc port.BudgetService, annualReportSvc port.AnnualReportService, certSvc port.SigningCertificateService, community export.Header, publicURL string, jwtIssuer *jwtadapter.Issuer, signer port.ReceiptSigner, tr *i18n.Translator) {
 	auth := RequireAuth(jwtIssuer, tr)
 	expH := &ExpenseHandler{svc: expenseSvc, tr: tr}
 	authH := &AuthHandler{svc: authSvc, tr: tr}
@@ -18,8 +20,16 @@ func RegisterRoutes(mux *http.ServeMux, expenseSvc port.ExpenseService, authSvc
 	contributorH := &ContributorHandler{svc: contributorSvc, tr: tr}
 	categoryH := &CategoryHandler{svc: categorySvc, tr: tr}
 	expCatH := &ExpenseCategoryHandler{svc: expCatSvc, tr: tr}
-	receiptH := &ReceiptHandler{contribSvc: contribSvc, contributorSvc: contributorSvc, receiptSvc: receiptSvc, signer: signer, tr: tr}
-	reportH := &ReportHandler{svc: reportSvc, tr: tr}
+	receiptH := &ReceiptHandler{contribSvc: contribSvc, contributorSvc: contributorSvc, receiptSvc: receiptSvc, signer: signer, tr: tr, header: community, publicURL: publicURL, batches: newBatchStore(), certSvc: certSvc}
+	reportH := &ReportHandler{svc: reportSvc, tr: tr, header: community}
+	supplierH := &SupplierHandler{svc: supplierSvc, tr: tr}
+	pettyCashH := &PettyCashHandler{svc: pettyCashSvc, tr: tr}
+	ledgerH := &LedgerHandler{svc: ledgerSvc, tr: tr, header: community}
+	treasuryH := &TreasuryHandler{svc: treasurySvc, tr: tr}
+	periodH := &PeriodHandler{svc: periodSvc, tr: tr}
+	budgetH := &BudgetHandler{svc: budgetSvc, tr: tr}
+	annualH := &AnnualReportHandler{svc: annualReportSvc, certSvc: certSvc, signer: signer, tr: tr, header: community}
+	certH := &CertificateHandler{svc: certSvc, tr: tr}
 
 	// Public routes
 	mux.HandleFunc("GET /health", Health)
@@ -27,6 +37,12 @@ func RegisterRoutes(mux *http.ServeMux, expenseSvc port.ExpenseService, authSvc
 	mux.HandleFunc("POST /auth/login", authH.Login)
 	mux.HandleFunc("POST /auth/refresh", authH.Refresh)
 
+	// Public receipt verification (QR code target), rate limited per client IP
+	mux.Handle("GET /public/receipts/{folio}", Chain(
+		http.HandlerFunc(receiptH.PublicVerify),
+		RateLimit(30, time.Minute, tr),
+	))
+
 	// Protected auth routes
 	mux.Handle("POST /auth/logout", Chain(http.HandlerFunc(authH.Logout), auth))
 	mux.Handle("GET /auth/me", Chain(http.HandlerFunc(authH.Me), auth))
@@ -119,6 +135,146 @@ func RegisterRoutes(mux *http.ServeMux, expenseSvc port.ExpenseService, authSvc
 		auth, RequirePermission(user.PermExpenseCategoryDelete, tr),
 	))
 
+	// Protected supplier (proveedor) routes
+	mux.Handle("POST /suppliers", Chain(
+		http.HandlerFunc(supplierH.Create),
+		auth, RequirePermission(user.PermSupplierCreate, tr),
+	))
+	mux.Handle("GET /suppliers", Chain(
+		http.HandlerFunc(supplierH.List),
+		auth, RequirePermission(user.PermSupplierRead, tr),
+	))
+	mux.Handle("GET /suppliers/{id}", Chain(
+		http.HandlerFunc(supplierH.GetByID),
+		auth, RequirePermission(user.PermSupplierRead, tr),
+	))
+	mux.Handle("PUT /suppliers/{id}", Chain(
+		http.HandlerFunc(supplierH.Update),
+		auth, RequirePermission(user.PermSupplierUpdate, tr),
+	))
+	mux.Handle("DELETE /suppliers/{id}", Chain(
+		http.HandlerFunc(supplierH.Delete),
+		auth, RequirePermission(user.PermSupplierDelete, tr),
+	))
+
+	// Petty cash (caja chica): funds are managed by admins, operated by their custodian
+	mux.Handle("POST /petty-cash/funds", Chain(
+		http.HandlerFunc(pettyCashH.CreateFund),
+		auth, RequirePermission(user.PermPettyCashManage, tr),
+	))
+	mux.Handle("GET /petty-cash/funds", Chain(
+		http.HandlerFunc(pettyCashH.ListFunds),
+		auth, RequirePermission(user.PermPettyCashRead, tr),
+	))
+	mux.Handle("GET /petty-cash/funds/{id}", Chain(
+		http.HandlerFunc(pettyCashH.GetFund),
+		auth, RequirePermission(user.PermPettyCashRead, tr),
+	))
+	mux.Handle("PUT /petty-cash/funds/{id}", Chain(
+		http.HandlerFunc(pettyCashH.UpdateFund),
+		auth, RequirePermission(user.PermPettyCashManage, tr),
+	))
+	mux.Handle("POST /petty-cash/funds/{id}/charges", Chain(
+		http.HandlerFunc(pettyCashH.Charge),
+		auth, RequirePermission(user.PermPettyCashOperate, tr),
+	))
+	mux.Handle("GET /petty-cash/funds/{id}/charges", Chain(
+		http.HandlerFunc(pettyCashH.ListCharges),
+		auth, RequirePermission(user.PermPettyCashRead, tr),
+	))
+	mux.Handle("POST /petty-cash/funds/{id}/replenishments", Chain(
+		http.HandlerFunc(pettyCashH.RequestReplenishment),
+		auth, RequirePermission(user.PermPettyCashOperate, tr),
+	))
+	mux.Handle("GET /petty-cash/funds/{id}/replenishments", Chain(
+		http.HandlerFunc(pettyCashH.ListReplenishments),
+		auth, RequirePermission(user.PermPettyCashRead, tr),
+	))
+	mux.Handle("POST /petty-cash/replenishments/{id}/complete", Chain(
+		http.HandlerFunc(pettyCashH.CompleteReplenishment),
+		auth, RequirePermission(user.PermPettyCashManage, tr),
+	))
+	mux.Handle("POST /petty-cash/funds/{id}/reconciliations", Chain(
+		http.HandlerFunc(pettyCashH.Reconcile),
+		auth, RequirePermission(user.PermPettyCashOperate, tr),
+	))
+	mux.Handle("GET /petty-cash/funds/{id}/reconciliations", Chain(
+		http.HandlerFunc(pettyCashH.ListReconciliations),
+		auth, RequirePermission(user.PermPettyCashRead, tr),
+	))
+
+	// Financial accounts (cash box, bank accounts) and transfers between them
+	mux.Handle("GET /financial-accounts", Chain(
+		http.HandlerFunc(treasuryH.ListAccounts),
+		auth, RequirePermission(user.PermTreasuryRead, tr),
+	))
+	mux.Handle("GET /financial-accounts/balances", Chain(
+		http.HandlerFunc(treasuryH.Balances),
+		auth, RequirePermission(user.PermTreasuryRead, tr),
+	))
+	mux.Handle("POST /financial-accounts", Chain(
+		http.HandlerFunc(treasuryH.CreateAccount),
+		auth, RequirePermission(user.PermTreasuryManage, tr),
+	))
+	mux.Handle("PUT /financial-accounts/{id}", Chain(
+		http.HandlerFunc(treasuryH.UpdateAccount),
+		auth, RequirePermission(user.PermTreasuryManage, tr),
+	))
+	mux.Handle("GET /transfers", Chain(
+		http.HandlerFunc(treasuryH.ListTransfers),
+		auth, RequirePermission(user.PermTreasuryRead, tr),
+	))
+	mux.Handle("POST /transfers", Chain(
+		http.HandlerFunc(treasuryH.CreateTransfer),
+		auth, RequirePermission(user.PermTreasuryTransfer, tr),
+	))
+	mux.Handle("DELETE /transfers/{id}", Chain(
+		http.HandlerFunc(treasuryH.DeleteTransfer),
+		auth, RequirePermission(user.PermTreasuryManage, tr),
+	))
+
+	// Period close: locks contributions and expenses of a month or fiscal year
+	mux.Handle("GET /periods", Chain(
+		http.HandlerFunc(periodH.List),
+		auth, RequirePermission(user.PermPeriodRead, tr),
+	))
+	mux.Handle("POST /periods/close", Chain(
+		http.HandlerFunc(periodH.Close),
+		auth, RequirePermission(user.PermPeriodManage, tr),
+	))
+	mux.Handle("POST /periods/reopen", Chain(
+		http.HandlerFunc(periodH.Reopen),
+		auth, RequirePermission(user.PermPeriodManage, tr),
+	))
+
+	// Annual budget per category, replaced as a whole for each year
+	mux.Handle("GET /budgets", Chain(
+		http.HandlerFunc(budgetH.Get),
+		auth, RequirePermission(user.PermBudgetRead, tr),
+	))
+	mux.Handle("PUT /budgets/{year}", Chain(
+		http.HandlerFunc(budgetH.Set),
+		auth, RequirePermission(user.PermBudgetManage, tr),
+	))
+
+	// Signed annual treasury reports (POST: requires password for key decryption)
+	mux.Handle("POST /annual-reports", Chain(
+		http.HandlerFunc(annualH.Issue),
+		auth, RequirePermission(user.PermAnnualReportIssue, tr),
+	))
+	mux.Handle("GET /annual-reports", Chain(
+		http.HandlerFunc(annualH.List),
+		auth, RequirePermission(user.PermAnnualReportRead, tr),
+	))
+	mux.Handle("GET /annual-reports/{folio}", Chain(
+		http.HandlerFunc(annualH.Verify),
+		auth, RequirePermission(user.PermAnnualReportRead, tr),
+	))
+	mux.Handle("GET /annual-reports/{folio}/pdf", Chain(
+		http.HandlerFunc(annualH.PDF),
+		auth, RequirePermission(user.PermAnnualReportRead, tr),
+	))
+
 	// Protected contribution routes
 	mux.Handle("POST /contributions", Chain(
 		http.HandlerFunc(contribH.Create),
@@ -147,15 +303,147 @@ func RegisterRoutes(mux *http.ServeMux, expenseSvc port.ExpenseService, authSvc
 		auth, RequirePermission(user.PermContributionRead, tr),
 	))
 
+	// Signing certificates: admins manage them per user; signers list their own
+	mux.Handle("POST /certificates", Chain(
+		http.HandlerFunc(certH.Upload),
+		auth, RequirePermission(user.PermCertificateManage, tr),
+	))
+	mux.Handle("GET /certificates", Chain(
+		http.HandlerFunc(certH.List),
+		auth, RequirePermission(user.PermCertificateManage, tr),
+	))
+	mux.Handle("POST /certificates/deactivate/{id}", Chain(
+		http.HandlerFunc(certH.Deactivate),
+		auth, RequirePermission(user.PermCertificateManage, tr),
+	))
+	mux.Handle("GET /certificates/mine", Chain(
+		http.HandlerFunc(certH.Mine),
+		auth, RequirePermission(user.PermContributionRead, tr),
+	))
+
+	// Batch receipt signing: one password entry, progress polling, ZIP of PDFs
+	mux.Handle("POST /receipts/batch", Chain(
+		http.HandlerFunc(receiptH.StartReceiptBatch),
+		auth, RequirePermission(user.PermContributionRead, tr),
+	))
+	mux.Handle("GET /receipts/batch/{id}", Chain(
+		http.HandlerFunc(receiptH.ReceiptBatch),
+		auth, RequirePermission(user.PermContributionRead, tr),
+	))
+	mux.Handle("GET /receipts/batch/{id}/zip", Chain(
+		http.HandlerFunc(receiptH.ReceiptBatchZIP),
+		auth, RequirePermission(user.PermContributionRead, tr),
+	))
+
+	// Receipt registry: search issued receipts, cancelled ones included
+	mux.Handle("GET /receipts", Chain(
+		http.HandlerFunc(receiptH.ListReceipts),
+		auth, RequirePermission(user.PermReceiptVerify, tr),
+	))
+	mux.Handle("GET /contributors/{id}/receipts", Chain(
+		http.HandlerFunc(receiptH.ListContributorReceipts),
+		auth, RequirePermission(user.PermReceiptVerify, tr),
+	))
+
 	// Receipt folio verification
 	mux.Handle("GET /receipts/verify/{folio}", Chain(
 		http.HandlerFunc(receiptH.VerifyReceipt),
 		auth, RequirePermission(user.PermReceiptVerify, tr),
 	))
+	mux.Handle("GET /receipts/pdf/{folio}", Chain(
+		http.HandlerFunc(receiptH.ReceiptPDF),
+		auth, RequirePermission(user.PermReceiptVerify, tr),
+	))
+
+	// Receipt cancellation (POST: requires password to sign the cancellation)
+	mux.Handle("POST /receipts/cancel/{folio}", Chain(
+		http.HandlerFunc(receiptH.CancelReceipt),
+		auth, RequirePermission(user.PermReceiptCancel, tr),
+	))
 
 	// Reports
 	mux.Handle("GET /reports/monthly-balance", Chain(
 		http.HandlerFunc(reportH.MonthlyBalance),
 		auth, RequirePermission(user.PermReportRead, tr),
 	))
+	mux.Handle("GET /reports/supplier-spending", Chain(
+		http.HandlerFunc(reportH.SupplierSpending),
+		auth, RequirePermission(user.PermReportRead, tr),
+	))
+	mux.Handle("GET /reports/range", Chain(
+		http.HandlerFunc(reportH.RangeBalance),
+		auth, RequirePermission(user.PermReportRead, tr),
+	))
+	mux.Handle("GET /reports/year-comparison", Chain(
+		http.HandlerFunc(reportH.YearComparison),
+		auth, RequirePermission(user.PermReportRead, tr),
+	))
+	mux.Handle("GET /reports/collection-rate", Chain(
+		http.HandlerFunc(reportH.CollectionRate),
+		auth, RequirePermission(user.PermReportRead, tr),
+	))
+	mux.Handle("GET /reports/budget-variance", Chain(
+		http.HandlerFunc(reportH.BudgetVariance),
+		auth, RequirePermission(user.PermReportRead, tr),
+	))
+	mux.Handle("POST /reports/forecast", Chain(
+		http.HandlerFunc(reportH.Forecast),
+		auth, RequirePermission(user.PermReportRead, tr),
+	))
+	mux.Handle("GET /reports/trial-balance", Chain(
+		http.HandlerFunc(ledgerH.TrialBalance),
+		auth, RequirePermission(user.PermReportRead, tr),
+	))
+	mux.Handle("GET /reports/balance-sheet", Chain(
+		http.HandlerFunc(ledgerH.BalanceSheet),
+		auth, RequirePermission(user.PermReportRead, tr),
+	))
+	mux.Handle("GET /reports/income-statement", Chain(
+		http.HandlerFunc(ledgerH.IncomeStatement),
+		auth, RequirePermission(user.PermReportRead, tr),
+	))
+	mux.Handle("GET /reports/chart-balance", Chain(
+		http.HandlerFunc(ledgerH.ChartBalance),
+		auth, RequirePermission(user.PermReportRead, tr),
+	))
+
+	// General ledger: entries from contributions, expenses and transfers are
+	// generated; only accounts and manual adjusting entries are written here,
+	// and rebuild regenerates the generated ones from their source documents
+	mux.Handle("GET /ledger/accounts", Chain(
+		http.HandlerFunc(ledgerH.ListAccounts),
+		auth, RequirePermission(user.PermLedgerRead, tr),
+	))
+	mux.Handle("POST /ledger/accounts", Chain(
+		http.HandlerFunc(ledgerH.CreateAccount),
+		auth, RequirePermission(user.PermLedgerWrite, tr),
+	))
+	mux.Handle("PUT /ledger/accounts/{id}", Chain(
+		http.HandlerFunc(ledgerH.UpdateAccount),
+		auth, RequirePermission(user.PermLedgerWrite, tr),
+	))
+	mux.Handle("GET /ledger/category-accounts", Chain(
+		http.HandlerFunc(ledgerH.ListCategoryAccounts),
+		auth, RequirePermission(user.PermLedgerRead, tr),
+	))
+	mux.Handle("PUT /ledger/category-accounts/{kind}/{id}", Chain(
+		http.HandlerFunc(ledgerH.SetCategoryAccount),
+		auth, RequirePermission(user.PermLedgerWrite, tr),
+	))
+	mux.Handle("GET /ledger/entries", Chain(
+		http.HandlerFunc(ledgerH.ListEntries),
+		auth, RequirePermission(user.PermLedgerRead, tr),
+	))
+	mux.Handle("GET /ledger/entries/{id}", Chain(
+		http.HandlerFunc(ledgerH.GetEntry),
+		auth, RequirePermission(user.PermLedgerRead, tr),
+	))
+	mux.Handle("POST /ledger/entries", Chain(
+		http.HandlerFunc(ledgerH.CreateEntry),
+		auth, RequirePermission(user.PermLedgerWrite, tr),
+	))
+	mux.Handle("POST /ledger/rebuild", Chain(
+		http.HandlerFunc(ledgerH.Rebuild),
+		auth, RequirePermission(user.PermLedgerWrite, tr),
+	))
 }
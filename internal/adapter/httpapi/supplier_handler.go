@@ -0,0 +1,151 @@
+package httpapi
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/ivsanmendez/ControlDeContabilidad/internal/adapter/i18n"
+	"github.com/ivsanmendez/ControlDeContabilidad/internal/domain/supplier"
+	"github.com/ivsanmendez/ControlDeContabilidad/internal/port"
+)
+
+type SupplierHandler struct {
+	svc port.SupplierService
+	tr  *i18n.Translator
+}
+
+type supplierRequest struct {
+	Name              string `json:"name"`
+	RFC               string `json:"rfc"`
+	ContactName       string `json:"contact_name"`
+	Phone             string `json:"phone"`
+	Email             string `json:"email"`
+	CLABE             string `json:"clabe"`
+	DefaultCategoryID *int64 `json:"default_category_id"`
+}
+
+type updateSupplierRequest struct {
+	supplierRequest
+	IsActive bool `json:"is_active"`
+}
+
+func (req supplierRequest) details() supplier.Details {
+	return supplier.Details{
+		Name:              req.Name,
+		RFC:               req.RFC,
+		ContactName:       req.ContactName,
+		Phone:             req.Phone,
+		Email:             req.Email,
+		CLABE:             req.CLABE,
+		DefaultCategoryID: req.DefaultCategoryID,
+	}
+}
+
+func (h *SupplierHandler) Create(w http.ResponseWriter, r *http.Request) {
+	claims, ok := ClaimsFromContext(r.Context())
+	if !ok {
+		writeErrorT(w, r, h.tr, http.StatusUnauthorized, "no_claims_in_context")
+		return
+	}
+
+	var req supplierRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeErrorT(w, r, h.tr, http.StatusBadRequest, "invalid_request_body")
+		return
+	}
+
+	s, err := h.svc.CreateSupplier(r.Context(), claims.UserID, req.details())
+	if err != nil {
+		if errors.Is(err, supplier.ErrDuplicate) {
+			writeError(w, http.StatusConflict, err.Error())
+		} else {
+			writeError(w, http.StatusUnprocessableEntity, err.Error())
+		}
+		return
+	}
+	writeJSON(w, http.StatusCreated, s)
+}
+
+// List handles GET /suppliers. Pass ?active=true to list only active suppliers.
+func (h *SupplierHandler) List(w http.ResponseWriter, r *http.Request) {
+	var (
+		suppliers []supplier.Supplier
+		err       error
+	)
+	if r.URL.Query().Get("active") == "true" {
+		suppliers, err = h.svc.ListActiveSuppliers(r.Context())
+	} else {
+		suppliers, err = h.svc.ListSuppliers(r.Context())
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, suppliers)
+}
+
+func (h *SupplierHandler) GetByID(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		writeErrorT(w, r, h.tr, http.StatusBadRequest, "invalid_id")
+		return
+	}
+
+	s, err := h.svc.GetSupplier(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, supplier.ErrNotFound) {
+			writeErrorT(w, r, h.tr, http.StatusNotFound, "supplier_not_found")
+		} else {
+			writeError(w, http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+	writeJSON(w, http.StatusOK, s)
+}
+
+func (h *SupplierHandler) Update(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		writeErrorT(w, r, h.tr, http.StatusBadRequest, "invalid_id")
+		return
+	}
+
+	var req updateSupplierRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeErrorT(w, r, h.tr, http.StatusBadRequest, "invalid_request_body")
+		return
+	}
+
+	s, err := h.svc.UpdateSupplier(r.Context(), id, req.details(), req.IsActive)
+	if err != nil {
+		if errors.Is(err, supplier.ErrNotFound) {
+			writeErrorT(w, r, h.tr, http.StatusNotFound, "supplier_not_found")
+		} else if errors.Is(err, supplier.ErrDuplicate) {
+			writeError(w, http.StatusConflict, err.Error())
+		} else {
+			writeError(w, http.StatusUnprocessableEntity, err.Error())
+		}
+		return
+	}
+	writeJSON(w, http.StatusOK, s)
+}
+
+func (h *SupplierHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		writeErrorT(w, r, h.tr, http.StatusBadRequest, "invalid_id")
+		return
+	}
+
+	if err := h.svc.DeleteSupplier(r.Context(), id); err != nil {
+		if errors.Is(err, supplier.ErrNotFound) {
+			writeErrorT(w, r, h.tr, http.StatusNotFound, "supplier_not_found")
+		} else {
+			writeError(w, http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,190 @@
+package httpapi
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/ivsanmendez/ControlDeContabilidad/internal/adapter/i18n"
+	"github.com/ivsanmendez/ControlDeContabilidad/internal/domain/treasury"
+	"github.com/ivsanmendez/ControlDeContabilidad/internal/port"
+)
+
+// TreasuryHandler serves financial accounts (cash box, bank accounts) and
+// the transfers between them.
+type TreasuryHandler struct {
+	svc port.TreasuryService
+	tr  *i18n.Translator
+}
+
+type financialAccountRequest struct {
+	Name            string        `json:"name"`
+	Kind            treasury.Kind `json:"kind"`
+	BankName        string        `json:"bank_name"`
+	AccountNumber   string        `json:"account_number"`
+	OpeningBalance  float64       `json:"opening_balance"`
+	LedgerAccountID *int64        `json:"ledger_account_id"`
+	IsActive        bool          `json:"is_active"`
+}
+
+func (req financialAccountRequest) details() treasury.Details {
+	return treasury.Details{
+		Name:            req.Name,
+		Kind:            req.Kind,
+		BankName:        req.BankName,
+		AccountNumber:   req.AccountNumber,
+		OpeningBalance:  req.OpeningBalance,
+		LedgerAccountID: req.LedgerAccountID,
+	}
+}
+
+type transferRequest struct {
+	FromAccountID int64   `json:"from_account_id"`
+	ToAccountID   int64   `json:"to_account_id"`
+	Amount        float64 `json:"amount"`
+	Date          string  `json:"date"`
+	Description   string  `json:"description"`
+}
+
+func (h *TreasuryHandler) CreateAccount(w http.ResponseWriter, r *http.Request) {
+	claims, ok := ClaimsFromContext(r.Context())
+	if !ok {
+		writeErrorT(w, r, h.tr, http.StatusUnauthorized, "no_claims_in_context")
+		return
+	}
+
+	var req financialAccountRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeErrorT(w, r, h.tr, http.StatusBadRequest, "invalid_request_body")
+		return
+	}
+
+	a, err := h.svc.CreateAccount(r.Context(), claims.UserID, req.details())
+	if err != nil {
+		h.writeErr(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, a)
+}
+
+func (h *TreasuryHandler) ListAccounts(w http.ResponseWriter, r *http.Request) {
+	accounts, err := h.svc.ListAccounts(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, accounts)
+}
+
+func (h *TreasuryHandler) UpdateAccount(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		writeErrorT(w, r, h.tr, http.StatusBadRequest, "invalid_id")
+		return
+	}
+
+	var req financialAccountRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeErrorT(w, r, h.tr, http.StatusBadRequest, "invalid_request_body")
+		return
+	}
+
+	a, err := h.svc.UpdateAccount(r.Context(), id, req.details(), req.IsActive)
+	if err != nil {
+		h.writeErr(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, a)
+}
+
+// Balances handles GET /financial-accounts/balances with each account's
+// current balance.
+func (h *TreasuryHandler) Balances(w http.ResponseWriter, r *http.Request) {
+	balances, err := h.svc.GetBalances(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, balances)
+}
+
+func (h *TreasuryHandler) CreateTransfer(w http.ResponseWriter, r *http.Request) {
+	claims, ok := ClaimsFromContext(r.Context())
+	if !ok {
+		writeErrorT(w, r, h.tr, http.StatusUnauthorized, "no_claims_in_context")
+		return
+	}
+
+	var req transferRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeErrorT(w, r, h.tr, http.StatusBadRequest, "invalid_request_body")
+		return
+	}
+
+	date, err := time.Parse("2006-01-02", req.Date)
+	if err != nil {
+		writeErrorT(w, r, h.tr, http.StatusBadRequest, "invalid_date_format")
+		return
+	}
+
+	t, err := h.svc.CreateTransfer(r.Context(), claims.UserID, req.FromAccountID, req.ToAccountID, req.Amount, date, req.Description)
+	if err != nil {
+		h.writeErr(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, t)
+}
+
+// ListTransfers handles GET /transfers?account_id=N (optional).
+func (h *TreasuryHandler) ListTransfers(w http.ResponseWriter, r *http.Request) {
+	var accountID int64
+	if v := r.URL.Query().Get("account_id"); v != "" {
+		var err error
+		accountID, err = strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			writeErrorT(w, r, h.tr, http.StatusBadRequest, "invalid_id")
+			return
+		}
+	}
+
+	transfers, err := h.svc.ListTransfers(r.Context(), accountID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, transfers)
+}
+
+func (h *TreasuryHandler) DeleteTransfer(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		writeErrorT(w, r, h.tr, http.StatusBadRequest, "invalid_id")
+		return
+	}
+
+	if err := h.svc.DeleteTransfer(r.Context(), id); err != nil {
+		h.writeErr(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *TreasuryHandler) writeErr(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, treasury.ErrNotFound):
+		writeErrorT(w, r, h.tr, http.StatusNotFound, "financial_account_not_found")
+	case errors.Is(err, treasury.ErrTransferNotFound):
+		writeErrorT(w, r, h.tr, http.StatusNotFound, "transfer_not_found")
+	case errors.Is(err, treasury.ErrDuplicate),
+		errors.Is(err, treasury.ErrInsufficientFunds),
+		errors.Is(err, treasury.ErrAccountInactive),
+		errors.Is(err, treasury.ErrDefaultCannotDisable):
+		writeError(w, http.StatusConflict, err.Error())
+	case errors.Is(err, treasury.ErrNotPosted):
+		writeNotPosted(w, r, h.tr, err)
+	default:
+		writeError(w, http.StatusUnprocessableEntity, err.Error())
+	}
+}
@@ -5,6 +5,7 @@ var MessagesEN = map[string]string{
 	// Generic
 	"invalid_request_body": "invalid request body",
 	"invalid_id":           "invalid id",
+	"rate_limit_exceeded":  "too many requests, try again later",
 
 	// Auth
 	"registration_failed":              "registration failed",
@@ -22,7 +23,8 @@ var MessagesEN = map[string]string{
 	"insufficient_permissions":       "insufficient permissions",
 
 	// Expenses
-	"expense_not_found": "expense not found",
+	"expense_not_found":         "expense not found",
+	"expense_petty_cash_charge": "the expense was paid from petty cash and cannot be changed or deleted",
 
 	// Contributors
 	"contributor_not_found": "contributor not found",
@@ -47,9 +49,207 @@ var MessagesEN = map[string]string{
 	"failed_to_generate_folio":         "failed to generate folio",
 	"failed_to_save_receipt":           "failed to save receipt",
 
+	// Public receipt verification
+	"receipt_verification_title": "Receipt verification",
+	"receipt_signature_valid":    "The digital seal is valid: this receipt was issued and signed by the treasury.",
+	"receipt_signature_invalid":  "The digital seal does not match this receipt. Do not accept it as proof of payment.",
+
+	// Receipt cancellation
+	"cancellation_reason_required":  "cancellation reason is required",
+	"receipt_already_cancelled":     "receipt is already cancelled",
+	"replacement_receipt_not_found": "replacement receipt folio not found",
+	"replacement_receipt_is_self":   "a receipt cannot replace itself",
+	"replacement_receipt_mismatch":  "replacement receipt belongs to another contributor",
+	"replacement_receipt_cancelled": "replacement receipt is cancelled",
+	"receipt_query_failed":          "failed to process receipt",
+	"receipt_cancelled_notice":      "This receipt was cancelled and is no longer valid proof of payment.",
+
+	// Receipt registry
+	"invalid_pagination": "invalid limit or offset",
+	"invalid_user_id":    "invalid user_id",
+
+	// Batch receipt signing
+	"receipt_batch_not_found":             "receipt batch not found",
+	"receipt_batch_running":               "receipt batch is still running",
+	"failed_to_list_contributors":         "failed to list contributors",
+	"receipt_batch_empty":                 "batch must include at least one contributor",
+	"receipt_batch_too_large":             "batch must not exceed 500 contributors",
+	"receipt_batch_scope_contribution":    "a batch cannot be scoped to a single contribution",
+	"invalid_receipt_scope_kind":          "scope kind must be year, contribution, months or category",
+	"receipt_scope_contribution_required": "contribution scope requires a contribution_id",
+	"receipt_scope_category_required":     "category scope requires a category_id",
+	"invalid_receipt_scope_months":        "month range must be within 1-12 with from not after to",
+	"receipt_scope_empty":                 "no contributions fall within the receipt scope",
+
+	// Signing certificates
+	"signing_certificate_not_found":          "signing certificate not found",
+	"signing_certificate_duplicate":          "this certificate is already active for the user",
+	"signing_certificate_inactive":           "signing certificate is deactivated",
+	"signing_certificate_not_holder":         "signing certificate belongs to another user",
+	"signing_certificate_expired":            "the signing certificate has expired",
+	"signing_certificate_not_yet_valid":      "the signing certificate is not valid yet",
+	"signing_certificate_key_usage":          "the signing certificate is not enabled for digital signatures",
+	"signing_certificate_untrusted":          "the signing certificate is not issued by a trusted SAT authority",
+	"signing_key_mismatch":                   "the private key does not belong to the signing certificate",
+	"signing_certificate_selection_required": "you have several active signing certificates; choose one with certificate_id",
+	"signing_certificate_required":           "certificate is required",
+	"signing_key_required":                   "private key is required",
+	"signing_certificate_invalid":            "certificate is neither valid PEM nor DER X.509",
+	"signing_key_not_encrypted":              "private key must be uploaded encrypted, as issued by SAT",
+	"signing_certificate_query_failed":       "failed to load signing certificates",
+
 	// Expense categories
 	"expense_category_not_found": "expense category not found",
 
+	// Suppliers
+	"supplier_not_found": "supplier not found",
+
+	// Petty cash
+	"petty_cash_fund_not_found":          "petty cash fund not found",
+	"petty_cash_replenishment_not_found": "petty cash replenishment not found",
+
+	// Ledger
+	"invalid_date_format":       "invalid date format, expected YYYY-MM-DD",
+	"ledger_account_not_found":  "ledger account not found",
+	"journal_entry_not_found":   "journal entry not found",
+	"ledger_category_not_found": "category not found",
+	"ledger_not_posted":         "the change was saved but the ledger could not be updated; rebuild the ledger to bring it back in line",
+	"ledger_rebuild_failed":     "the ledger could not be rebuilt",
+
+	// Treasury
+	"financial_account_not_found": "financial account not found",
+	"transfer_not_found":          "transfer not found",
+
+	// Periods
+	"period_closed":         "the accounting period is closed; an admin must reopen it first",
+	"period_already_closed": "the accounting period is already closed",
+	"period_not_closed":     "the accounting period is not closed",
+
+	// Budgets
+	"invalid_budget_kind":     "invalid budget line kind, expected income or expense",
+	"invalid_budget_category": "budget line needs an existing category",
+	"negative_budget_amount":  "budgeted amount cannot be negative",
+	"duplicate_budget_line":   "category is budgeted more than once",
+
+	// Annual reports
+	"annual_report_not_found":    "annual report not found",
+	"annual_report_query_failed": "failed to build the annual report",
+
 	// Reports
-	"report_query_failed": "report query failed",
+	"report_query_failed":            "report query failed",
+	"invalid_date_range":             "invalid date range, from must not be after to",
+	"invalid_group_by":               "invalid grouping, expected day, week, month, quarter or year",
+	"invalid_basis":                  "invalid basis, expected cash or accrual",
+	"invalid_month_format":           "invalid month format, expected YYYY-MM",
+	"invalid_forecast_horizon":       "forecast horizon must be between 3 and 12 months",
+	"invalid_forecast_expense":       "each forecast expense needs a description and a positive amount",
+	"invalid_forecast_expense_month": "each one-off forecast expense needs a month within the forecast horizon",
+	"invalid_forecast_adjustment":    "income adjustment cannot be below -100%",
+
+	// Report exports
+	"invalid_export_format":       "unsupported export format, expected json, csv, xlsx or pdf",
+	"report_export_failed":        "failed to export report",
+	"export_generated_at":         "Generated",
+	"export_page":                 "Page",
+	"export_summary":              "Summary",
+	"export_by_account":           "By financial account",
+	"export_income_by_category":   "Income by category",
+	"export_expenses_by_category": "Expenses by category",
+	"export_monthly_balance":      "Monthly balance",
+	"export_supplier_spending":    "Spending by supplier",
+	"export_unassigned":           "No supplier",
+	"export_range_balance":        "Balance by period",
+	"export_year_comparison":      "Year-over-year comparison",
+	"export_collection_rate":      "Collection rate",
+	"export_forecast":             "Cash-flow forecast",
+	"export_expected_income":      "Expected income",
+	"export_trial_balance":        "Trial balance",
+	"export_balance_sheet":        "Balance sheet",
+	"export_assets":               "Assets",
+	"export_liabilities":          "Liabilities",
+	"export_equity":               "Equity",
+	"export_income_statement":     "Income statement",
+	"export_chart_balance":        "Chart of accounts balance",
+	"export_chart_of_accounts":    "Chart of accounts",
+	"export_by_sat_code":          "By SAT grouping code",
+	"basis_cash":                  "Cash basis",
+	"basis_accrual":               "Accrual basis",
+	"col_month":                   "Month",
+	"col_period":                  "Period",
+	"col_income":                  "Income",
+	"col_expenses":                "Expenses",
+	"col_balance":                 "Balance",
+	"col_cumulative_balance":      "Cumulative balance",
+	"col_opening_balance":         "Opening balance",
+	"col_closing_balance":         "Closing balance",
+	"col_current_balance":         "Current balance",
+	"col_total":                   "Total",
+	"col_category":                "Category",
+	"col_account":                 "Account",
+	"col_transfers_in":            "Transfers in",
+	"col_transfers_out":           "Transfers out",
+	"col_supplier":                "Supplier",
+	"col_rfc":                     "RFC",
+	"col_expense_count":           "Expenses",
+	"col_balance_delta":           "Balance change vs",
+	"col_expected":                "Expected",
+	"col_paid":                    "Paid",
+	"col_rate":                    "Rate %",
+	"col_non_payers":              "Houses not paid",
+	"col_expected_income":         "Expected income",
+	"col_recurring_expenses":      "Recurring expenses",
+	"col_one_off_expenses":        "One-off expenses",
+	"col_average_amount":          "Average amount",
+	"col_monthly_expected":        "Expected per month",
+	"col_description":             "Description",
+	"col_amount":                  "Amount",
+	"col_code":                    "Code",
+	"col_sat_code":                "SAT code",
+	"col_nature":                  "Nature",
+	"col_debit":                   "Debit",
+	"col_credit":                  "Credit",
+	"col_net_income":              "Net income",
+	"col_budgeted":                "Budgeted",
+	"col_actual":                  "Actual",
+	"col_variance":                "Variance",
+	"col_variance_pct":            "Variance %",
+	"col_folio":                   "Folio",
+	"col_house_number":            "House",
+	"col_contributor":             "Contributor",
+	"col_receipt_year":            "Receipt year",
+	"col_scope":                   "Covers",
+	"col_signer":                  "Signer",
+	"col_signed_at":               "Signed on",
+	"col_status":                  "Status",
+	"export_budget_variance":      "Budget vs. actual",
+	"export_budget_income":        "Income budget",
+	"export_budget_expenses":      "Expense budget",
+	"export_issued_receipts":      "Signed receipts issued",
+	"export_annual_report":        "Annual treasury report",
+	"export_receipt":              "Contribution receipt",
+	"export_payments":             "Payments",
+	"receipt_scope_year":          "Full year",
+	"sig_folio":                   "Folio",
+	"sig_signer":                  "Signed by",
+	"sig_signed_at":               "Signed on",
+	"sig_certificate":             "Certificate number",
+	"sig_content_hash":            "Content SHA-256 fingerprint",
+	"sig_signature":               "Digital seal",
+	"sig_verify":                  "Verification",
+	"sig_cancelled":               "CANCELLED",
+	"sig_cancelled_at":            "Cancelled on",
+	"sig_cancel_reason":           "Cancellation reason",
+	"sig_replacement":             "Replaced by",
+	"month_1":                     "January",
+	"month_2":                     "February",
+	"month_3":                     "March",
+	"month_4":                     "April",
+	"month_5":                     "May",
+	"month_6":                     "June",
+	"month_7":                     "July",
+	"month_8":                     "August",
+	"month_9":                     "September",
+	"month_10":                    "October",
+	"month_11":                    "November",
+	"month_12":                    "December",
 }
@@ -5,6 +5,7 @@ var MessagesES = map[string]string{
 	// Generic
 	"invalid_request_body": "cuerpo de solicitud inválido",
 	"invalid_id":           "id inválido",
+	"rate_limit_exceeded":  "demasiadas solicitudes, intente más tarde",
 
 	// Auth
 	"registration_failed":              "error en el registro",
@@ -22,7 +23,8 @@ var MessagesES = map[string]string{
 	"insufficient_permissions":       "permisos insuficientes",
 
 	// Expenses
-	"expense_not_found": "gasto no encontrado",
+	"expense_not_found":         "gasto no encontrado",
+	"expense_petty_cash_charge": "el gasto se pagó con caja chica y no puede modificarse ni eliminarse",
 
 	// Contributors
 	"contributor_not_found": "contribuyente no encontrado",
@@ -47,9 +49,207 @@ var MessagesES = map[string]string{
 	"failed_to_generate_folio":         "no se pudo generar el folio",
 	"failed_to_save_receipt":           "no se pudo guardar el recibo",
 
+	// Public receipt verification
+	"receipt_verification_title": "Verificación de recibo",
+	"receipt_signature_valid":    "El sello digital es válido: este recibo fue emitido y firmado por la tesorería.",
+	"receipt_signature_invalid":  "El sello digital no corresponde a este recibo. No lo acepte como comprobante de pago.",
+
+	// Receipt cancellation
+	"cancellation_reason_required":  "el motivo de cancelación es obligatorio",
+	"receipt_already_cancelled":     "el recibo ya está cancelado",
+	"replacement_receipt_not_found": "folio de recibo sustituto no encontrado",
+	"replacement_receipt_is_self":   "un recibo no puede sustituirse a sí mismo",
+	"replacement_receipt_mismatch":  "el recibo sustituto pertenece a otro contribuyente",
+	"replacement_receipt_cancelled": "el recibo sustituto está cancelado",
+	"receipt_query_failed":          "no se pudo procesar el recibo",
+	"receipt_cancelled_notice":      "Este recibo fue cancelado y ya no es válido como comprobante de pago.",
+
+	// Receipt registry
+	"invalid_pagination": "limit u offset inválido",
+	"invalid_user_id":    "user_id inválido",
+
+	// Batch receipt signing
+	"receipt_batch_not_found":             "lote de recibos no encontrado",
+	"receipt_batch_running":               "el lote de recibos aún está en proceso",
+	"failed_to_list_contributors":         "no se pudieron listar los contribuyentes",
+	"receipt_batch_empty":                 "el lote debe incluir al menos un contribuyente",
+	"receipt_batch_too_large":             "el lote no puede exceder 500 contribuyentes",
+	"receipt_batch_scope_contribution":    "un lote no puede limitarse a una sola aportación",
+	"invalid_receipt_scope_kind":          "el alcance debe ser year, contribution, months o category",
+	"receipt_scope_contribution_required": "el alcance por aportación requiere contribution_id",
+	"receipt_scope_category_required":     "el alcance por categoría requiere category_id",
+	"invalid_receipt_scope_months":        "el rango de meses debe estar entre 1 y 12, con from no mayor que to",
+	"receipt_scope_empty":                 "ninguna aportación cae dentro del alcance del recibo",
+
+	// Signing certificates
+	"signing_certificate_not_found":          "certificado de firma no encontrado",
+	"signing_certificate_duplicate":          "este certificado ya está activo para el usuario",
+	"signing_certificate_inactive":           "el certificado de firma está desactivado",
+	"signing_certificate_not_holder":         "el certificado de firma pertenece a otro usuario",
+	"signing_certificate_expired":            "el certificado de firma ha expirado",
+	"signing_certificate_not_yet_valid":      "el certificado de firma aún no es válido",
+	"signing_certificate_key_usage":          "el certificado de firma no está habilitado para firma digital",
+	"signing_certificate_untrusted":          "el certificado de firma no fue emitido por una autoridad del SAT de confianza",
+	"signing_key_mismatch":                   "la llave privada no corresponde al certificado de firma",
+	"signing_certificate_selection_required": "tiene varios certificados de firma activos; elija uno con certificate_id",
+	"signing_certificate_required":           "el certificado es obligatorio",
+	"signing_key_required":                   "la llave privada es obligatoria",
+	"signing_certificate_invalid":            "el certificado no es un X.509 válido en PEM ni DER",
+	"signing_key_not_encrypted":              "la llave privada debe subirse cifrada, tal como la emite el SAT",
+	"signing_certificate_query_failed":       "no se pudieron cargar los certificados de firma",
+
 	// Expense categories
 	"expense_category_not_found": "categoría de gasto no encontrada",
 
+	// Suppliers
+	"supplier_not_found": "proveedor no encontrado",
+
+	// Petty cash
+	"petty_cash_fund_not_found":          "fondo de caja chica no encontrado",
+	"petty_cash_replenishment_not_found": "reposición de caja chica no encontrada",
+
+	// Ledger
+	"invalid_date_format":       "formato de fecha inválido, se esperaba YYYY-MM-DD",
+	"ledger_account_not_found":  "cuenta contable no encontrada",
+	"journal_entry_not_found":   "póliza no encontrada",
+	"ledger_category_not_found": "categoría no encontrada",
+	"ledger_not_posted":         "el cambio se guardó pero no se pudo actualizar la contabilidad; reconstruya la contabilidad para corregirla",
+	"ledger_rebuild_failed":     "no se pudo reconstruir la contabilidad",
+
+	// Treasury
+	"financial_account_not_found": "cuenta de tesorería no encontrada",
+	"transfer_not_found":          "traspaso no encontrado",
+
+	// Periods
+	"period_closed":         "el periodo contable está cerrado; un administrador debe reabrirlo primero",
+	"period_already_closed": "el periodo contable ya está cerrado",
+	"period_not_closed":     "el periodo contable no está cerrado",
+
+	// Budgets
+	"invalid_budget_kind":     "tipo de partida inválido, se esperaba income o expense",
+	"invalid_budget_category": "la partida presupuestal requiere una categoría existente",
+	"negative_budget_amount":  "el monto presupuestado no puede ser negativo",
+	"duplicate_budget_line":   "la categoría está presupuestada más de una vez",
+
+	// Annual reports
+	"annual_report_not_found":    "informe anual no encontrado",
+	"annual_report_query_failed": "no se pudo generar el informe anual",
+
 	// Reports
-	"report_query_failed": "error al generar el reporte",
+	"report_query_failed":            "error al generar el reporte",
+	"invalid_date_range":             "rango de fechas inválido, la fecha inicial no puede ser posterior a la final",
+	"invalid_group_by":               "agrupación inválida, use day, week, month, quarter o year",
+	"invalid_basis":                  "base inválida, use cash (flujo de efectivo) o accrual (devengado)",
+	"invalid_month_format":           "formato de mes inválido, se espera AAAA-MM",
+	"invalid_forecast_horizon":       "el pronóstico debe cubrir entre 3 y 12 meses",
+	"invalid_forecast_expense":       "cada gasto del pronóstico necesita descripción y un monto positivo",
+	"invalid_forecast_expense_month": "cada gasto extraordinario del pronóstico necesita un mes dentro del horizonte",
+	"invalid_forecast_adjustment":    "el ajuste de ingresos no puede ser menor a -100%",
+
+	// Report exports
+	"invalid_export_format":       "formato de exportación no soportado, use json, csv, xlsx o pdf",
+	"report_export_failed":        "error al exportar el reporte",
+	"export_generated_at":         "Generado",
+	"export_page":                 "Página",
+	"export_summary":              "Resumen",
+	"export_by_account":           "Por cuenta financiera",
+	"export_income_by_category":   "Ingresos por categoría",
+	"export_expenses_by_category": "Egresos por categoría",
+	"export_monthly_balance":      "Balance mensual",
+	"export_supplier_spending":    "Gasto por proveedor",
+	"export_unassigned":           "Sin proveedor",
+	"export_range_balance":        "Balance por periodo",
+	"export_year_comparison":      "Comparativo anual",
+	"export_collection_rate":      "Tasa de cobranza",
+	"export_forecast":             "Pronóstico de flujo de efectivo",
+	"export_expected_income":      "Ingresos esperados",
+	"export_trial_balance":        "Balanza de comprobación",
+	"export_balance_sheet":        "Estado de posición financiera",
+	"export_assets":               "Activo",
+	"export_liabilities":          "Pasivo",
+	"export_equity":               "Patrimonio",
+	"export_income_statement":     "Estado de resultados",
+	"export_chart_balance":        "Balanza del catálogo de cuentas",
+	"export_chart_of_accounts":    "Catálogo de cuentas",
+	"export_by_sat_code":          "Por código agrupador SAT",
+	"basis_cash":                  "Base de flujo de efectivo",
+	"basis_accrual":               "Base devengada",
+	"col_month":                   "Mes",
+	"col_period":                  "Periodo",
+	"col_income":                  "Ingresos",
+	"col_expenses":                "Egresos",
+	"col_balance":                 "Saldo",
+	"col_cumulative_balance":      "Saldo acumulado",
+	"col_opening_balance":         "Saldo inicial",
+	"col_closing_balance":         "Saldo final",
+	"col_current_balance":         "Saldo actual",
+	"col_total":                   "Total",
+	"col_category":                "Categoría",
+	"col_account":                 "Cuenta",
+	"col_transfers_in":            "Traspasos recibidos",
+	"col_transfers_out":           "Traspasos enviados",
+	"col_supplier":                "Proveedor",
+	"col_rfc":                     "RFC",
+	"col_expense_count":           "Gastos",
+	"col_balance_delta":           "Variación de saldo vs",
+	"col_expected":                "Esperadas",
+	"col_paid":                    "Pagadas",
+	"col_rate":                    "% cobranza",
+	"col_non_payers":              "Casas sin pago",
+	"col_expected_income":         "Ingresos esperados",
+	"col_recurring_expenses":      "Gastos recurrentes",
+	"col_one_off_expenses":        "Gastos extraordinarios",
+	"col_average_amount":          "Monto promedio",
+	"col_monthly_expected":        "Esperado por mes",
+	"col_description":             "Descripción",
+	"col_amount":                  "Monto",
+	"col_code":                    "Código",
+	"col_sat_code":                "Código SAT",
+	"col_nature":                  "Naturaleza",
+	"col_debit":                   "Debe",
+	"col_credit":                  "Haber",
+	"col_net_income":              "Resultado del ejercicio",
+	"col_budgeted":                "Presupuestado",
+	"col_actual":                  "Real",
+	"col_variance":                "Variación",
+	"col_variance_pct":            "% variación",
+	"col_folio":                   "Folio",
+	"col_house_number":            "Casa",
+	"col_contributor":             "Aportante",
+	"col_receipt_year":            "Año del recibo",
+	"col_scope":                   "Concepto",
+	"col_signer":                  "Firmante",
+	"col_signed_at":               "Fecha de firma",
+	"col_status":                  "Estado",
+	"export_budget_variance":      "Presupuesto contra real",
+	"export_budget_income":        "Presupuesto de ingresos",
+	"export_budget_expenses":      "Presupuesto de egresos",
+	"export_issued_receipts":      "Recibos firmados emitidos",
+	"export_annual_report":        "Informe anual de tesorería",
+	"export_receipt":              "Recibo de aportaciones",
+	"export_payments":             "Pagos",
+	"receipt_scope_year":          "Año completo",
+	"sig_folio":                   "Folio",
+	"sig_signer":                  "Firmado por",
+	"sig_signed_at":               "Fecha de firma",
+	"sig_certificate":             "No. de certificado",
+	"sig_content_hash":            "Huella SHA-256 del contenido",
+	"sig_signature":               "Sello digital",
+	"sig_verify":                  "Verificación",
+	"sig_cancelled":               "CANCELADO",
+	"sig_cancelled_at":            "Cancelado el",
+	"sig_cancel_reason":           "Motivo de cancelación",
+	"sig_replacement":             "Sustituido por",
+	"month_1":                     "Enero",
+	"month_2":                     "Febrero",
+	"month_3":                     "Marzo",
+	"month_4":                     "Abril",
+	"month_5":                     "Mayo",
+	"month_6":                     "Junio",
+	"month_7":                     "Julio",
+	"month_8":                     "Agosto",
+	"month_9":                     "Septiembre",
+	"month_10":                    "Octubre",
+	"month_11":                    "Noviembre",
+	"month_12":                    "Diciembre",
 }
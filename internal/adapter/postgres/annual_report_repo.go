@@ -0,0 +1,117 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/ivsanmendez/ControlDeContabilidad/internal/domain/annualreport"
+)
+
+// AnnualReportRepo implements annualreport.Repository.
+type AnnualReportRepo struct {
+	db *sql.DB
+}
+
+func NewAnnualReportRepo(db *sql.DB) *AnnualReportRepo {
+	return &AnnualReportRepo{db: db}
+}
+
+const annualReportSelect = `
+	SELECT id, folio, year_issued, seq_number, uuid_suffix, report_year, signer_name, user_id, canonical_json, signature, certificate, signed_at
+	FROM annual_reports`
+
+// NextSequence atomically increments and returns the next folio sequence for the given year.
+func (r *AnnualReportRepo) NextSequence(ctx context.Context, year int) (int, error) {
+	const q = `
+		INSERT INTO annual_report_counters (year, last_seq)
+		VALUES ($1, 1)
+		ON CONFLICT (year) DO UPDATE SET last_seq = annual_report_counters.last_seq + 1
+		RETURNING last_seq`
+
+	var seq int
+	if err := r.db.QueryRowContext(ctx, q, year).Scan(&seq); err != nil {
+		return 0, fmt.Errorf("next annual report sequence for year %d: %w", year, err)
+	}
+	return seq, nil
+}
+
+func (r *AnnualReportRepo) Save(ctx context.Context, d *annualreport.Document) error {
+	const q = `
+		INSERT INTO annual_reports (folio, year_issued, seq_number, uuid_suffix, report_year, signer_name, user_id, canonical_json, signature, certificate, signed_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		RETURNING id`
+
+	err := r.db.QueryRowContext(ctx, q,
+		d.Folio,
+		d.YearIssued,
+		d.SeqNumber,
+		d.UUIDSuffix,
+		d.ReportYear,
+		d.SignerName,
+		d.UserID,
+		d.CanonicalJSON,
+		d.Signature,
+		d.Certificate,
+		d.SignedAt,
+	).Scan(&d.ID)
+	if err != nil {
+		return fmt.Errorf("save annual report: %w", err)
+	}
+	return nil
+}
+
+func (r *AnnualReportRepo) FindByFolio(ctx context.Context, folio string) (*annualreport.Document, error) {
+	d, err := scanAnnualReport(r.db.QueryRowContext(ctx, annualReportSelect+` WHERE folio = $1`, folio))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, annualreport.ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("find annual report %s: %w", folio, err)
+	}
+	return d, nil
+}
+
+func (r *AnnualReportRepo) FindByReportYear(ctx context.Context, year int) ([]annualreport.Document, error) {
+	rows, err := r.db.QueryContext(ctx, annualReportSelect+` WHERE report_year = $1 ORDER BY signed_at DESC`, year)
+	if err != nil {
+		return nil, fmt.Errorf("list annual reports %d: %w", year, err)
+	}
+	defer rows.Close()
+
+	var docs []annualreport.Document
+	for rows.Next() {
+		d, err := scanAnnualReport(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan annual report: %w", err)
+		}
+		docs = append(docs, *d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("list annual reports %d: %w", year, err)
+	}
+	return docs, nil
+}
+
+func scanAnnualReport(s interface{ Scan(...any) error }) (*annualreport.Document, error) {
+	var d annualreport.Document
+	err := s.Scan(
+		&d.ID,
+		&d.Folio,
+		&d.YearIssued,
+		&d.SeqNumber,
+		&d.UUIDSuffix,
+		&d.ReportYear,
+		&d.SignerName,
+		&d.UserID,
+		&d.CanonicalJSON,
+		&d.Signature,
+		&d.Certificate,
+		&d.SignedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
@@ -0,0 +1,90 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/lib/pq"
+
+	"github.com/ivsanmendez/ControlDeContabilidad/internal/domain/budget"
+)
+
+// BudgetRepo implements budget.Repository.
+type BudgetRepo struct {
+	db *sql.DB
+}
+
+func NewBudgetRepo(db *sql.DB) *BudgetRepo {
+	return &BudgetRepo{db: db}
+}
+
+func (r *BudgetRepo) ReplaceYear(ctx context.Context, year int, lines []budget.Line) error {
+	const deleteQ = `DELETE FROM budget_lines WHERE year = $1`
+	const insertQ = `
+		INSERT INTO budget_lines (year, kind, contribution_category_id, expense_category_id, amount, user_id, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)`
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("replace budget %d: %w", year, err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, deleteQ, year); err != nil {
+		return fmt.Errorf("replace budget %d: %w", year, err)
+	}
+	for _, l := range lines {
+		var incomeCat, expenseCat sql.NullInt64
+		if l.Kind == budget.KindIncome {
+			incomeCat = sql.NullInt64{Int64: l.CategoryID, Valid: true}
+		} else {
+			expenseCat = sql.NullInt64{Int64: l.CategoryID, Valid: true}
+		}
+		_, err := tx.ExecContext(ctx, insertQ, year, l.Kind, incomeCat, expenseCat, l.Amount, l.UserID, l.UpdatedAt)
+		if err != nil {
+			var pqErr *pq.Error
+			if errors.As(err, &pqErr) {
+				switch pqErr.Code {
+				case "23503":
+					return budget.ErrUnknownCategory
+				case "23505":
+					return budget.ErrDuplicateLine
+				}
+			}
+			return fmt.Errorf("save budget line: %w", err)
+		}
+	}
+	return tx.Commit()
+}
+
+func (r *BudgetRepo) FindByYear(ctx context.Context, year int) ([]budget.Line, error) {
+	const q = `
+		SELECT b.id, b.year, b.kind, COALESCE(b.contribution_category_id, b.expense_category_id),
+		       COALESCE(cc.name, ec.name), b.amount, b.user_id, b.updated_at
+		FROM budget_lines b
+		LEFT JOIN contribution_categories cc ON cc.id = b.contribution_category_id
+		LEFT JOIN expense_categories ec ON ec.id = b.expense_category_id
+		WHERE b.year = $1
+		ORDER BY b.kind DESC, COALESCE(cc.name, ec.name)`
+
+	rows, err := r.db.QueryContext(ctx, q, year)
+	if err != nil {
+		return nil, fmt.Errorf("find budget %d: %w", year, err)
+	}
+	defer rows.Close()
+
+	var lines []budget.Line
+	for rows.Next() {
+		var l budget.Line
+		if err := rows.Scan(&l.ID, &l.Year, &l.Kind, &l.CategoryID, &l.CategoryName, &l.Amount, &l.UserID, &l.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("scan budget line: %w", err)
+		}
+		lines = append(lines, l)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("find budget %d: %w", year, err)
+	}
+	return lines, nil
+}
@@ -22,9 +22,10 @@ func NewContributionRepo(db *sql.DB) *ContributionRepo {
 
 func (r *ContributionRepo) Save(ctx context.Context, c *contribution.Contribution) error {
 	const q = `
-		INSERT INTO contributions (contributor_id, category_id, amount, month, year, payment_date, payment_method, user_id, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
-		RETURNING id`
+		INSERT INTO contributions (contributor_id, category_id, amount, month, year, payment_date, payment_method, user_id, created_at, updated_at, financial_account_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10,
+		        COALESCE(NULLIF($11, 0), (SELECT id FROM financial_accounts WHERE is_default)))
+		RETURNING id, financial_account_id`
 
 	err := r.db.QueryRowContext(ctx, q,
 		c.ContributorID,
@@ -37,7 +38,8 @@ func (r *ContributionRepo) Save(ctx context.Context, c *contribution.Contributio
 		c.UserID,
 		c.CreatedAt,
 		c.UpdatedAt,
-	).Scan(&c.ID)
+		c.FinancialAccountID,
+	).Scan(&c.ID, &c.FinancialAccountID)
 	if err != nil {
 		var pqErr *pq.Error
 		if errors.As(err, &pqErr) && pqErr.Code == "23505" {
@@ -52,8 +54,8 @@ func (r *ContributionRepo) Update(ctx context.Context, c *contribution.Contribut
 	const q = `
 		UPDATE contributions
 		SET contributor_id = $1, category_id = $2, amount = $3, month = $4, year = $5,
-		    payment_date = $6, payment_method = $7, updated_at = $8
-		WHERE id = $9`
+		    payment_date = $6, payment_method = $7, updated_at = $8, financial_account_id = $9
+		WHERE id = $10`
 
 	result, err := r.db.ExecContext(ctx, q,
 		c.ContributorID,
@@ -64,6 +66,7 @@ func (r *ContributionRepo) Update(ctx context.Context, c *contribution.Contribut
 		c.PaymentDate,
 		string(c.PaymentMethod),
 		c.UpdatedAt,
+		c.FinancialAccountID,
 		c.ID,
 	)
 	if err != nil {
@@ -86,7 +89,7 @@ func (r *ContributionRepo) Update(ctx context.Context, c *contribution.Contribut
 
 func (r *ContributionRepo) FindByID(ctx context.Context, id int64) (*contribution.Contribution, error) {
 	const q = `
-		SELECT id, contributor_id, category_id, amount, month, year, payment_date, payment_method, user_id, created_at, updated_at
+		SELECT id, contributor_id, category_id, amount, month, year, payment_date, payment_method, user_id, created_at, updated_at, financial_account_id
 		FROM contributions
 		WHERE id = $1`
 
@@ -102,7 +105,7 @@ func (r *ContributionRepo) FindByID(ctx context.Context, id int64) (*contributio
 
 func (r *ContributionRepo) FindAll(ctx context.Context) ([]contribution.Contribution, error) {
 	const q = `
-		SELECT id, contributor_id, category_id, amount, month, year, payment_date, payment_method, user_id, created_at, updated_at
+		SELECT id, contributor_id, category_id, amount, month, year, payment_date, payment_method, user_id, created_at, updated_at, financial_account_id
 		FROM contributions
 		ORDER BY year DESC, month DESC`
 
@@ -111,7 +114,7 @@ func (r *ContributionRepo) FindAll(ctx context.Context) ([]contribution.Contribu
 
 func (r *ContributionRepo) FindByContributorAndYear(ctx context.Context, contributorID int64, year int) ([]contribution.Contribution, error) {
 	const q = `
-		SELECT id, contributor_id, category_id, amount, month, year, payment_date, payment_method, user_id, created_at, updated_at
+		SELECT id, contributor_id, category_id, amount, month, year, payment_date, payment_method, user_id, created_at, updated_at, financial_account_id
 		FROM contributions
 		WHERE contributor_id = $1 AND year = $2
 		ORDER BY month`
@@ -140,12 +143,13 @@ func (r *ContributionRepo) Delete(ctx context.Context, id int64) error {
 // --- Detailed (JOIN) queries ---
 
 const detailSelect = `
-	SELECT c.id, c.contributor_id, c.category_id, c.amount, c.month, c.year, c.payment_date, c.payment_method, c.user_id, c.created_at, c.updated_at,
+	SELECT c.id, c.contributor_id, c.category_id, c.amount, c.month, c.year, c.payment_date, c.payment_method, c.user_id, c.created_at, c.updated_at, c.financial_account_id,
 	       ct.house_number, ct.name, ct.phone,
-	       cc.name
+	       cc.name, fa.name
 	FROM contributions c
 	JOIN contributors ct ON ct.id = c.contributor_id
-	JOIN contribution_categories cc ON cc.id = c.category_id`
+	JOIN contribution_categories cc ON cc.id = c.category_id
+	JOIN financial_accounts fa ON fa.id = c.financial_account_id`
 
 func (r *ContributionRepo) FindDetailedByID(ctx context.Context, id int64) (*contribution.ContributionDetail, error) {
 	q := detailSelect + ` WHERE c.id = $1`
@@ -188,6 +192,7 @@ func (r *ContributionRepo) scanOne(ctx context.Context, query string, args ...an
 		&c.UserID,
 		&c.CreatedAt,
 		&c.UpdatedAt,
+		&c.FinancialAccountID,
 	)
 	if err != nil {
 		return nil, err
@@ -220,6 +225,7 @@ func (r *ContributionRepo) scanMany(ctx context.Context, query string, args ...a
 			&c.UserID,
 			&c.CreatedAt,
 			&c.UpdatedAt,
+			&c.FinancialAccountID,
 		); err != nil {
 			return nil, fmt.Errorf("scan contribution: %w", err)
 		}
@@ -248,10 +254,12 @@ func (r *ContributionRepo) scanDetail(ctx context.Context, query string, args ..
 		&d.UserID,
 		&d.CreatedAt,
 		&d.UpdatedAt,
+		&d.FinancialAccountID,
 		&d.HouseNumber,
 		&d.ContributorName,
 		&d.Phone,
 		&d.CategoryName,
+		&d.FinancialAccountName,
 	)
 	if err != nil {
 		return nil, err
@@ -284,10 +292,12 @@ func (r *ContributionRepo) scanDetails(ctx context.Context, query string, args .
 			&d.UserID,
 			&d.CreatedAt,
 			&d.UpdatedAt,
+			&d.FinancialAccountID,
 			&d.HouseNumber,
 			&d.ContributorName,
 			&d.Phone,
 			&d.CategoryName,
+			&d.FinancialAccountName,
 		); err != nil {
 			return nil, fmt.Errorf("scan contribution detail: %w", err)
 		}
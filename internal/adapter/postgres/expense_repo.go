@@ -6,6 +6,8 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/lib/pq"
+
 	"github.com/ivsanmendez/ControlDeContabilidad/internal/domain/expense"
 )
 
@@ -18,35 +20,66 @@ func NewExpenseRepo(db *sql.DB) *ExpenseRepo {
 	return &ExpenseRepo{db: db}
 }
 
+// Save inserts the expense header and its lines in a single transaction.
 func (r *ExpenseRepo) Save(ctx context.Context, e *expense.Expense) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("save expense: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := insertExpense(ctx, tx, e); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// insertExpense inserts the expense header and its lines within tx, so other
+// repositories can store an expense together with their own records.
+func insertExpense(ctx context.Context, tx *sql.Tx, e *expense.Expense) error {
 	const q = `
-		INSERT INTO expenses (user_id, description, amount, category_id, date, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7)
-		RETURNING id`
+		INSERT INTO expenses (user_id, description, amount, category_id, supplier_id, date, created_at, updated_at, financial_account_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8,
+		        COALESCE(NULLIF($9, 0), (SELECT id FROM financial_accounts WHERE is_default)))
+		RETURNING id, financial_account_id`
 
-	return r.db.QueryRowContext(ctx, q,
+	if err := tx.QueryRowContext(ctx, q,
 		e.UserID,
 		e.Description,
 		e.Amount,
 		e.CategoryID,
+		e.SupplierID,
 		e.Date,
 		e.CreatedAt,
 		e.UpdatedAt,
-	).Scan(&e.ID)
+		e.FinancialAccountID,
+	).Scan(&e.ID, &e.FinancialAccountID); err != nil {
+		return fmt.Errorf("save expense: %w", err)
+	}
+	return insertExpenseLines(ctx, tx, e)
 }
 
+// Update rewrites the expense header and replaces its lines in a single transaction.
 func (r *ExpenseRepo) Update(ctx context.Context, e *expense.Expense) error {
 	const q = `
 		UPDATE expenses
-		SET description = $1, amount = $2, category_id = $3, date = $4, updated_at = $5
-		WHERE id = $6`
+		SET description = $1, amount = $2, category_id = $3, supplier_id = $4, date = $5, updated_at = $6, financial_account_id = $7
+		WHERE id = $8`
 
-	result, err := r.db.ExecContext(ctx, q,
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("update expense %d: %w", e.ID, err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, q,
 		e.Description,
 		e.Amount,
 		e.CategoryID,
+		e.SupplierID,
 		e.Date,
 		e.UpdatedAt,
+		e.FinancialAccountID,
 		e.ID,
 	)
 	if err != nil {
@@ -60,12 +93,20 @@ func (r *ExpenseRepo) Update(ctx context.Context, e *expense.Expense) error {
 	if rows == 0 {
 		return expense.ErrNotFound
 	}
-	return nil
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM expense_lines WHERE expense_id = $1`, e.ID); err != nil {
+		return fmt.Errorf("update expense %d lines: %w", e.ID, err)
+	}
+	if err := insertExpenseLines(ctx, tx, e); err != nil {
+		return err
+	}
+	return tx.Commit()
 }
 
 func (r *ExpenseRepo) FindByID(ctx context.Context, id int64) (*expense.Expense, error) {
 	const q = `
-		SELECT id, user_id, description, amount, category_id, date, created_at, updated_at
+		SELECT id, user_id, description, amount, category_id, supplier_id, date, created_at, updated_at, financial_account_id,
+		       EXISTS (SELECT 1 FROM petty_cash_charges WHERE expense_id = expenses.id)
 		FROM expenses
 		WHERE id = $1`
 
@@ -76,9 +117,12 @@ func (r *ExpenseRepo) FindByID(ctx context.Context, id int64) (*expense.Expense,
 		&e.Description,
 		&e.Amount,
 		&e.CategoryID,
+		&e.SupplierID,
 		&e.Date,
 		&e.CreatedAt,
 		&e.UpdatedAt,
+		&e.FinancialAccountID,
+		&e.PettyCashCharge,
 	)
 	if errors.Is(err, sql.ErrNoRows) {
 		return nil, expense.ErrNotFound
@@ -86,12 +130,20 @@ func (r *ExpenseRepo) FindByID(ctx context.Context, id int64) (*expense.Expense,
 	if err != nil {
 		return nil, fmt.Errorf("find expense %d: %w", id, err)
 	}
+
+	lines, err := r.loadLines(ctx, []int64{e.ID})
+	if err != nil {
+		return nil, err
+	}
+	for _, l := range lines[e.ID] {
+		e.Lines = append(e.Lines, l.Line)
+	}
 	return &e, nil
 }
 
 func (r *ExpenseRepo) FindAll(ctx context.Context) ([]expense.Expense, error) {
 	const q = `
-		SELECT id, user_id, description, amount, category_id, date, created_at, updated_at
+		SELECT id, user_id, description, amount, category_id, supplier_id, date, created_at, updated_at, financial_account_id
 		FROM expenses
 		ORDER BY date DESC, created_at DESC`
 
@@ -100,7 +152,7 @@ func (r *ExpenseRepo) FindAll(ctx context.Context) ([]expense.Expense, error) {
 
 func (r *ExpenseRepo) FindAllByUser(ctx context.Context, userID int64) ([]expense.Expense, error) {
 	const q = `
-		SELECT id, user_id, description, amount, category_id, date, created_at, updated_at
+		SELECT id, user_id, description, amount, category_id, supplier_id, date, created_at, updated_at, financial_account_id
 		FROM expenses
 		WHERE user_id = $1
 		ORDER BY date DESC, created_at DESC`
@@ -109,9 +161,12 @@ func (r *ExpenseRepo) FindAllByUser(ctx context.Context, userID int64) ([]expens
 }
 
 const expenseDetailSelect = `
-	SELECT e.id, e.user_id, e.description, e.amount, e.category_id, ec.name, e.date, e.created_at, e.updated_at
+	SELECT e.id, e.user_id, e.description, e.amount, e.category_id, ec.name, e.supplier_id, COALESCE(s.name, ''), e.date, e.created_at, e.updated_at,
+	       e.financial_account_id, fa.name
 	FROM expenses e
-	JOIN expense_categories ec ON ec.id = e.category_id`
+	JOIN expense_categories ec ON ec.id = e.category_id
+	JOIN financial_accounts fa ON fa.id = e.financial_account_id
+	LEFT JOIN suppliers s ON s.id = e.supplier_id`
 
 func (r *ExpenseRepo) FindAllDetailed(ctx context.Context) ([]expense.ExpenseDetail, error) {
 	q := expenseDetailSelect + ` ORDER BY e.date DESC, e.created_at DESC`
@@ -139,9 +194,11 @@ func (r *ExpenseRepo) scanExpenses(ctx context.Context, query string, args ...an
 			&e.Description,
 			&e.Amount,
 			&e.CategoryID,
+			&e.SupplierID,
 			&e.Date,
 			&e.CreatedAt,
 			&e.UpdatedAt,
+			&e.FinancialAccountID,
 		); err != nil {
 			return nil, fmt.Errorf("scan expense: %w", err)
 		}
@@ -150,6 +207,20 @@ func (r *ExpenseRepo) scanExpenses(ctx context.Context, query string, args ...an
 	if err := rows.Err(); err != nil {
 		return nil, fmt.Errorf("list expenses: %w", err)
 	}
+
+	ids := make([]int64, len(expenses))
+	for i := range expenses {
+		ids[i] = expenses[i].ID
+	}
+	lines, err := r.loadLines(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	for i := range expenses {
+		for _, l := range lines[expenses[i].ID] {
+			expenses[i].Lines = append(expenses[i].Lines, l.Line)
+		}
+	}
 	return expenses, nil
 }
 
@@ -170,9 +241,13 @@ func (r *ExpenseRepo) scanDetails(ctx context.Context, query string, args ...any
 			&d.Amount,
 			&d.CategoryID,
 			&d.CategoryName,
+			&d.SupplierID,
+			&d.SupplierName,
 			&d.Date,
 			&d.CreatedAt,
 			&d.UpdatedAt,
+			&d.FinancialAccountID,
+			&d.FinancialAccountName,
 		); err != nil {
 			return nil, fmt.Errorf("scan expense detail: %w", err)
 		}
@@ -181,14 +256,80 @@ func (r *ExpenseRepo) scanDetails(ctx context.Context, query string, args ...any
 	if err := rows.Err(); err != nil {
 		return nil, fmt.Errorf("list expense details: %w", err)
 	}
+
+	ids := make([]int64, len(details))
+	for i := range details {
+		ids[i] = details[i].ID
+	}
+	lines, err := r.loadLines(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	for i := range details {
+		details[i].Lines = lines[details[i].ID]
+	}
 	return details, nil
 }
 
+// loadLines fetches the lines of the given expenses, keyed by expense ID.
+func (r *ExpenseRepo) loadLines(ctx context.Context, expenseIDs []int64) (map[int64][]expense.LineDetail, error) {
+	const q = `
+		SELECT l.expense_id, l.id, l.category_id, ec.name, l.description, l.amount
+		FROM expense_lines l
+		JOIN expense_categories ec ON ec.id = l.category_id
+		WHERE l.expense_id = ANY($1)
+		ORDER BY l.expense_id, l.line_no`
+
+	result := make(map[int64][]expense.LineDetail, len(expenseIDs))
+	if len(expenseIDs) == 0 {
+		return result, nil
+	}
+
+	rows, err := r.db.QueryContext(ctx, q, pq.Array(expenseIDs))
+	if err != nil {
+		return nil, fmt.Errorf("list expense lines: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var expenseID int64
+		var l expense.LineDetail
+		if err := rows.Scan(&expenseID, &l.ID, &l.CategoryID, &l.CategoryName, &l.Description, &l.Amount); err != nil {
+			return nil, fmt.Errorf("scan expense line: %w", err)
+		}
+		result[expenseID] = append(result[expenseID], l)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("list expense lines: %w", err)
+	}
+	return result, nil
+}
+
+// insertExpenseLines writes e.Lines in order and fills in their IDs.
+func insertExpenseLines(ctx context.Context, tx *sql.Tx, e *expense.Expense) error {
+	const q = `
+		INSERT INTO expense_lines (expense_id, line_no, category_id, description, amount)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id`
+
+	for i := range e.Lines {
+		l := &e.Lines[i]
+		if err := tx.QueryRowContext(ctx, q, e.ID, i+1, l.CategoryID, l.Description, l.Amount).Scan(&l.ID); err != nil {
+			return fmt.Errorf("save expense %d line %d: %w", e.ID, i+1, err)
+		}
+	}
+	return nil
+}
+
 func (r *ExpenseRepo) Delete(ctx context.Context, id int64) error {
 	const q = `DELETE FROM expenses WHERE id = $1`
 
 	result, err := r.db.ExecContext(ctx, q, id)
 	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23503" {
+			return expense.ErrPettyCashCharge
+		}
 		return fmt.Errorf("delete expense %d: %w", id, err)
 	}
 
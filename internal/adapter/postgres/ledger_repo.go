@@ -0,0 +1,425 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+
+	"github.com/ivsanmendez/ControlDeContabilidad/internal/domain/ledger"
+)
+
+// LedgerRepo implements ledger.Repository.
+type LedgerRepo struct {
+	db *sql.DB
+}
+
+func NewLedgerRepo(db *sql.DB) *LedgerRepo {
+	return &LedgerRepo{db: db}
+}
+
+// --- Accounts ---
+
+const accountSelect = `
+	SELECT id, code, name, type, nature, parent_id, sat_code, is_active, created_at, updated_at
+	FROM ledger_accounts`
+
+func (r *LedgerRepo) SaveAccount(ctx context.Context, a *ledger.Account) error {
+	const q = `
+		INSERT INTO ledger_accounts (code, name, type, nature, parent_id, sat_code, is_active, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING id`
+
+	err := r.db.QueryRowContext(ctx, q,
+		a.Code,
+		a.Name,
+		string(a.Type),
+		string(a.Nature),
+		a.ParentID,
+		a.SATCode,
+		a.IsActive,
+		a.CreatedAt,
+		a.UpdatedAt,
+	).Scan(&a.ID)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+			return ledger.ErrDuplicateAccount
+		}
+		return fmt.Errorf("save ledger account: %w", err)
+	}
+	return nil
+}
+
+func (r *LedgerRepo) UpdateAccount(ctx context.Context, a *ledger.Account) error {
+	const q = `
+		UPDATE ledger_accounts
+		SET name = $1, parent_id = $2, sat_code = $3, is_active = $4, updated_at = $5
+		WHERE id = $6`
+
+	result, err := r.db.ExecContext(ctx, q, a.Name, a.ParentID, a.SATCode, a.IsActive, a.UpdatedAt, a.ID)
+	if err != nil {
+		return fmt.Errorf("update ledger account %d: %w", a.ID, err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("update ledger account %d: %w", a.ID, err)
+	}
+	if rows == 0 {
+		return ledger.ErrAccountNotFound
+	}
+	return nil
+}
+
+func (r *LedgerRepo) FindAccountByID(ctx context.Context, id int64) (*ledger.Account, error) {
+	return r.scanAccount(r.db.QueryRowContext(ctx, accountSelect+` WHERE id = $1`, id), fmt.Sprintf("id %d", id))
+}
+
+func (r *LedgerRepo) FindAccountByCode(ctx context.Context, code string) (*ledger.Account, error) {
+	return r.scanAccount(r.db.QueryRowContext(ctx, accountSelect+` WHERE code = $1`, code), "code "+code)
+}
+
+func (r *LedgerRepo) FindAllAccounts(ctx context.Context) ([]ledger.Account, error) {
+	rows, err := r.db.QueryContext(ctx, accountSelect+` ORDER BY code`)
+	if err != nil {
+		return nil, fmt.Errorf("list ledger accounts: %w", err)
+	}
+	defer rows.Close()
+
+	var result []ledger.Account
+	for rows.Next() {
+		a, err := scanAccountRow(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan ledger account: %w", err)
+		}
+		result = append(result, *a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("list ledger accounts: %w", err)
+	}
+	return result, nil
+}
+
+// --- Category mappings ---
+
+func (r *LedgerRepo) FindCategoryAccounts(ctx context.Context) ([]ledger.CategoryAccount, error) {
+	const q = `
+		SELECT 'contribution', id, name, ledger_account_id FROM contribution_categories
+		UNION ALL
+		SELECT 'expense', id, name, ledger_account_id FROM expense_categories
+		ORDER BY 1, 3`
+
+	rows, err := r.db.QueryContext(ctx, q)
+	if err != nil {
+		return nil, fmt.Errorf("list category accounts: %w", err)
+	}
+	defer rows.Close()
+
+	var result []ledger.CategoryAccount
+	for rows.Next() {
+		var c ledger.CategoryAccount
+		var kind string
+		if err := rows.Scan(&kind, &c.CategoryID, &c.CategoryName, &c.AccountID); err != nil {
+			return nil, fmt.Errorf("scan category account: %w", err)
+		}
+		c.Kind = ledger.CategoryKind(kind)
+		result = append(result, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("list category accounts: %w", err)
+	}
+	return result, nil
+}
+
+func (r *LedgerRepo) FindCategoryAccountID(ctx context.Context, kind ledger.CategoryKind, categoryID int64) (*int64, error) {
+	var accountID *int64
+	err := r.db.QueryRowContext(ctx, `SELECT ledger_account_id FROM `+categoryTable(kind)+` WHERE id = $1`, categoryID).Scan(&accountID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("find %s category %d account: %w", kind, categoryID, err)
+	}
+	return accountID, nil
+}
+
+func (r *LedgerRepo) SetCategoryAccount(ctx context.Context, kind ledger.CategoryKind, categoryID int64, accountID *int64) error {
+	result, err := r.db.ExecContext(ctx, `UPDATE `+categoryTable(kind)+` SET ledger_account_id = $1 WHERE id = $2`, accountID, categoryID)
+	if err != nil {
+		return fmt.Errorf("set %s category %d account: %w", kind, categoryID, err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("set %s category %d account: %w", kind, categoryID, err)
+	}
+	if rows == 0 {
+		return ledger.ErrCategoryNotFound
+	}
+	return nil
+}
+
+func (r *LedgerRepo) FindFinancialAccountLedgerID(ctx context.Context, financialAccountID int64) (*int64, error) {
+	var accountID *int64
+	err := r.db.QueryRowContext(ctx, `SELECT ledger_account_id FROM financial_accounts WHERE id = $1`, financialAccountID).Scan(&accountID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("find financial account %d ledger account: %w", financialAccountID, err)
+	}
+	return accountID, nil
+}
+
+// categoryTable maps a validated category kind to its catalog table.
+func categoryTable(kind ledger.CategoryKind) string {
+	if kind == ledger.CategoryExpense {
+		return "expense_categories"
+	}
+	return "contribution_categories"
+}
+
+// --- Entries ---
+
+func (r *LedgerRepo) SaveEntry(ctx context.Context, e *ledger.Entry) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("save journal entry: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := insertEntry(ctx, tx, e); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *LedgerRepo) ReplaceSourceEntry(ctx context.Context, source ledger.Source, sourceID int64, e *ledger.Entry) error {
+	const q = `DELETE FROM journal_entries WHERE source = $1 AND source_id = $2`
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("replace %s %d journal entry: %w", source, sourceID, err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, q, string(source), sourceID); err != nil {
+		return fmt.Errorf("replace %s %d journal entry: %w", source, sourceID, err)
+	}
+	if e != nil {
+		if err := insertEntry(ctx, tx, e); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+func (r *LedgerRepo) DeleteSourceEntriesExcept(ctx context.Context, source ledger.Source, keep []int64) (int, error) {
+	const q = `DELETE FROM journal_entries WHERE source = $1 AND NOT (source_id = ANY($2))`
+
+	res, err := r.db.ExecContext(ctx, q, string(source), pq.Array(keep))
+	if err != nil {
+		return 0, fmt.Errorf("delete orphaned %s journal entries: %w", source, err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("delete orphaned %s journal entries: %w", source, err)
+	}
+	return int(n), nil
+}
+
+const entrySelect = `
+	SELECT id, date, description, source, source_id, user_id, created_at
+	FROM journal_entries`
+
+func (r *LedgerRepo) FindEntryByID(ctx context.Context, id int64) (*ledger.EntryDetail, error) {
+	rows, err := r.db.QueryContext(ctx, entrySelect+` WHERE id = $1`, id)
+	if err != nil {
+		return nil, fmt.Errorf("find journal entry %d: %w", id, err)
+	}
+	entries, err := r.scanEntries(ctx, rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		return nil, ledger.ErrEntryNotFound
+	}
+	return &entries[0], nil
+}
+
+func (r *LedgerRepo) FindEntries(ctx context.Context, from, to time.Time) ([]ledger.EntryDetail, error) {
+	const q = entrySelect + `
+		WHERE ($1::date IS NULL OR date >= $1)
+		  AND ($2::date IS NULL OR date <= $2)
+		ORDER BY date, id`
+
+	rows, err := r.db.QueryContext(ctx, q, nullDate(from), nullDate(to))
+	if err != nil {
+		return nil, fmt.Errorf("list journal entries: %w", err)
+	}
+	return r.scanEntries(ctx, rows)
+}
+
+func (r *LedgerRepo) SumPostingsByAccount(ctx context.Context, from, to time.Time) ([]ledger.AccountTotal, error) {
+	const q = `
+		SELECT p.account_id, SUM(p.debit), SUM(p.credit)
+		FROM journal_postings p
+		JOIN journal_entries e ON e.id = p.entry_id
+		WHERE ($1::date IS NULL OR e.date >= $1)
+		  AND ($2::date IS NULL OR e.date <= $2)
+		GROUP BY p.account_id`
+
+	rows, err := r.db.QueryContext(ctx, q, nullDate(from), nullDate(to))
+	if err != nil {
+		return nil, fmt.Errorf("sum postings by account: %w", err)
+	}
+	defer rows.Close()
+
+	var result []ledger.AccountTotal
+	for rows.Next() {
+		var t ledger.AccountTotal
+		if err := rows.Scan(&t.AccountID, &t.Debit, &t.Credit); err != nil {
+			return nil, fmt.Errorf("scan account total: %w", err)
+		}
+		result = append(result, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("sum postings by account: %w", err)
+	}
+	return result, nil
+}
+
+// insertEntry writes the entry header and its postings and fills in their IDs.
+func insertEntry(ctx context.Context, tx *sql.Tx, e *ledger.Entry) error {
+	const entryQ = `
+		INSERT INTO journal_entries (date, description, source, source_id, user_id, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id`
+	const postingQ = `
+		INSERT INTO journal_postings (entry_id, account_id, debit, credit, memo)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id`
+
+	if err := tx.QueryRowContext(ctx, entryQ,
+		e.Date,
+		e.Description,
+		string(e.Source),
+		e.SourceID,
+		e.UserID,
+		e.CreatedAt,
+	).Scan(&e.ID); err != nil {
+		return fmt.Errorf("save journal entry: %w", err)
+	}
+
+	for i := range e.Postings {
+		p := &e.Postings[i]
+		if err := tx.QueryRowContext(ctx, postingQ, e.ID, p.AccountID, p.Debit, p.Credit, p.Memo).Scan(&p.ID); err != nil {
+			var pqErr *pq.Error
+			if errors.As(err, &pqErr) && pqErr.Code == "23503" {
+				return ledger.ErrAccountNotFound
+			}
+			return fmt.Errorf("save journal entry %d posting %d: %w", e.ID, i+1, err)
+		}
+	}
+	return nil
+}
+
+// nullDate maps a zero time to NULL so the bound is ignored by the query.
+func nullDate(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
+
+// --- Scanners ---
+
+func (r *LedgerRepo) scanAccount(row *sql.Row, key string) (*ledger.Account, error) {
+	a, err := scanAccountRow(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ledger.ErrAccountNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("find ledger account %s: %w", key, err)
+	}
+	return a, nil
+}
+
+func scanAccountRow(row interface{ Scan(...any) error }) (*ledger.Account, error) {
+	var a ledger.Account
+	var typ, nature string
+	if err := row.Scan(&a.ID, &a.Code, &a.Name, &typ, &nature, &a.ParentID, &a.SATCode, &a.IsActive, &a.CreatedAt, &a.UpdatedAt); err != nil {
+		return nil, err
+	}
+	a.Type = ledger.AccountType(typ)
+	a.Nature = ledger.Nature(nature)
+	return &a, nil
+}
+
+// scanEntries reads entry headers from rows, then loads their postings.
+func (r *LedgerRepo) scanEntries(ctx context.Context, rows *sql.Rows) ([]ledger.EntryDetail, error) {
+	defer rows.Close()
+
+	var result []ledger.EntryDetail
+	for rows.Next() {
+		var e ledger.EntryDetail
+		var source string
+		if err := rows.Scan(&e.ID, &e.Date, &e.Description, &source, &e.SourceID, &e.UserID, &e.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scan journal entry: %w", err)
+		}
+		e.Source = ledger.Source(source)
+		result = append(result, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("list journal entries: %w", err)
+	}
+	rows.Close()
+
+	ids := make([]int64, len(result))
+	for i, e := range result {
+		ids[i] = e.ID
+	}
+	postings, err := r.loadPostings(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	for i := range result {
+		result[i].Postings = postings[result[i].ID]
+	}
+	return result, nil
+}
+
+func (r *LedgerRepo) loadPostings(ctx context.Context, entryIDs []int64) (map[int64][]ledger.PostingDetail, error) {
+	const q = `
+		SELECT p.entry_id, p.id, p.account_id, a.code, a.name, p.debit, p.credit, p.memo
+		FROM journal_postings p
+		JOIN ledger_accounts a ON a.id = p.account_id
+		WHERE p.entry_id = ANY($1)
+		ORDER BY p.entry_id, p.id`
+
+	result := make(map[int64][]ledger.PostingDetail, len(entryIDs))
+	if len(entryIDs) == 0 {
+		return result, nil
+	}
+
+	rows, err := r.db.QueryContext(ctx, q, pq.Array(entryIDs))
+	if err != nil {
+		return nil, fmt.Errorf("list journal postings: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var entryID int64
+		var p ledger.PostingDetail
+		if err := rows.Scan(&entryID, &p.ID, &p.AccountID, &p.AccountCode, &p.AccountName, &p.Debit, &p.Credit, &p.Memo); err != nil {
+			return nil, fmt.Errorf("scan journal posting: %w", err)
+		}
+		result[entryID] = append(result[entryID], p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("list journal postings: %w", err)
+	}
+	return result, nil
+}
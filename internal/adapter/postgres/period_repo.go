@@ -0,0 +1,104 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/lib/pq"
+
+	"github.com/ivsanmendez/ControlDeContabilidad/internal/domain/period"
+)
+
+// PeriodRepo implements period.Repository.
+type PeriodRepo struct {
+	db *sql.DB
+}
+
+func NewPeriodRepo(db *sql.DB) *PeriodRepo {
+	return &PeriodRepo{db: db}
+}
+
+const periodSelect = `
+	SELECT id, year, month, closed_by, closed_at, reopened_by, reopened_at, reopen_reason
+	FROM closed_periods`
+
+func (r *PeriodRepo) Save(ctx context.Context, p *period.Period) error {
+	const q = `
+		INSERT INTO closed_periods (year, month, closed_by, closed_at)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id`
+
+	err := r.db.QueryRowContext(ctx, q, p.Year, p.Month, p.ClosedBy, p.ClosedAt).Scan(&p.ID)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+			return period.ErrAlreadyClosed
+		}
+		return fmt.Errorf("save closed period: %w", err)
+	}
+	return nil
+}
+
+func (r *PeriodRepo) Update(ctx context.Context, p *period.Period) error {
+	const q = `
+		UPDATE closed_periods
+		SET reopened_by = $1, reopened_at = $2, reopen_reason = $3
+		WHERE id = $4`
+
+	result, err := r.db.ExecContext(ctx, q, p.ReopenedBy, p.ReopenedAt, p.ReopenReason, p.ID)
+	if err != nil {
+		return fmt.Errorf("update closed period %d: %w", p.ID, err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("update closed period %d: %w", p.ID, err)
+	}
+	if rows == 0 {
+		return period.ErrNotClosed
+	}
+	return nil
+}
+
+func (r *PeriodRepo) FindByYear(ctx context.Context, year int) ([]period.Period, error) {
+	q := periodSelect + ` WHERE year = $1 ORDER BY month, closed_at`
+	return r.scanMany(ctx, q, year)
+}
+
+func (r *PeriodRepo) FindLocked(ctx context.Context, year int) ([]period.Period, error) {
+	q := periodSelect + ` WHERE year = $1 AND reopened_at IS NULL ORDER BY month`
+	return r.scanMany(ctx, q, year)
+}
+
+// --- Scanners ---
+
+func (r *PeriodRepo) scanMany(ctx context.Context, query string, args ...any) ([]period.Period, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("list closed periods: %w", err)
+	}
+	defer rows.Close()
+
+	var periods []period.Period
+	for rows.Next() {
+		var p period.Period
+		if err := rows.Scan(
+			&p.ID,
+			&p.Year,
+			&p.Month,
+			&p.ClosedBy,
+			&p.ClosedAt,
+			&p.ReopenedBy,
+			&p.ReopenedAt,
+			&p.ReopenReason,
+		); err != nil {
+			return nil, fmt.Errorf("scan closed period: %w", err)
+		}
+		periods = append(periods, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("list closed periods: %w", err)
+	}
+	return periods, nil
+}
@@ -0,0 +1,405 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/lib/pq"
+
+	"github.com/ivsanmendez/ControlDeContabilidad/internal/domain/expense"
+	"github.com/ivsanmendez/ControlDeContabilidad/internal/domain/pettycash"
+)
+
+// PettyCashRepo implements pettycash.Repository.
+type PettyCashRepo struct {
+	db *sql.DB
+}
+
+func NewPettyCashRepo(db *sql.DB) *PettyCashRepo {
+	return &PettyCashRepo{db: db}
+}
+
+// --- Funds ---
+
+const fundSelect = `
+	SELECT id, name, custodian_user_id, float_amount, financial_account_id, is_active, user_id, created_at, updated_at
+	FROM petty_cash_funds`
+
+func (r *PettyCashRepo) SaveFund(ctx context.Context, f *pettycash.Fund) error {
+	const q = `
+		INSERT INTO petty_cash_funds (name, custodian_user_id, float_amount, financial_account_id, is_active, user_id, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id`
+
+	err := r.db.QueryRowContext(ctx, q,
+		f.Name,
+		f.CustodianUserID,
+		f.FloatAmount,
+		f.FinancialAccountID,
+		f.IsActive,
+		f.UserID,
+		f.CreatedAt,
+		f.UpdatedAt,
+	).Scan(&f.ID)
+	if err != nil {
+		return fmt.Errorf("save petty cash fund: %w", err)
+	}
+	return nil
+}
+
+func (r *PettyCashRepo) UpdateFund(ctx context.Context, f *pettycash.Fund) error {
+	const q = `
+		UPDATE petty_cash_funds
+		SET name = $1, custodian_user_id = $2, float_amount = $3, financial_account_id = $4, is_active = $5, updated_at = $6
+		WHERE id = $7`
+
+	result, err := r.db.ExecContext(ctx, q, f.Name, f.CustodianUserID, f.FloatAmount, f.FinancialAccountID, f.IsActive, f.UpdatedAt, f.ID)
+	if err != nil {
+		return fmt.Errorf("update petty cash fund %d: %w", f.ID, err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("update petty cash fund %d: %w", f.ID, err)
+	}
+	if rows == 0 {
+		return pettycash.ErrNotFound
+	}
+	return nil
+}
+
+func (r *PettyCashRepo) FindFundByID(ctx context.Context, id int64) (*pettycash.Fund, error) {
+	var f pettycash.Fund
+	err := r.db.QueryRowContext(ctx, fundSelect+` WHERE id = $1`, id).Scan(
+		&f.ID,
+		&f.Name,
+		&f.CustodianUserID,
+		&f.FloatAmount,
+		&f.FinancialAccountID,
+		&f.IsActive,
+		&f.UserID,
+		&f.CreatedAt,
+		&f.UpdatedAt,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, pettycash.ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("find petty cash fund %d: %w", id, err)
+	}
+	return &f, nil
+}
+
+func (r *PettyCashRepo) FindAllFunds(ctx context.Context) ([]pettycash.Fund, error) {
+	rows, err := r.db.QueryContext(ctx, fundSelect+` ORDER BY name`)
+	if err != nil {
+		return nil, fmt.Errorf("list petty cash funds: %w", err)
+	}
+	defer rows.Close()
+
+	var funds []pettycash.Fund
+	for rows.Next() {
+		var f pettycash.Fund
+		if err := rows.Scan(
+			&f.ID,
+			&f.Name,
+			&f.CustodianUserID,
+			&f.FloatAmount,
+			&f.FinancialAccountID,
+			&f.IsActive,
+			&f.UserID,
+			&f.CreatedAt,
+			&f.UpdatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("scan petty cash fund: %w", err)
+		}
+		funds = append(funds, f)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("list petty cash funds: %w", err)
+	}
+	return funds, nil
+}
+
+// --- Charges ---
+
+const chargeSelect = `
+	SELECT c.id, c.fund_id, c.expense_id, e.description, e.amount, e.date, c.replenishment_id, c.user_id, c.created_at
+	FROM petty_cash_charges c
+	JOIN expenses e ON e.id = c.expense_id`
+
+// openChargesQuery sums charges not covered by a completed replenishment.
+const openChargesQuery = `
+	SELECT COALESCE(SUM(e.amount), 0)
+	FROM petty_cash_charges c
+	JOIN expenses e ON e.id = c.expense_id
+	LEFT JOIN petty_cash_replenishments rp ON rp.id = c.replenishment_id
+	WHERE c.fund_id = $1 AND (rp.id IS NULL OR rp.status <> 'completed')`
+
+// SaveCharge inserts the expense and its charge in one transaction. The fund
+// row is locked first, so concurrent charges are checked against the book
+// balance one at a time.
+func (r *PettyCashRepo) SaveCharge(ctx context.Context, c *pettycash.Charge, e *expense.Expense) error {
+	const lockQ = `SELECT float_amount FROM petty_cash_funds WHERE id = $1 FOR UPDATE`
+	const insertQ = `
+		INSERT INTO petty_cash_charges (fund_id, expense_id, user_id, created_at)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id`
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("save petty cash charge: %w", err)
+	}
+	defer tx.Rollback()
+
+	var float, open float64
+	err = tx.QueryRowContext(ctx, lockQ, c.FundID).Scan(&float)
+	if errors.Is(err, sql.ErrNoRows) {
+		return pettycash.ErrNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("lock petty cash fund %d: %w", c.FundID, err)
+	}
+	if err := tx.QueryRowContext(ctx, openChargesQuery, c.FundID).Scan(&open); err != nil {
+		return fmt.Errorf("petty cash open charges for fund %d: %w", c.FundID, err)
+	}
+	if math.Round(c.Amount*100) > math.Round((float-open)*100) {
+		return pettycash.ErrInsufficientFunds
+	}
+
+	if err := insertExpense(ctx, tx, e); err != nil {
+		return err
+	}
+	c.ExpenseID = e.ID
+	if err := tx.QueryRowContext(ctx, insertQ, c.FundID, c.ExpenseID, c.UserID, c.CreatedAt).Scan(&c.ID); err != nil {
+		return fmt.Errorf("save petty cash charge: %w", err)
+	}
+	return tx.Commit()
+}
+
+func (r *PettyCashRepo) FindChargesByFund(ctx context.Context, fundID int64) ([]pettycash.Charge, error) {
+	q := chargeSelect + ` WHERE c.fund_id = $1 ORDER BY e.date DESC, c.id DESC`
+	return r.scanCharges(ctx, q, fundID)
+}
+
+func (r *PettyCashRepo) FindUnrequestedCharges(ctx context.Context, fundID int64) ([]pettycash.Charge, error) {
+	q := chargeSelect + ` WHERE c.fund_id = $1 AND c.replenishment_id IS NULL ORDER BY e.date, c.id`
+	return r.scanCharges(ctx, q, fundID)
+}
+
+func (r *PettyCashRepo) OpenChargesTotal(ctx context.Context, fundID int64) (float64, error) {
+	var total float64
+	if err := r.db.QueryRowContext(ctx, openChargesQuery, fundID).Scan(&total); err != nil {
+		return 0, fmt.Errorf("petty cash open charges for fund %d: %w", fundID, err)
+	}
+	return total, nil
+}
+
+func (r *PettyCashRepo) scanCharges(ctx context.Context, query string, args ...any) ([]pettycash.Charge, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("list petty cash charges: %w", err)
+	}
+	defer rows.Close()
+
+	var charges []pettycash.Charge
+	for rows.Next() {
+		var c pettycash.Charge
+		if err := rows.Scan(
+			&c.ID,
+			&c.FundID,
+			&c.ExpenseID,
+			&c.Description,
+			&c.Amount,
+			&c.Date,
+			&c.ReplenishmentID,
+			&c.UserID,
+			&c.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("scan petty cash charge: %w", err)
+		}
+		charges = append(charges, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("list petty cash charges: %w", err)
+	}
+	return charges, nil
+}
+
+// --- Replenishments ---
+
+const replenishmentSelect = `
+	SELECT id, fund_id, amount, charge_count, status, requested_by, requested_at, completed_by, completed_at
+	FROM petty_cash_replenishments`
+
+// SaveReplenishment inserts the replenishment and claims the given charges in
+// one transaction. Only charges still unclaimed are updated, so two concurrent
+// requests cannot both include the same charge.
+func (r *PettyCashRepo) SaveReplenishment(ctx context.Context, rp *pettycash.Replenishment, chargeIDs []int64) error {
+	const insertQ = `
+		INSERT INTO petty_cash_replenishments (fund_id, amount, charge_count, status, requested_by, requested_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id`
+	const claimQ = `
+		UPDATE petty_cash_charges SET replenishment_id = $1
+		WHERE id = ANY($2) AND fund_id = $3 AND replenishment_id IS NULL`
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("save petty cash replenishment: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := tx.QueryRowContext(ctx, insertQ,
+		rp.FundID,
+		rp.Amount,
+		rp.ChargeCount,
+		string(rp.Status),
+		rp.RequestedBy,
+		rp.RequestedAt,
+	).Scan(&rp.ID); err != nil {
+		return fmt.Errorf("save petty cash replenishment: %w", err)
+	}
+
+	result, err := tx.ExecContext(ctx, claimQ, rp.ID, pq.Array(chargeIDs), rp.FundID)
+	if err != nil {
+		return fmt.Errorf("claim petty cash charges: %w", err)
+	}
+	claimed, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("claim petty cash charges: %w", err)
+	}
+	if int(claimed) != len(chargeIDs) {
+		return pettycash.ErrNoOpenCharges
+	}
+	return tx.Commit()
+}
+
+func (r *PettyCashRepo) UpdateReplenishment(ctx context.Context, rp *pettycash.Replenishment) error {
+	const q = `
+		UPDATE petty_cash_replenishments
+		SET status = $1, completed_by = $2, completed_at = $3
+		WHERE id = $4`
+
+	result, err := r.db.ExecContext(ctx, q, string(rp.Status), rp.CompletedBy, rp.CompletedAt, rp.ID)
+	if err != nil {
+		return fmt.Errorf("update petty cash replenishment %d: %w", rp.ID, err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("update petty cash replenishment %d: %w", rp.ID, err)
+	}
+	if rows == 0 {
+		return pettycash.ErrReplenishmentNotFound
+	}
+	return nil
+}
+
+func (r *PettyCashRepo) FindReplenishmentByID(ctx context.Context, id int64) (*pettycash.Replenishment, error) {
+	rps, err := r.scanReplenishments(ctx, replenishmentSelect+` WHERE id = $1`, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(rps) == 0 {
+		return nil, pettycash.ErrReplenishmentNotFound
+	}
+	return &rps[0], nil
+}
+
+func (r *PettyCashRepo) FindReplenishmentsByFund(ctx context.Context, fundID int64) ([]pettycash.Replenishment, error) {
+	return r.scanReplenishments(ctx, replenishmentSelect+` WHERE fund_id = $1 ORDER BY requested_at DESC`, fundID)
+}
+
+func (r *PettyCashRepo) scanReplenishments(ctx context.Context, query string, args ...any) ([]pettycash.Replenishment, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("list petty cash replenishments: %w", err)
+	}
+	defer rows.Close()
+
+	var result []pettycash.Replenishment
+	for rows.Next() {
+		var rp pettycash.Replenishment
+		var status string
+		if err := rows.Scan(
+			&rp.ID,
+			&rp.FundID,
+			&rp.Amount,
+			&rp.ChargeCount,
+			&status,
+			&rp.RequestedBy,
+			&rp.RequestedAt,
+			&rp.CompletedBy,
+			&rp.CompletedAt,
+		); err != nil {
+			return nil, fmt.Errorf("scan petty cash replenishment: %w", err)
+		}
+		rp.Status = pettycash.ReplenishmentStatus(status)
+		result = append(result, rp)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("list petty cash replenishments: %w", err)
+	}
+	return result, nil
+}
+
+// --- Reconciliations ---
+
+func (r *PettyCashRepo) SaveReconciliation(ctx context.Context, rec *pettycash.Reconciliation) error {
+	const q = `
+		INSERT INTO petty_cash_reconciliations (fund_id, counted_amount, book_balance, difference, notes, user_id, counted_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id`
+
+	err := r.db.QueryRowContext(ctx, q,
+		rec.FundID,
+		rec.CountedAmount,
+		rec.BookBalance,
+		rec.Difference,
+		rec.Notes,
+		rec.UserID,
+		rec.CountedAt,
+	).Scan(&rec.ID)
+	if err != nil {
+		return fmt.Errorf("save petty cash reconciliation: %w", err)
+	}
+	return nil
+}
+
+func (r *PettyCashRepo) FindReconciliationsByFund(ctx context.Context, fundID int64) ([]pettycash.Reconciliation, error) {
+	const q = `
+		SELECT id, fund_id, counted_amount, book_balance, difference, COALESCE(notes, ''), user_id, counted_at
+		FROM petty_cash_reconciliations
+		WHERE fund_id = $1
+		ORDER BY counted_at DESC`
+
+	rows, err := r.db.QueryContext(ctx, q, fundID)
+	if err != nil {
+		return nil, fmt.Errorf("list petty cash reconciliations: %w", err)
+	}
+	defer rows.Close()
+
+	var result []pettycash.Reconciliation
+	for rows.Next() {
+		var rec pettycash.Reconciliation
+		if err := rows.Scan(
+			&rec.ID,
+			&rec.FundID,
+			&rec.CountedAmount,
+			&rec.BookBalance,
+			&rec.Difference,
+			&rec.Notes,
+			&rec.UserID,
+			&rec.CountedAt,
+		); err != nil {
+			return nil, fmt.Errorf("scan petty cash reconciliation: %w", err)
+		}
+		result = append(result, rec)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("list petty cash reconciliations: %w", err)
+	}
+	return result, nil
+}
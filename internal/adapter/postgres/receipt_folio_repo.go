@@ -5,6 +5,9 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"strings"
+
+	"github.com/lib/pq"
 
 	"github.com/ivsanmendez/ControlDeContabilidad/internal/domain/receipt"
 )
@@ -36,8 +39,9 @@ func (r *ReceiptFolioRepo) NextSequence(ctx context.Context, year int) (int, err
 // Save inserts a new receipt folio record.
 func (r *ReceiptFolioRepo) Save(ctx context.Context, rf *receipt.ReceiptFolio) error {
 	const q = `
-		INSERT INTO receipt_folios (folio, year_issued, seq_number, uuid_suffix, contributor_id, receipt_year, signer_name, user_id, canonical_json, signature, certificate, signed_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+		INSERT INTO receipt_folios (folio, year_issued, seq_number, uuid_suffix, contributor_id, receipt_year, signer_name, user_id, canonical_json, signature, certificate, signed_at,
+			scope_kind, scope_contribution_id, scope_from_month, scope_to_month, scope_category_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17)
 		RETURNING id`
 
 	err := r.db.QueryRowContext(ctx, q,
@@ -53,6 +57,11 @@ func (r *ReceiptFolioRepo) Save(ctx context.Context, rf *receipt.ReceiptFolio) e
 		rf.Signature,
 		rf.Certificate,
 		rf.SignedAt,
+		rf.Scope.Kind,
+		rf.Scope.ContributionID,
+		nullIfZero(rf.Scope.FromMonth),
+		nullIfZero(rf.Scope.ToMonth),
+		rf.Scope.CategoryID,
 	).Scan(&rf.ID)
 	if err != nil {
 		return fmt.Errorf("save receipt folio: %w", err)
@@ -60,12 +69,17 @@ func (r *ReceiptFolioRepo) Save(ctx context.Context, rf *receipt.ReceiptFolio) e
 	return nil
 }
 
-// FindByFolio looks up a receipt folio by its unique folio string.
+// FindByFolio looks up a receipt folio by its unique folio string, along with
+// its cancellation if it has one.
 func (r *ReceiptFolioRepo) FindByFolio(ctx context.Context, folio string) (*receipt.ReceiptFolio, error) {
 	const q = `
-		SELECT id, folio, year_issued, seq_number, uuid_suffix, contributor_id, receipt_year, signer_name, user_id, canonical_json, signature, certificate, signed_at
-		FROM receipt_folios
-		WHERE folio = $1`
+		SELECT f.id, f.folio, f.year_issued, f.seq_number, f.uuid_suffix, f.contributor_id, f.receipt_year, f.signer_name, f.user_id, f.canonical_json, f.signature, f.certificate, f.signed_at,
+			f.scope_kind, f.scope_contribution_id, f.scope_from_month, f.scope_to_month, f.scope_category_id,
+			c.id, c.reason, c.user_id, COALESCE(rf.folio, ''), c.canonical_json, c.signature, c.certificate, c.cancelled_at
+		FROM receipt_folios f
+		LEFT JOIN receipt_cancellations c ON c.receipt_folio_id = f.id
+		LEFT JOIN receipt_folios rf ON rf.id = c.replacement_folio_id
+		WHERE f.folio = $1`
 
 	rf, err := r.scanOne(ctx, q, folio)
 	if errors.Is(err, sql.ErrNoRows) {
@@ -77,8 +91,119 @@ func (r *ReceiptFolioRepo) FindByFolio(ctx context.Context, folio string) (*rece
 	return rf, nil
 }
 
+// SaveCancellation inserts the signed cancellation of a receipt. The
+// replacement is referenced by id, resolved from its folio.
+func (r *ReceiptFolioRepo) SaveCancellation(ctx context.Context, c *receipt.Cancellation) error {
+	const q = `
+		INSERT INTO receipt_cancellations (receipt_folio_id, reason, user_id, replacement_folio_id, canonical_json, signature, certificate, cancelled_at)
+		VALUES ($1, $2, $3, (SELECT id FROM receipt_folios WHERE folio = NULLIF($4, '')), $5, $6, $7, $8)
+		RETURNING id`
+
+	err := r.db.QueryRowContext(ctx, q,
+		c.ReceiptFolioID,
+		c.Reason,
+		c.UserID,
+		c.ReplacementFolio,
+		c.CanonicalJSON,
+		c.Signature,
+		c.Certificate,
+		c.CancelledAt,
+	).Scan(&c.ID)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+			return receipt.ErrAlreadyCancelled
+		}
+		return fmt.Errorf("save receipt cancellation %s: %w", c.Folio, err)
+	}
+	return nil
+}
+
+// receiptFilter is shared by the registry page and its count. Empty filters
+// are passed as NULL and match everything.
+const receiptFilter = `
+		WHERE ($1::bigint IS NULL OR f.contributor_id = $1)
+		  AND ($2::int IS NULL OR f.receipt_year = $2)
+		  AND ($3::text IS NULL OR strpos(lower(f.signer_name), lower($3)) > 0)
+		  AND ($4::bigint IS NULL OR f.user_id = $4)
+		  AND ($5::date IS NULL OR f.signed_at >= $5)
+		  AND ($6::date IS NULL OR f.signed_at < $6::date + 1)`
+
+// List returns a page of issued receipts, newest first, and the total number
+// of receipts matching the filter.
+func (r *ReceiptFolioRepo) List(ctx context.Context, f receipt.Filter) ([]receipt.Summary, int, error) {
+	const q = `
+		SELECT f.id, f.folio, f.contributor_id, c.house_number, c.name, f.receipt_year,
+			f.scope_kind, f.scope_contribution_id, f.scope_from_month, f.scope_to_month, f.scope_category_id,
+			f.signer_name, f.user_id, f.signed_at, x.cancelled_at
+		FROM receipt_folios f
+		JOIN contributors c ON c.id = f.contributor_id
+		LEFT JOIN receipt_cancellations x ON x.receipt_folio_id = f.id` + receiptFilter + `
+		ORDER BY f.signed_at DESC, f.id DESC
+		LIMIT $7 OFFSET $8`
+	const countQ = `SELECT COUNT(*) FROM receipt_folios f` + receiptFilter
+
+	args := []any{
+		nullIfZero(f.ContributorID),
+		nullIfZero(f.ReceiptYear),
+		nullIfZero(strings.TrimSpace(f.SignerName)),
+		nullIfZero(f.UserID),
+		nullDate(f.From),
+		nullDate(f.To),
+	}
+
+	var total int
+	if err := r.db.QueryRowContext(ctx, countQ, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("count receipt folios: %w", err)
+	}
+
+	rows, err := r.db.QueryContext(ctx, q, append(args, f.Limit, f.Offset)...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("list receipt folios: %w", err)
+	}
+	defer rows.Close()
+
+	var result []receipt.Summary
+	for rows.Next() {
+		var s receipt.Summary
+		var fromMonth, toMonth sql.NullInt32
+		if err := rows.Scan(
+			&s.ID,
+			&s.Folio,
+			&s.ContributorID,
+			&s.HouseNumber,
+			&s.ContributorName,
+			&s.ReceiptYear,
+			&s.Scope.Kind,
+			&s.Scope.ContributionID,
+			&fromMonth,
+			&toMonth,
+			&s.Scope.CategoryID,
+			&s.SignerName,
+			&s.UserID,
+			&s.SignedAt,
+			&s.CancelledAt,
+		); err != nil {
+			return nil, 0, fmt.Errorf("scan receipt folio: %w", err)
+		}
+		s.Scope.FromMonth = int(fromMonth.Int32)
+		s.Scope.ToMonth = int(toMonth.Int32)
+		s.Cancelled = s.CancelledAt != nil
+		result = append(result, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("list receipt folios: %w", err)
+	}
+	return result, total, nil
+}
+
 func (r *ReceiptFolioRepo) scanOne(ctx context.Context, query string, args ...any) (*receipt.ReceiptFolio, error) {
 	var rf receipt.ReceiptFolio
+	var fromMonth, toMonth sql.NullInt32
+	var cancelID, cancelUser sql.NullInt64
+	var cancelReason, replacement sql.NullString
+	var cancelJSON, cancelSig, cancelCert []byte
+	var cancelledAt sql.NullTime
 	err := r.db.QueryRowContext(ctx, query, args...).Scan(
 		&rf.ID,
 		&rf.Folio,
@@ -93,9 +218,47 @@ func (r *ReceiptFolioRepo) scanOne(ctx context.Context, query string, args ...an
 		&rf.Signature,
 		&rf.Certificate,
 		&rf.SignedAt,
+		&rf.Scope.Kind,
+		&rf.Scope.ContributionID,
+		&fromMonth,
+		&toMonth,
+		&rf.Scope.CategoryID,
+		&cancelID,
+		&cancelReason,
+		&cancelUser,
+		&replacement,
+		&cancelJSON,
+		&cancelSig,
+		&cancelCert,
+		&cancelledAt,
 	)
 	if err != nil {
 		return nil, err
 	}
+	rf.Scope.FromMonth = int(fromMonth.Int32)
+	rf.Scope.ToMonth = int(toMonth.Int32)
+	if cancelID.Valid {
+		rf.Cancellation = &receipt.Cancellation{
+			ID:               cancelID.Int64,
+			ReceiptFolioID:   rf.ID,
+			Folio:            rf.Folio,
+			Reason:           cancelReason.String,
+			UserID:           cancelUser.Int64,
+			ReplacementFolio: replacement.String,
+			CanonicalJSON:    cancelJSON,
+			Signature:        cancelSig,
+			Certificate:      cancelCert,
+			CancelledAt:      cancelledAt.Time,
+		}
+	}
 	return &rf, nil
 }
+
+// nullIfZero maps unset scope bounds and filters to NULL.
+func nullIfZero[T comparable](v T) *T {
+	var zero T
+	if v == zero {
+		return nil
+	}
+	return &v
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"time"
 
 	"github.com/ivsanmendez/ControlDeContabilidad/internal/domain/report"
 )
@@ -17,28 +18,458 @@ func NewReportRepo(db *sql.DB) *ReportRepo {
 	return &ReportRepo{db: db}
 }
 
-func (r *ReportRepo) AggregateIncomeByMonth(ctx context.Context, year int) ([]report.MonthAggregate, error) {
+// Yearly aggregates read report_monthly_totals, which the database keeps in
+// step with every contribution, expense and expense line change.
+
+// incomeKind maps a basis to its report_monthly_totals bucket.
+func incomeKind(basis report.Basis) string {
+	if basis == report.BasisAccrual {
+		return "income_accrual"
+	}
+	return "income_cash"
+}
+
+// yearRange is the half-open [Jan 1, next Jan 1) range of a year, so date
+// columns are filtered through their indexes.
+func yearRange(year int) (time.Time, time.Time) {
+	from := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+	return from, from.AddDate(1, 0, 0)
+}
+
+func (r *ReportRepo) AggregateIncomeByMonth(ctx context.Context, year int, basis report.Basis) ([]report.MonthAggregate, error) {
 	const q = `
-		SELECT EXTRACT(MONTH FROM payment_date)::int, COALESCE(SUM(amount), 0)
-		FROM contributions
-		WHERE EXTRACT(YEAR FROM payment_date)::int = $1
-		GROUP BY EXTRACT(MONTH FROM payment_date)
-		ORDER BY EXTRACT(MONTH FROM payment_date)`
+		SELECT month, SUM(amount)
+		FROM report_monthly_totals
+		WHERE kind = $1 AND year = $2
+		GROUP BY month
+		ORDER BY month`
 
-	return r.scanAggregates(ctx, q, year)
+	return r.scanAggregates(ctx, q, incomeKind(basis), year)
 }
 
+// AggregateExpensesByMonth sums expense lines, so split tickets are counted
+// once per category bucket rather than once per header.
 func (r *ReportRepo) AggregateExpensesByMonth(ctx context.Context, year int) ([]report.MonthAggregate, error) {
 	const q = `
-		SELECT EXTRACT(MONTH FROM date)::int, COALESCE(SUM(amount), 0)
-		FROM expenses
-		WHERE EXTRACT(YEAR FROM date)::int = $1
-		GROUP BY EXTRACT(MONTH FROM date)
-		ORDER BY EXTRACT(MONTH FROM date)`
+		SELECT month, SUM(amount)
+		FROM report_monthly_totals
+		WHERE kind = 'expense' AND year = $1
+		GROUP BY month
+		ORDER BY month`
 
 	return r.scanAggregates(ctx, q, year)
 }
 
+func (r *ReportRepo) AggregateExpensesBySupplier(ctx context.Context, year int) ([]report.SupplierAggregate, error) {
+	const q = `
+		SELECT COALESCE(e.supplier_id, 0), COALESCE(s.name, ''), COALESCE(s.rfc, ''), COUNT(*), COALESCE(SUM(e.amount), 0)
+		FROM expenses e
+		LEFT JOIN suppliers s ON s.id = e.supplier_id
+		WHERE e.date >= $1 AND e.date < $2
+		GROUP BY e.supplier_id, s.name, s.rfc`
+
+	from, to := yearRange(year)
+	rows, err := r.db.QueryContext(ctx, q, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("report supplier aggregate: %w", err)
+	}
+	defer rows.Close()
+
+	var result []report.SupplierAggregate
+	for rows.Next() {
+		var a report.SupplierAggregate
+		if err := rows.Scan(&a.SupplierID, &a.SupplierName, &a.RFC, &a.Count, &a.Amount); err != nil {
+			return nil, fmt.Errorf("scan supplier aggregate: %w", err)
+		}
+		result = append(result, a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("report supplier aggregate: %w", err)
+	}
+	return result, nil
+}
+
+// AccountOpeningBalances rolls every financial account's opening balance
+// forward through the movements dated before the year.
+func (r *ReportRepo) AccountOpeningBalances(ctx context.Context, year int) ([]report.AccountOpening, error) {
+	const q = `
+		SELECT fa.id, fa.name, fa.opening_balance
+		       + COALESCE((SELECT SUM(c.amount) FROM contributions c
+		                   WHERE c.financial_account_id = fa.id AND c.payment_date < $1), 0)
+		       - COALESCE((SELECT SUM(e.amount) FROM expenses e
+		                   WHERE e.financial_account_id = fa.id AND e.date < $1), 0)
+		       + COALESCE((SELECT SUM(t.amount) FROM transfers t
+		                   WHERE t.to_account_id = fa.id AND t.date < $1), 0)
+		       - COALESCE((SELECT SUM(t.amount) FROM transfers t
+		                   WHERE t.from_account_id = fa.id AND t.date < $1), 0)
+		FROM financial_accounts fa
+		ORDER BY fa.is_default DESC, fa.name`
+
+	from, _ := yearRange(year)
+	rows, err := r.db.QueryContext(ctx, q, from)
+	if err != nil {
+		return nil, fmt.Errorf("report account openings: %w", err)
+	}
+	defer rows.Close()
+
+	var result []report.AccountOpening
+	for rows.Next() {
+		var o report.AccountOpening
+		if err := rows.Scan(&o.AccountID, &o.AccountName, &o.Balance); err != nil {
+			return nil, fmt.Errorf("scan account opening: %w", err)
+		}
+		result = append(result, o)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("report account openings: %w", err)
+	}
+	return result, nil
+}
+
+func (r *ReportRepo) AccrualOpeningShift(ctx context.Context, year int) (float64, error) {
+	const q = `
+		SELECT COALESCE(SUM(CASE WHEN kind = 'income_accrual' THEN amount ELSE -amount END), 0)
+		FROM report_monthly_totals
+		WHERE kind IN ('income_cash', 'income_accrual') AND year < $1`
+
+	var shift float64
+	if err := r.db.QueryRowContext(ctx, q, year).Scan(&shift); err != nil {
+		return 0, fmt.Errorf("report accrual opening shift: %w", err)
+	}
+	return shift, nil
+}
+
+func (r *ReportRepo) AccrualShiftBefore(ctx context.Context, date time.Time) (float64, error) {
+	const q = `
+		SELECT COALESCE((SELECT SUM(amount) FROM contributions WHERE make_date(year, month, 1) < $1), 0)
+		     - COALESCE((SELECT SUM(amount) FROM contributions WHERE payment_date < $1), 0)`
+
+	var shift float64
+	if err := r.db.QueryRowContext(ctx, q, date).Scan(&shift); err != nil {
+		return 0, fmt.Errorf("report accrual shift before %s: %w", date.Format("2006-01-02"), err)
+	}
+	return shift, nil
+}
+
+func (r *ReportRepo) AggregateByAccountAndMonth(ctx context.Context, year int) ([]report.AccountMonthAggregate, error) {
+	const q = `
+		SELECT account_id, month, SUM(income), SUM(expenses), SUM(transfers_in), SUM(transfers_out)
+		FROM (
+		    SELECT financial_account_id AS account_id, EXTRACT(MONTH FROM payment_date)::int AS month,
+		           amount AS income, 0 AS expenses, 0 AS transfers_in, 0 AS transfers_out
+		    FROM contributions WHERE payment_date >= $1 AND payment_date < $2
+		    UNION ALL
+		    SELECT financial_account_id, EXTRACT(MONTH FROM date)::int, 0, amount, 0, 0
+		    FROM expenses WHERE date >= $1 AND date < $2
+		    UNION ALL
+		    SELECT to_account_id, EXTRACT(MONTH FROM date)::int, 0, 0, amount, 0
+		    FROM transfers WHERE date >= $1 AND date < $2
+		    UNION ALL
+		    SELECT from_account_id, EXTRACT(MONTH FROM date)::int, 0, 0, 0, amount
+		    FROM transfers WHERE date >= $1 AND date < $2
+		) m
+		GROUP BY account_id, month
+		ORDER BY account_id, month`
+
+	from, to := yearRange(year)
+	rows, err := r.db.QueryContext(ctx, q, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("report account aggregate: %w", err)
+	}
+	defer rows.Close()
+
+	var result []report.AccountMonthAggregate
+	for rows.Next() {
+		var a report.AccountMonthAggregate
+		if err := rows.Scan(&a.AccountID, &a.Month, &a.Income, &a.Expenses, &a.TransfersIn, &a.TransfersOut); err != nil {
+			return nil, fmt.Errorf("scan account aggregate: %w", err)
+		}
+		result = append(result, a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("report account aggregate: %w", err)
+	}
+	return result, nil
+}
+
+func (r *ReportRepo) AggregateIncomeByCategoryAndMonth(ctx context.Context, year int, basis report.Basis) ([]report.CategoryMonthAggregate, error) {
+	const q = `
+		SELECT cc.id, cc.name, t.month, t.amount
+		FROM report_monthly_totals t
+		JOIN contribution_categories cc ON cc.id = t.category_id
+		WHERE t.kind = $1 AND t.year = $2
+		ORDER BY cc.name, cc.id`
+
+	return r.scanCategoryAggregates(ctx, q, incomeKind(basis), year)
+}
+
+func (r *ReportRepo) AggregateExpensesByCategoryAndMonth(ctx context.Context, year int) ([]report.CategoryMonthAggregate, error) {
+	const q = `
+		SELECT ec.id, ec.name, t.month, t.amount
+		FROM report_monthly_totals t
+		JOIN expense_categories ec ON ec.id = t.category_id
+		WHERE t.kind = 'expense' AND t.year = $1
+		ORDER BY ec.name, ec.id`
+
+	return r.scanCategoryAggregates(ctx, q, year)
+}
+
+func (r *ReportRepo) AggregateIncomeByDay(ctx context.Context, from, to time.Time, basis report.Basis) ([]report.DayAggregate, error) {
+	const cash = `
+		SELECT payment_date, COALESCE(SUM(amount), 0)
+		FROM contributions
+		WHERE payment_date BETWEEN $1 AND $2
+		GROUP BY payment_date
+		ORDER BY payment_date`
+	const accrual = `
+		SELECT make_date(year, month, 1) AS day, COALESCE(SUM(amount), 0)
+		FROM contributions
+		WHERE make_date(year, month, 1) BETWEEN $1 AND $2
+		GROUP BY day
+		ORDER BY day`
+
+	if basis == report.BasisAccrual {
+		return r.scanDayAggregates(ctx, accrual, from, to)
+	}
+	return r.scanDayAggregates(ctx, cash, from, to)
+}
+
+func (r *ReportRepo) AggregateExpensesByDay(ctx context.Context, from, to time.Time) ([]report.DayAggregate, error) {
+	const q = `
+		SELECT e.date, COALESCE(SUM(l.amount), 0)
+		FROM expense_lines l
+		JOIN expenses e ON e.id = l.expense_id
+		WHERE e.date BETWEEN $1 AND $2
+		GROUP BY e.date
+		ORDER BY e.date`
+
+	return r.scanDayAggregates(ctx, q, from, to)
+}
+
+// BalanceBefore ignores transfers: they only move money between accounts.
+func (r *ReportRepo) BalanceBefore(ctx context.Context, date time.Time) (float64, error) {
+	const q = `
+		SELECT COALESCE((SELECT SUM(opening_balance) FROM financial_accounts), 0)
+		     + COALESCE((SELECT SUM(amount) FROM contributions WHERE payment_date < $1), 0)
+		     - COALESCE((SELECT SUM(amount) FROM expenses WHERE date < $1), 0)`
+
+	var balance float64
+	if err := r.db.QueryRowContext(ctx, q, date).Scan(&balance); err != nil {
+		return 0, fmt.Errorf("report balance before %s: %w", date.Format("2006-01-02"), err)
+	}
+	return balance, nil
+}
+
+// Roster dates each house from its registration or the first month it paid
+// for, whichever is earlier: houses carried over by migration 007 were
+// registered on the migration date.
+func (r *ReportRepo) Roster(ctx context.Context) ([]report.RosterHouse, error) {
+	const q = `
+		SELECT ct.id, ct.house_number, ct.name,
+		       LEAST(ct.created_at::date,
+		             COALESCE((SELECT MIN(make_date(c.year, c.month, 1)) FROM contributions c
+		                       WHERE c.contributor_id = ct.id), ct.created_at::date))
+		FROM contributors ct
+		ORDER BY ct.house_number`
+
+	rows, err := r.db.QueryContext(ctx, q)
+	if err != nil {
+		return nil, fmt.Errorf("report roster: %w", err)
+	}
+	defer rows.Close()
+
+	var result []report.RosterHouse
+	for rows.Next() {
+		var h report.RosterHouse
+		if err := rows.Scan(&h.ContributorID, &h.HouseNumber, &h.Name, &h.Since); err != nil {
+			return nil, fmt.Errorf("scan roster house: %w", err)
+		}
+		result = append(result, h)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("report roster: %w", err)
+	}
+	return result, nil
+}
+
+func (r *ReportRepo) CollectionCategories(ctx context.Context, year int) ([]report.CollectionCategory, error) {
+	const q = `
+		SELECT cc.id, cc.name
+		FROM contribution_categories cc
+		WHERE cc.is_active
+		   OR EXISTS (SELECT 1 FROM contributions c WHERE c.category_id = cc.id AND c.year = $1)
+		ORDER BY cc.name`
+
+	rows, err := r.db.QueryContext(ctx, q, year)
+	if err != nil {
+		return nil, fmt.Errorf("report collection categories: %w", err)
+	}
+	defer rows.Close()
+
+	var result []report.CollectionCategory
+	for rows.Next() {
+		var c report.CollectionCategory
+		if err := rows.Scan(&c.ID, &c.Name); err != nil {
+			return nil, fmt.Errorf("scan collection category: %w", err)
+		}
+		result = append(result, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("report collection categories: %w", err)
+	}
+	return result, nil
+}
+
+func (r *ReportRepo) PaidMarks(ctx context.Context, year int) ([]report.PaidMark, error) {
+	const q = `
+		SELECT DISTINCT contributor_id, category_id, month
+		FROM contributions
+		WHERE year = $1`
+
+	rows, err := r.db.QueryContext(ctx, q, year)
+	if err != nil {
+		return nil, fmt.Errorf("report paid marks: %w", err)
+	}
+	defer rows.Close()
+
+	var result []report.PaidMark
+	for rows.Next() {
+		var m report.PaidMark
+		if err := rows.Scan(&m.ContributorID, &m.CategoryID, &m.Month); err != nil {
+			return nil, fmt.Errorf("scan paid mark: %w", err)
+		}
+		result = append(result, m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("report paid marks: %w", err)
+	}
+	return result, nil
+}
+
+func (r *ReportRepo) ContributionHistory(ctx context.Context, from, to time.Time) ([]report.CategoryHistory, error) {
+	const q = `
+		SELECT cc.id, cc.name, COALESCE(AVG(c.amount), 0), COUNT(DISTINCT (c.contributor_id, c.year, c.month))
+		FROM contribution_categories cc
+		LEFT JOIN contributions c
+		       ON c.category_id = cc.id
+		      AND (c.year, c.month) >= ($1, $2)
+		      AND (c.year, c.month) < ($3, $4)
+		WHERE cc.is_active
+		GROUP BY cc.id, cc.name
+		ORDER BY cc.name`
+
+	rows, err := r.db.QueryContext(ctx, q, from.Year(), int(from.Month()), to.Year(), int(to.Month()))
+	if err != nil {
+		return nil, fmt.Errorf("report contribution history: %w", err)
+	}
+	defer rows.Close()
+
+	var result []report.CategoryHistory
+	for rows.Next() {
+		var h report.CategoryHistory
+		if err := rows.Scan(&h.CategoryID, &h.CategoryName, &h.AverageAmount, &h.Payments); err != nil {
+			return nil, fmt.Errorf("scan contribution history: %w", err)
+		}
+		result = append(result, h)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("report contribution history: %w", err)
+	}
+	return result, nil
+}
+
+func (r *ReportRepo) ExpenseTotalsByCategory(ctx context.Context, from, to time.Time) ([]report.ExpenseCategoryTotal, error) {
+	const q = `
+		SELECT ec.id, ec.name, SUM(l.amount)
+		FROM expense_lines l
+		JOIN expenses e ON e.id = l.expense_id
+		JOIN expense_categories ec ON ec.id = l.category_id
+		WHERE e.date >= $1 AND e.date < $2
+		GROUP BY ec.id, ec.name
+		ORDER BY ec.name`
+
+	rows, err := r.db.QueryContext(ctx, q, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("report expense totals: %w", err)
+	}
+	defer rows.Close()
+
+	var result []report.ExpenseCategoryTotal
+	for rows.Next() {
+		var t report.ExpenseCategoryTotal
+		if err := rows.Scan(&t.CategoryID, &t.CategoryName, &t.Amount); err != nil {
+			return nil, fmt.Errorf("scan expense total: %w", err)
+		}
+		result = append(result, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("report expense totals: %w", err)
+	}
+	return result, nil
+}
+
+func (r *ReportRepo) BudgetLines(ctx context.Context, year int) ([]report.BudgetAggregate, error) {
+	const q = `
+		SELECT b.kind, COALESCE(b.contribution_category_id, b.expense_category_id),
+		       COALESCE(cc.name, ec.name), b.amount
+		FROM budget_lines b
+		LEFT JOIN contribution_categories cc ON cc.id = b.contribution_category_id
+		LEFT JOIN expense_categories ec ON ec.id = b.expense_category_id
+		WHERE b.year = $1
+		ORDER BY b.kind, COALESCE(cc.name, ec.name)`
+
+	rows, err := r.db.QueryContext(ctx, q, year)
+	if err != nil {
+		return nil, fmt.Errorf("report budget lines: %w", err)
+	}
+	defer rows.Close()
+
+	var result []report.BudgetAggregate
+	for rows.Next() {
+		var b report.BudgetAggregate
+		if err := rows.Scan(&b.Kind, &b.CategoryID, &b.CategoryName, &b.Amount); err != nil {
+			return nil, fmt.Errorf("scan budget line: %w", err)
+		}
+		result = append(result, b)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("report budget lines: %w", err)
+	}
+	return result, nil
+}
+
+func (r *ReportRepo) IssuedReceipts(ctx context.Context, year int) ([]report.IssuedReceipt, error) {
+	const q = `
+		SELECT rf.folio, ct.house_number, ct.name, rf.receipt_year, rf.signer_name, rf.signed_at,
+		       rc.id IS NOT NULL, COALESCE(rp.folio, '')
+		FROM receipt_folios rf
+		JOIN contributors ct ON ct.id = rf.contributor_id
+		LEFT JOIN receipt_cancellations rc ON rc.receipt_folio_id = rf.id
+		LEFT JOIN receipt_folios rp ON rp.id = rc.replacement_folio_id
+		WHERE rf.receipt_year = $1
+		ORDER BY rf.year_issued, rf.seq_number`
+
+	rows, err := r.db.QueryContext(ctx, q, year)
+	if err != nil {
+		return nil, fmt.Errorf("report issued receipts: %w", err)
+	}
+	defer rows.Close()
+
+	var result []report.IssuedReceipt
+	for rows.Next() {
+		var rc report.IssuedReceipt
+		if err := rows.Scan(&rc.Folio, &rc.HouseNumber, &rc.ContributorName, &rc.ReceiptYear, &rc.SignerName, &rc.SignedAt,
+			&rc.Cancelled, &rc.ReplacementFolio); err != nil {
+			return nil, fmt.Errorf("scan issued receipt: %w", err)
+		}
+		result = append(result, rc)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("report issued receipts: %w", err)
+	}
+	return result, nil
+}
+
 func (r *ReportRepo) scanAggregates(ctx context.Context, query string, args ...any) ([]report.MonthAggregate, error) {
 	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
@@ -59,3 +490,45 @@ func (r *ReportRepo) scanAggregates(ctx context.Context, query string, args ...a
 	}
 	return result, nil
 }
+
+func (r *ReportRepo) scanDayAggregates(ctx context.Context, query string, args ...any) ([]report.DayAggregate, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("report day aggregate: %w", err)
+	}
+	defer rows.Close()
+
+	var result []report.DayAggregate
+	for rows.Next() {
+		var a report.DayAggregate
+		if err := rows.Scan(&a.Date, &a.Amount); err != nil {
+			return nil, fmt.Errorf("scan day aggregate: %w", err)
+		}
+		result = append(result, a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("report day aggregate: %w", err)
+	}
+	return result, nil
+}
+
+func (r *ReportRepo) scanCategoryAggregates(ctx context.Context, query string, args ...any) ([]report.CategoryMonthAggregate, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("report category aggregate: %w", err)
+	}
+	defer rows.Close()
+
+	var result []report.CategoryMonthAggregate
+	for rows.Next() {
+		var a report.CategoryMonthAggregate
+		if err := rows.Scan(&a.CategoryID, &a.CategoryName, &a.Month, &a.Amount); err != nil {
+			return nil, fmt.Errorf("scan category aggregate: %w", err)
+		}
+		result = append(result, a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("report category aggregate: %w", err)
+	}
+	return result, nil
+}
@@ -0,0 +1,123 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/lib/pq"
+
+	"github.com/ivsanmendez/ControlDeContabilidad/internal/domain/signingcert"
+)
+
+// SigningCertificateRepo implements signingcert.Repository.
+type SigningCertificateRepo struct {
+	db *sql.DB
+}
+
+func NewSigningCertificateRepo(db *sql.DB) *SigningCertificateRepo {
+	return &SigningCertificateRepo{db: db}
+}
+
+const signingCertificateSelect = `
+	SELECT id, user_id, label, serial, rfc, holder, certificate, encrypted_key,
+	       not_before, not_after, uploaded_by, uploaded_at, deactivated_by, deactivated_at
+	FROM signing_certificates`
+
+func (r *SigningCertificateRepo) Save(ctx context.Context, c *signingcert.Certificate) error {
+	const q = `
+		INSERT INTO signing_certificates
+			(user_id, label, serial, rfc, holder, certificate, encrypted_key, not_before, not_after, uploaded_by, uploaded_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		RETURNING id`
+
+	err := r.db.QueryRowContext(ctx, q,
+		c.UserID, c.Label, c.Serial, c.RFC, c.Holder, c.CertificateDER, c.EncryptedKey,
+		c.NotBefore, c.NotAfter, c.UploadedBy, c.UploadedAt,
+	).Scan(&c.ID)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+			return signingcert.ErrDuplicate
+		}
+		return fmt.Errorf("save signing certificate: %w", err)
+	}
+	return nil
+}
+
+func (r *SigningCertificateRepo) FindByID(ctx context.Context, id int64) (*signingcert.Certificate, error) {
+	certs, err := r.scanMany(ctx, signingCertificateSelect+` WHERE id = $1`, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(certs) == 0 {
+		return nil, signingcert.ErrNotFound
+	}
+	return &certs[0], nil
+}
+
+func (r *SigningCertificateRepo) List(ctx context.Context, userID int64) ([]signingcert.Certificate, error) {
+	q := signingCertificateSelect + `
+		WHERE ($1::bigint IS NULL OR user_id = $1)
+		ORDER BY deactivated_at IS NOT NULL, user_id, uploaded_at DESC`
+	return r.scanMany(ctx, q, nullIfZero(userID))
+}
+
+func (r *SigningCertificateRepo) Deactivate(ctx context.Context, c *signingcert.Certificate) error {
+	const q = `
+		UPDATE signing_certificates
+		SET deactivated_by = $1, deactivated_at = $2
+		WHERE id = $3 AND deactivated_at IS NULL`
+
+	result, err := r.db.ExecContext(ctx, q, c.DeactivatedBy, c.DeactivatedAt, c.ID)
+	if err != nil {
+		return fmt.Errorf("deactivate signing certificate %d: %w", c.ID, err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("deactivate signing certificate %d: %w", c.ID, err)
+	}
+	if rows == 0 {
+		return signingcert.ErrInactive
+	}
+	return nil
+}
+
+// --- Scanners ---
+
+func (r *SigningCertificateRepo) scanMany(ctx context.Context, query string, args ...any) ([]signingcert.Certificate, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("list signing certificates: %w", err)
+	}
+	defer rows.Close()
+
+	var certs []signingcert.Certificate
+	for rows.Next() {
+		var c signingcert.Certificate
+		if err := rows.Scan(
+			&c.ID,
+			&c.UserID,
+			&c.Label,
+			&c.Serial,
+			&c.RFC,
+			&c.Holder,
+			&c.CertificateDER,
+			&c.EncryptedKey,
+			&c.NotBefore,
+			&c.NotAfter,
+			&c.UploadedBy,
+			&c.UploadedAt,
+			&c.DeactivatedBy,
+			&c.DeactivatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("scan signing certificate: %w", err)
+		}
+		certs = append(certs, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("list signing certificates: %w", err)
+	}
+	return certs, nil
+}
@@ -0,0 +1,193 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/lib/pq"
+
+	"github.com/ivsanmendez/ControlDeContabilidad/internal/domain/supplier"
+)
+
+// SupplierRepo implements supplier.Repository.
+type SupplierRepo struct {
+	db *sql.DB
+}
+
+func NewSupplierRepo(db *sql.DB) *SupplierRepo {
+	return &SupplierRepo{db: db}
+}
+
+const supplierSelect = `
+	SELECT id, name, COALESCE(rfc, ''), COALESCE(contact_name, ''), COALESCE(phone, ''),
+	       COALESCE(email, ''), COALESCE(clabe, ''), default_category_id, is_active, user_id, created_at, updated_at
+	FROM suppliers`
+
+func (r *SupplierRepo) Save(ctx context.Context, s *supplier.Supplier) error {
+	const q = `
+		INSERT INTO suppliers (name, rfc, contact_name, phone, email, clabe, default_category_id, is_active, user_id, created_at, updated_at)
+		VALUES ($1, NULLIF($2, ''), NULLIF($3, ''), NULLIF($4, ''), NULLIF($5, ''), NULLIF($6, ''), $7, $8, $9, $10, $11)
+		RETURNING id`
+
+	err := r.db.QueryRowContext(ctx, q,
+		s.Name,
+		s.RFC,
+		s.ContactName,
+		s.Phone,
+		s.Email,
+		s.CLABE,
+		s.DefaultCategoryID,
+		s.IsActive,
+		s.UserID,
+		s.CreatedAt,
+		s.UpdatedAt,
+	).Scan(&s.ID)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+			return supplier.ErrDuplicate
+		}
+		return fmt.Errorf("save supplier: %w", err)
+	}
+	return nil
+}
+
+func (r *SupplierRepo) FindByID(ctx context.Context, id int64) (*supplier.Supplier, error) {
+	q := supplierSelect + ` WHERE id = $1`
+
+	s, err := r.scanOne(ctx, q, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, supplier.ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("find supplier %d: %w", id, err)
+	}
+	return s, nil
+}
+
+func (r *SupplierRepo) FindAll(ctx context.Context) ([]supplier.Supplier, error) {
+	q := supplierSelect + ` ORDER BY name`
+	return r.scanMany(ctx, q)
+}
+
+func (r *SupplierRepo) FindActive(ctx context.Context) ([]supplier.Supplier, error) {
+	q := supplierSelect + ` WHERE is_active = TRUE ORDER BY name`
+	return r.scanMany(ctx, q)
+}
+
+func (r *SupplierRepo) Update(ctx context.Context, s *supplier.Supplier) error {
+	const q = `
+		UPDATE suppliers
+		SET name = $1, rfc = NULLIF($2, ''), contact_name = NULLIF($3, ''), phone = NULLIF($4, ''),
+		    email = NULLIF($5, ''), clabe = NULLIF($6, ''), default_category_id = $7, is_active = $8, updated_at = $9
+		WHERE id = $10`
+
+	result, err := r.db.ExecContext(ctx, q,
+		s.Name,
+		s.RFC,
+		s.ContactName,
+		s.Phone,
+		s.Email,
+		s.CLABE,
+		s.DefaultCategoryID,
+		s.IsActive,
+		s.UpdatedAt,
+		s.ID,
+	)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+			return supplier.ErrDuplicate
+		}
+		return fmt.Errorf("update supplier %d: %w", s.ID, err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("update supplier %d: %w", s.ID, err)
+	}
+	if rows == 0 {
+		return supplier.ErrNotFound
+	}
+	return nil
+}
+
+func (r *SupplierRepo) Delete(ctx context.Context, id int64) error {
+	const q = `DELETE FROM suppliers WHERE id = $1`
+
+	result, err := r.db.ExecContext(ctx, q, id)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23503" {
+			return fmt.Errorf("cannot delete: supplier is referenced by expenses")
+		}
+		return fmt.Errorf("delete supplier %d: %w", id, err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("delete supplier %d: %w", id, err)
+	}
+	if rows == 0 {
+		return supplier.ErrNotFound
+	}
+	return nil
+}
+
+// --- Scanners ---
+
+func (r *SupplierRepo) scanOne(ctx context.Context, query string, args ...any) (*supplier.Supplier, error) {
+	var s supplier.Supplier
+	err := r.db.QueryRowContext(ctx, query, args...).Scan(
+		&s.ID,
+		&s.Name,
+		&s.RFC,
+		&s.ContactName,
+		&s.Phone,
+		&s.Email,
+		&s.CLABE,
+		&s.DefaultCategoryID,
+		&s.IsActive,
+		&s.UserID,
+		&s.CreatedAt,
+		&s.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+func (r *SupplierRepo) scanMany(ctx context.Context, query string, args ...any) ([]supplier.Supplier, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("list suppliers: %w", err)
+	}
+	defer rows.Close()
+
+	var suppliers []supplier.Supplier
+	for rows.Next() {
+		var s supplier.Supplier
+		if err := rows.Scan(
+			&s.ID,
+			&s.Name,
+			&s.RFC,
+			&s.ContactName,
+			&s.Phone,
+			&s.Email,
+			&s.CLABE,
+			&s.DefaultCategoryID,
+			&s.IsActive,
+			&s.UserID,
+			&s.CreatedAt,
+			&s.UpdatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("scan supplier: %w", err)
+		}
+		suppliers = append(suppliers, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("list suppliers: %w", err)
+	}
+	return suppliers, nil
+}
@@ -0,0 +1,291 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+
+	"github.com/ivsanmendez/ControlDeContabilidad/internal/domain/treasury"
+)
+
+// TreasuryRepo implements treasury.Repository.
+type TreasuryRepo struct {
+	db *sql.DB
+}
+
+func NewTreasuryRepo(db *sql.DB) *TreasuryRepo {
+	return &TreasuryRepo{db: db}
+}
+
+const financialAccountSelect = `
+	SELECT fa.id, fa.name, fa.kind, fa.bank_name, fa.account_number, fa.opening_balance, fa.ledger_account_id,
+	       fa.is_default, fa.is_active, COALESCE(fa.user_id, 0), fa.created_at, fa.updated_at
+	FROM financial_accounts fa`
+
+func (r *TreasuryRepo) Save(ctx context.Context, a *treasury.Account) error {
+	const q = `
+		INSERT INTO financial_accounts (name, kind, bank_name, account_number, opening_balance, ledger_account_id, is_default, is_active, user_id, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		RETURNING id`
+
+	err := r.db.QueryRowContext(ctx, q,
+		a.Name,
+		string(a.Kind),
+		a.BankName,
+		a.AccountNumber,
+		a.OpeningBalance,
+		a.LedgerAccountID,
+		a.IsDefault,
+		a.IsActive,
+		a.UserID,
+		a.CreatedAt,
+		a.UpdatedAt,
+	).Scan(&a.ID)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+			return treasury.ErrDuplicate
+		}
+		return fmt.Errorf("save financial account: %w", err)
+	}
+	return nil
+}
+
+func (r *TreasuryRepo) Update(ctx context.Context, a *treasury.Account) error {
+	const q = `
+		UPDATE financial_accounts
+		SET name = $1, kind = $2, bank_name = $3, account_number = $4, opening_balance = $5,
+		    ledger_account_id = $6, is_active = $7, updated_at = $8
+		WHERE id = $9`
+
+	result, err := r.db.ExecContext(ctx, q,
+		a.Name,
+		string(a.Kind),
+		a.BankName,
+		a.AccountNumber,
+		a.OpeningBalance,
+		a.LedgerAccountID,
+		a.IsActive,
+		a.UpdatedAt,
+		a.ID,
+	)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+			return treasury.ErrDuplicate
+		}
+		return fmt.Errorf("update financial account %d: %w", a.ID, err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("update financial account %d: %w", a.ID, err)
+	}
+	if rows == 0 {
+		return treasury.ErrNotFound
+	}
+	return nil
+}
+
+func (r *TreasuryRepo) FindByID(ctx context.Context, id int64) (*treasury.Account, error) {
+	q := financialAccountSelect + ` WHERE fa.id = $1`
+
+	a, err := scanFinancialAccount(r.db.QueryRowContext(ctx, q, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, treasury.ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("find financial account %d: %w", id, err)
+	}
+	return a, nil
+}
+
+func (r *TreasuryRepo) FindAll(ctx context.Context) ([]treasury.Account, error) {
+	q := financialAccountSelect + ` ORDER BY fa.is_default DESC, fa.name`
+
+	rows, err := r.db.QueryContext(ctx, q)
+	if err != nil {
+		return nil, fmt.Errorf("list financial accounts: %w", err)
+	}
+	defer rows.Close()
+
+	var accounts []treasury.Account
+	for rows.Next() {
+		a, err := scanFinancialAccount(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan financial account: %w", err)
+		}
+		accounts = append(accounts, *a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("list financial accounts: %w", err)
+	}
+	return accounts, nil
+}
+
+func (r *TreasuryRepo) FindBalances(ctx context.Context, asOf time.Time) ([]treasury.AccountBalance, error) {
+	const q = `
+		SELECT fa.id, fa.name, fa.kind, fa.bank_name, fa.account_number, fa.opening_balance, fa.ledger_account_id,
+		       fa.is_default, fa.is_active, COALESCE(fa.user_id, 0), fa.created_at, fa.updated_at,
+		       COALESCE((SELECT SUM(c.amount) FROM contributions c
+		                 WHERE c.financial_account_id = fa.id AND c.payment_date <= $1::date), 0),
+		       COALESCE((SELECT SUM(e.amount) FROM expenses e
+		                 WHERE e.financial_account_id = fa.id AND e.date <= $1::date), 0),
+		       COALESCE((SELECT SUM(t.amount) FROM transfers t
+		                 WHERE t.to_account_id = fa.id AND t.date <= $1::date), 0),
+		       COALESCE((SELECT SUM(t.amount) FROM transfers t
+		                 WHERE t.from_account_id = fa.id AND t.date <= $1::date), 0)
+		FROM financial_accounts fa
+		ORDER BY fa.is_default DESC, fa.name`
+
+	rows, err := r.db.QueryContext(ctx, q, asOf)
+	if err != nil {
+		return nil, fmt.Errorf("financial account balances: %w", err)
+	}
+	defer rows.Close()
+
+	var balances []treasury.AccountBalance
+	for rows.Next() {
+		var b treasury.AccountBalance
+		var kind string
+		if err := rows.Scan(
+			&b.ID,
+			&b.Name,
+			&kind,
+			&b.BankName,
+			&b.AccountNumber,
+			&b.OpeningBalance,
+			&b.LedgerAccountID,
+			&b.IsDefault,
+			&b.IsActive,
+			&b.UserID,
+			&b.CreatedAt,
+			&b.UpdatedAt,
+			&b.Income,
+			&b.Expenses,
+			&b.TransfersIn,
+			&b.TransfersOut,
+		); err != nil {
+			return nil, fmt.Errorf("scan financial account balance: %w", err)
+		}
+		b.Kind = treasury.Kind(kind)
+		balances = append(balances, b)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("financial account balances: %w", err)
+	}
+	return balances, nil
+}
+
+// --- Transfers ---
+
+const transferSelect = `
+	SELECT id, from_account_id, to_account_id, amount, date, description, user_id, created_at
+	FROM transfers`
+
+func (r *TreasuryRepo) SaveTransfer(ctx context.Context, t *treasury.Transfer) error {
+	const q = `
+		INSERT INTO transfers (from_account_id, to_account_id, amount, date, description, user_id, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id`
+
+	err := r.db.QueryRowContext(ctx, q,
+		t.FromAccountID,
+		t.ToAccountID,
+		t.Amount,
+		t.Date,
+		t.Description,
+		t.UserID,
+		t.CreatedAt,
+	).Scan(&t.ID)
+	if err != nil {
+		return fmt.Errorf("save transfer: %w", err)
+	}
+	return nil
+}
+
+func (r *TreasuryRepo) FindTransferByID(ctx context.Context, id int64) (*treasury.Transfer, error) {
+	q := transferSelect + ` WHERE id = $1`
+
+	var t treasury.Transfer
+	err := r.db.QueryRowContext(ctx, q, id).Scan(
+		&t.ID, &t.FromAccountID, &t.ToAccountID, &t.Amount, &t.Date, &t.Description, &t.UserID, &t.CreatedAt,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, treasury.ErrTransferNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("find transfer %d: %w", id, err)
+	}
+	return &t, nil
+}
+
+func (r *TreasuryRepo) FindTransfers(ctx context.Context, accountID int64) ([]treasury.Transfer, error) {
+	q := transferSelect + ` WHERE $1::BIGINT = 0 OR from_account_id = $1 OR to_account_id = $1 ORDER BY date DESC, id DESC`
+
+	rows, err := r.db.QueryContext(ctx, q, accountID)
+	if err != nil {
+		return nil, fmt.Errorf("list transfers: %w", err)
+	}
+	defer rows.Close()
+
+	var transfers []treasury.Transfer
+	for rows.Next() {
+		var t treasury.Transfer
+		if err := rows.Scan(
+			&t.ID, &t.FromAccountID, &t.ToAccountID, &t.Amount, &t.Date, &t.Description, &t.UserID, &t.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("scan transfer: %w", err)
+		}
+		transfers = append(transfers, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("list transfers: %w", err)
+	}
+	return transfers, nil
+}
+
+func (r *TreasuryRepo) DeleteTransfer(ctx context.Context, id int64) error {
+	const q = `DELETE FROM transfers WHERE id = $1`
+
+	result, err := r.db.ExecContext(ctx, q, id)
+	if err != nil {
+		return fmt.Errorf("delete transfer %d: %w", id, err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("delete transfer %d: %w", id, err)
+	}
+	if rows == 0 {
+		return treasury.ErrTransferNotFound
+	}
+	return nil
+}
+
+// --- Scanners ---
+
+func scanFinancialAccount(row interface{ Scan(...any) error }) (*treasury.Account, error) {
+	var a treasury.Account
+	var kind string
+	if err := row.Scan(
+		&a.ID,
+		&a.Name,
+		&kind,
+		&a.BankName,
+		&a.AccountNumber,
+		&a.OpeningBalance,
+		&a.LedgerAccountID,
+		&a.IsDefault,
+		&a.IsActive,
+		&a.UserID,
+		&a.CreatedAt,
+		&a.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+	a.Kind = treasury.Kind(kind)
+	return &a, nil
+}
@@ -0,0 +1,66 @@
+// Package annualreport issues the signed yearly treasury report presented to
+// the community: monthly balance, category breakdowns, budget variance and
+// the receipts issued, sealed with the receipt signing certificate.
+package annualreport
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ivsanmendez/ControlDeContabilidad/internal/domain/report"
+)
+
+var (
+	ErrNotFound           = errors.New("annual report not found")
+	ErrInvalidYear        = errors.New("year must be >= 2000")
+	ErrInvalidUserID      = errors.New("user ID must be positive")
+	ErrSignerNameRequired = errors.New("signer name is required")
+	ErrPasswordRequired   = errors.New("certificate password is required")
+	ErrSigningUnavailable = errors.New("signing certificate is not configured")
+	ErrInvalidPassword    = errors.New("invalid certificate password")
+)
+
+// Content is the signed body of an annual report. It is serialized once to
+// canonical JSON, signed, and stored verbatim, so the document can be
+// re-rendered and verified exactly as issued.
+type Content struct {
+	Folio       string                       `json:"folio"`
+	Year        int                          `json:"year"`
+	Balance     *report.MonthlyBalanceReport `json:"balance"`
+	Budget      *report.BudgetVarianceReport `json:"budget"`
+	Receipts    []report.IssuedReceipt       `json:"receipts"`
+	SignerName  string                       `json:"signer_name"`
+	GeneratedAt time.Time                    `json:"generated_at"`
+}
+
+// Document is a persisted, signed annual report with its own folio.
+type Document struct {
+	ID            int64
+	Folio         string
+	YearIssued    int
+	SeqNumber     int
+	UUIDSuffix    string
+	ReportYear    int
+	SignerName    string
+	UserID        int64
+	CanonicalJSON []byte
+	Signature     []byte
+	Certificate   []byte
+	SignedAt      time.Time
+}
+
+// Content decodes the signed body.
+func (d *Document) Content() (*Content, error) {
+	var c Content
+	if err := json.Unmarshal(d.CanonicalJSON, &c); err != nil {
+		return nil, fmt.Errorf("decode annual report %s: %w", d.Folio, err)
+	}
+	return &c, nil
+}
+
+// GenerateFolio formats a folio string: INF-YYYY-NNNNNN-XXXXXXXX
+func GenerateFolio(year, seq int, suffix string) string {
+	return fmt.Sprintf("INF-%04d-%06d-%s", year, seq, suffix)
+}
@@ -0,0 +1,156 @@
+package annualreport
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ivsanmendez/ControlDeContabilidad/internal/domain/receipt"
+	"github.com/ivsanmendez/ControlDeContabilidad/internal/domain/report"
+)
+
+// Repository is the outbound port for annual report persistence.
+type Repository interface {
+	NextSequence(ctx context.Context, year int) (int, error)
+	Save(ctx context.Context, d *Document) error
+	FindByFolio(ctx context.Context, folio string) (*Document, error)
+	// FindByReportYear lists the reports issued for a fiscal year, newest first.
+	FindByReportYear(ctx context.Context, year int) ([]Document, error)
+}
+
+// Reports is the subset of the report service the annual report is built from.
+type Reports interface {
+	GetMonthlyBalance(ctx context.Context, year int, basis report.Basis) (*report.MonthlyBalanceReport, error)
+	GetBudgetVariance(ctx context.Context, year int, basis report.Basis) (*report.BudgetVarianceReport, error)
+	GetIssuedReceipts(ctx context.Context, year int) ([]report.IssuedReceipt, error)
+}
+
+// Signer signs the canonical content with the issuer's SAT certificate.
+// Sign fails with an error wrapping receipt.ErrInvalidPassword when password
+// does not decrypt the private key.
+type Signer interface {
+	Sign(data []byte, password string) ([]byte, error)
+	Certificate() []byte
+	Available() bool
+}
+
+// Service orchestrates annual report use cases.
+type Service struct {
+	repo    Repository
+	reports Reports
+	now     func() time.Time
+}
+
+func NewService(repo Repository, reports Reports) *Service {
+	return &Service{repo: repo, reports: reports, now: time.Now}
+}
+
+// Issue gathers the year's figures on a cash basis, signs them with signer,
+// the certificate the caller chose, and stores the report under a new folio.
+func (s *Service) Issue(ctx context.Context, signer Signer, callerID int64, year int, signerName, password string) (*Document, error) {
+	if callerID <= 0 {
+		return nil, ErrInvalidUserID
+	}
+	if year < 2000 {
+		return nil, ErrInvalidYear
+	}
+	signerName = strings.TrimSpace(signerName)
+	if signerName == "" {
+		return nil, ErrSignerNameRequired
+	}
+	if password == "" {
+		return nil, ErrPasswordRequired
+	}
+	if !signer.Available() {
+		return nil, ErrSigningUnavailable
+	}
+
+	balance, err := s.reports.GetMonthlyBalance(ctx, year, report.BasisCash)
+	if err != nil {
+		return nil, err
+	}
+	budget, err := s.reports.GetBudgetVariance(ctx, year, report.BasisCash)
+	if err != nil {
+		return nil, err
+	}
+	receipts, err := s.reports.GetIssuedReceipts(ctx, year)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.now().UTC()
+	seq, err := s.repo.NextSequence(ctx, now.Year())
+	if err != nil {
+		return nil, err
+	}
+	suffix, err := receipt.GenerateUUIDSuffix()
+	if err != nil {
+		return nil, err
+	}
+	folio := GenerateFolio(now.Year(), seq, suffix)
+
+	canonical, err := json.Marshal(Content{
+		Folio:       folio,
+		Year:        year,
+		Balance:     balance,
+		Budget:      budget,
+		Receipts:    receipts,
+		SignerName:  signerName,
+		GeneratedAt: now,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("serialize annual report: %w", err)
+	}
+
+	sig, err := signer.Sign(canonical, password)
+	if err != nil {
+		if errors.Is(err, receipt.ErrInvalidPassword) {
+			return nil, ErrInvalidPassword
+		}
+		return nil, fmt.Errorf("sign annual report: %w", err)
+	}
+
+	d := &Document{
+		Folio:         folio,
+		YearIssued:    now.Year(),
+		SeqNumber:     seq,
+		UUIDSuffix:    suffix,
+		ReportYear:    year,
+		SignerName:    signerName,
+		UserID:        callerID,
+		CanonicalJSON: canonical,
+		Signature:     sig,
+		Certificate:   signer.Certificate(),
+		SignedAt:      now,
+	}
+	if err := s.repo.Save(ctx, d); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+func (s *Service) GetReport(ctx context.Context, folio string) (*Document, error) {
+	return s.repo.FindByFolio(ctx, folio)
+}
+
+// VerifyReport loads a report and checks its seal the way receipts are
+// checked: the signature over the stored content and the certificate's
+// validity window at signing time.
+func (s *Service) VerifyReport(ctx context.Context, folio string) (*Document, *receipt.Verdict, error) {
+	d, err := s.repo.FindByFolio(ctx, folio)
+	if err != nil {
+		return nil, nil, err
+	}
+	v := receipt.VerifySeal(d.Certificate, d.CanonicalJSON, d.Signature, d.SignedAt)
+	return d, &v, nil
+}
+
+func (s *Service) ListReports(ctx context.Context, year int) ([]Document, error) {
+	if year < 2000 {
+		return nil, ErrInvalidYear
+	}
+	return s.repo.FindByReportYear(ctx, year)
+}
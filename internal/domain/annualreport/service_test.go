@@ -0,0 +1,213 @@
+package annualreport_test
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ivsanmendez/ControlDeContabilidad/internal/domain/annualreport"
+	"github.com/ivsanmendez/ControlDeContabilidad/internal/domain/receipt"
+	"github.com/ivsanmendez/ControlDeContabilidad/internal/domain/report"
+)
+
+// --- Fakes ---
+
+type fakeRepo struct {
+	seq  int
+	docs []annualreport.Document
+}
+
+func (r *fakeRepo) NextSequence(_ context.Context, _ int) (int, error) {
+	r.seq++
+	return r.seq, nil
+}
+
+func (r *fakeRepo) Save(_ context.Context, d *annualreport.Document) error {
+	d.ID = int64(len(r.docs) + 1)
+	r.docs = append(r.docs, *d)
+	return nil
+}
+
+func (r *fakeRepo) FindByFolio(_ context.Context, folio string) (*annualreport.Document, error) {
+	for _, d := range r.docs {
+		if d.Folio == folio {
+			return &d, nil
+		}
+	}
+	return nil, annualreport.ErrNotFound
+}
+
+func (r *fakeRepo) FindByReportYear(_ context.Context, year int) ([]annualreport.Document, error) {
+	var result []annualreport.Document
+	for _, d := range r.docs {
+		if d.ReportYear == year {
+			result = append(result, d)
+		}
+	}
+	return result, nil
+}
+
+type fakeReports struct{}
+
+func (fakeReports) GetMonthlyBalance(_ context.Context, year int, basis report.Basis) (*report.MonthlyBalanceReport, error) {
+	return &report.MonthlyBalanceReport{Year: year, Basis: basis, TotalIncome: 1000}, nil
+}
+
+func (fakeReports) GetBudgetVariance(_ context.Context, year int, basis report.Basis) (*report.BudgetVarianceReport, error) {
+	return &report.BudgetVarianceReport{Year: year, Basis: basis}, nil
+}
+
+func (fakeReports) GetIssuedReceipts(_ context.Context, _ int) ([]report.IssuedReceipt, error) {
+	return []report.IssuedReceipt{{Folio: "REC-2026-000001-ABCDEF12"}}, nil
+}
+
+type fakeSigner struct {
+	available bool
+	signed    []byte
+}
+
+func (s *fakeSigner) Sign(data []byte, password string) ([]byte, error) {
+	if password != "secret" {
+		return nil, fmt.Errorf("certsigner: decrypt key: %w", receipt.ErrInvalidPassword)
+	}
+	s.signed = data
+	return []byte("signature"), nil
+}
+
+func (s *fakeSigner) Certificate() []byte { return []byte("certificate") }
+func (s *fakeSigner) Available() bool     { return s.available }
+
+func TestIssue_SignsAndStoresContent(t *testing.T) {
+	repo := &fakeRepo{}
+	signer := &fakeSigner{available: true}
+	svc := annualreport.NewService(repo, fakeReports{})
+
+	d, err := svc.Issue(context.Background(), signer, 1, 2025, " Tesorera ", "secret")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.HasPrefix(d.Folio, "INF-") || d.ReportYear != 2025 || d.SignerName != "Tesorera" {
+		t.Errorf("unexpected document: %+v", d)
+	}
+	if string(d.CanonicalJSON) != string(signer.signed) {
+		t.Error("stored content must be exactly what was signed")
+	}
+
+	c, err := d.Content()
+	if err != nil {
+		t.Fatalf("decode content: %v", err)
+	}
+	if c.Folio != d.Folio || c.Balance.TotalIncome != 1000 || len(c.Receipts) != 1 {
+		t.Errorf("unexpected content: %+v", c)
+	}
+	if c.Balance.Basis != report.BasisCash {
+		t.Errorf("annual report should be on a cash basis, got %q", c.Balance.Basis)
+	}
+
+	got, err := svc.GetReport(context.Background(), d.Folio)
+	if err != nil || got.ID != d.ID {
+		t.Errorf("expected stored report, got %+v, %v", got, err)
+	}
+}
+
+func TestIssue_Errors(t *testing.T) {
+	tests := []struct {
+		name      string
+		available bool
+		year      int
+		signer    string
+		password  string
+		want      error
+	}{
+		{"invalid year", true, 1999, "Tesorera", "secret", annualreport.ErrInvalidYear},
+		{"missing signer", true, 2025, " ", "secret", annualreport.ErrSignerNameRequired},
+		{"missing password", true, 2025, "Tesorera", "", annualreport.ErrPasswordRequired},
+		{"signer unavailable", false, 2025, "Tesorera", "secret", annualreport.ErrSigningUnavailable},
+		{"wrong password", true, 2025, "Tesorera", "wrong", annualreport.ErrInvalidPassword},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeRepo{}
+			svc := annualreport.NewService(repo, fakeReports{})
+			_, err := svc.Issue(context.Background(), &fakeSigner{available: tt.available}, 1, tt.year, tt.signer, tt.password)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("expected %v, got %v", tt.want, err)
+			}
+			if len(repo.docs) != 0 {
+				t.Error("failed issue must not store a report")
+			}
+		})
+	}
+}
+
+func TestVerifyReport(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "María López"},
+		NotBefore:    time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		NotAfter:     time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	cert, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	content := []byte(`{"folio":"INF-2026-000001-ABCDEF12","year":2025}`)
+	digest := sha256.Sum256(content)
+	sig, err := rsa.SignPKCS1v15(nil, key, crypto.SHA256, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	signedAt := time.Date(2026, 1, 15, 10, 0, 0, 0, time.UTC)
+	repo := &fakeRepo{docs: []annualreport.Document{
+		{Folio: "INF-2026-000001-ABCDEF12", CanonicalJSON: content, Signature: sig, Certificate: cert, SignedAt: signedAt},
+		{Folio: "INF-2026-000002-ABCDEF12", CanonicalJSON: []byte(`{"folio":"INF-2026-000001-ABCDEF12","year":2024}`), Signature: sig, Certificate: cert, SignedAt: signedAt},
+		{Folio: "INF-2027-000001-ABCDEF12", CanonicalJSON: content, Signature: sig, Certificate: cert, SignedAt: time.Date(2027, 2, 1, 0, 0, 0, 0, time.UTC)},
+	}}
+	svc := annualreport.NewService(repo, fakeReports{})
+
+	tests := []struct {
+		name    string
+		folio   string
+		valid   bool
+		problem receipt.Problem
+	}{
+		{"genuine", "INF-2026-000001-ABCDEF12", true, ""},
+		{"tampered content", "INF-2026-000002-ABCDEF12", false, receipt.ProblemSignatureMismatch},
+		{"signed after expiry", "INF-2027-000001-ABCDEF12", false, receipt.ProblemCertificateExpired},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, v, err := svc.VerifyReport(context.Background(), tt.folio)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if d.Folio != tt.folio || v.Valid != tt.valid {
+				t.Fatalf("expected valid=%v for %s, got %+v", tt.valid, d.Folio, v)
+			}
+			if tt.problem != "" && !slices.Contains(v.Problems, tt.problem) {
+				t.Errorf("expected %s among %v", tt.problem, v.Problems)
+			}
+		})
+	}
+
+	if _, _, err := svc.VerifyReport(context.Background(), "INF-0000-000000-00000000"); !errors.Is(err, annualreport.ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,69 @@
+package budget
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrInvalidYear     = errors.New("year must be >= 2000")
+	ErrInvalidKind     = errors.New("budget line kind must be income or expense")
+	ErrInvalidCategory = errors.New("budget line needs a category")
+	ErrNegativeAmount  = errors.New("budgeted amount cannot be negative")
+	ErrDuplicateLine   = errors.New("category is budgeted more than once")
+	ErrUnknownCategory = errors.New("budgeted category does not exist")
+	ErrInvalidUserID   = errors.New("user ID must be positive")
+)
+
+// Kind tells whether a line budgets income (by contribution category) or
+// expenses (by expense category).
+type Kind string
+
+const (
+	KindIncome  Kind = "income"
+	KindExpense Kind = "expense"
+)
+
+func (k Kind) Valid() bool {
+	return k == KindIncome || k == KindExpense
+}
+
+// Line is the amount budgeted for one category over a whole year.
+type Line struct {
+	ID           int64
+	Year         int
+	Kind         Kind
+	CategoryID   int64
+	CategoryName string
+	Amount       float64
+	UserID       int64
+	UpdatedAt    time.Time
+}
+
+// NewLine creates a Line enforcing domain invariants.
+func NewLine(userID int64, year int, kind Kind, categoryID int64, amount float64) (*Line, error) {
+	if userID <= 0 {
+		return nil, ErrInvalidUserID
+	}
+	if year < 2000 {
+		return nil, ErrInvalidYear
+	}
+	if !kind.Valid() {
+		return nil, ErrInvalidKind
+	}
+	if categoryID <= 0 {
+		return nil, ErrInvalidCategory
+	}
+	if amount < 0 {
+		return nil, ErrNegativeAmount
+	}
+
+	return &Line{
+		Year:       year,
+		Kind:       kind,
+		CategoryID: categoryID,
+		Amount:     amount,
+		UserID:     userID,
+		UpdatedAt:  time.Now(),
+	}, nil
+}
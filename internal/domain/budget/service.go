@@ -0,0 +1,65 @@
+package budget
+
+import "context"
+
+// Repository is the outbound port for budget persistence.
+type Repository interface {
+	// ReplaceYear swaps every line of the year for lines in one transaction.
+	ReplaceYear(ctx context.Context, year int, lines []Line) error
+	FindByYear(ctx context.Context, year int) ([]Line, error)
+}
+
+// Service orchestrates budget use cases.
+type Service struct {
+	repo Repository
+}
+
+func NewService(repo Repository) *Service {
+	return &Service{repo: repo}
+}
+
+// LineInput is one category's annual amount in a SetBudget request.
+type LineInput struct {
+	Kind       Kind
+	CategoryID int64
+	Amount     float64
+}
+
+// SetBudget replaces the year's budget with the given lines. Categories left
+// out are no longer budgeted.
+func (s *Service) SetBudget(ctx context.Context, callerID int64, year int, inputs []LineInput) ([]Line, error) {
+	if year < 2000 {
+		return nil, ErrInvalidYear
+	}
+
+	type key struct {
+		kind     Kind
+		category int64
+	}
+	seen := make(map[key]bool, len(inputs))
+	lines := make([]Line, 0, len(inputs))
+	for _, in := range inputs {
+		l, err := NewLine(callerID, year, in.Kind, in.CategoryID, in.Amount)
+		if err != nil {
+			return nil, err
+		}
+		k := key{l.Kind, l.CategoryID}
+		if seen[k] {
+			return nil, ErrDuplicateLine
+		}
+		seen[k] = true
+		lines = append(lines, *l)
+	}
+
+	if err := s.repo.ReplaceYear(ctx, year, lines); err != nil {
+		return nil, err
+	}
+	return s.repo.FindByYear(ctx, year)
+}
+
+func (s *Service) GetBudget(ctx context.Context, year int) ([]Line, error) {
+	if year < 2000 {
+		return nil, ErrInvalidYear
+	}
+	return s.repo.FindByYear(ctx, year)
+}
@@ -0,0 +1,82 @@
+package budget_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ivsanmendez/ControlDeContabilidad/internal/domain/budget"
+)
+
+// --- Fakes ---
+
+type fakeRepo struct {
+	lines map[int][]budget.Line
+}
+
+func (r *fakeRepo) ReplaceYear(_ context.Context, year int, lines []budget.Line) error {
+	if r.lines == nil {
+		r.lines = make(map[int][]budget.Line)
+	}
+	r.lines[year] = lines
+	return nil
+}
+
+func (r *fakeRepo) FindByYear(_ context.Context, year int) ([]budget.Line, error) {
+	return r.lines[year], nil
+}
+
+func TestSetBudget_ReplacesYear(t *testing.T) {
+	repo := &fakeRepo{}
+	svc := budget.NewService(repo)
+
+	_, err := svc.SetBudget(context.Background(), 1, 2026, []budget.LineInput{
+		{Kind: budget.KindIncome, CategoryID: 1, Amount: 1200},
+		{Kind: budget.KindExpense, CategoryID: 1, Amount: 300},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	lines, err := svc.SetBudget(context.Background(), 1, 2026, []budget.LineInput{
+		{Kind: budget.KindExpense, CategoryID: 2, Amount: 450},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(lines) != 1 || lines[0].CategoryID != 2 || lines[0].Year != 2026 {
+		t.Errorf("expected the year to be replaced, got %+v", lines)
+	}
+}
+
+func TestSetBudget_Validation(t *testing.T) {
+	tests := []struct {
+		name   string
+		year   int
+		inputs []budget.LineInput
+		want   error
+	}{
+		{"invalid year", 1999, nil, budget.ErrInvalidYear},
+		{"invalid kind", 2026, []budget.LineInput{{Kind: "other", CategoryID: 1}}, budget.ErrInvalidKind},
+		{"missing category", 2026, []budget.LineInput{{Kind: budget.KindIncome}}, budget.ErrInvalidCategory},
+		{"negative amount", 2026, []budget.LineInput{{Kind: budget.KindIncome, CategoryID: 1, Amount: -1}}, budget.ErrNegativeAmount},
+		{"duplicate category", 2026, []budget.LineInput{
+			{Kind: budget.KindIncome, CategoryID: 1, Amount: 10},
+			{Kind: budget.KindIncome, CategoryID: 1, Amount: 20},
+		}, budget.ErrDuplicateLine},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeRepo{}
+			svc := budget.NewService(repo)
+			_, err := svc.SetBudget(context.Background(), 1, tt.year, tt.inputs)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("expected %v, got %v", tt.want, err)
+			}
+			if len(repo.lines) != 0 {
+				t.Error("invalid budget must not be saved")
+			}
+		})
+	}
+}
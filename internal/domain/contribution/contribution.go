@@ -42,19 +42,23 @@ type Contribution struct {
 	Year          int
 	PaymentDate   time.Time
 	PaymentMethod PaymentMethod
-	UserID        int64
-	CreatedAt     time.Time
-	UpdatedAt     time.Time
+	// FinancialAccountID is the cash box or bank account that received the
+	// money. Zero on create means the default account.
+	FinancialAccountID int64
+	UserID             int64
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
 }
 
 // ContributionDetail is a read-only DTO returned by JOIN queries,
 // enriching a Contribution with contributor and category info.
 type ContributionDetail struct {
 	Contribution
-	HouseNumber     string
-	ContributorName string
-	Phone           string
-	CategoryName    string
+	HouseNumber          string
+	ContributorName      string
+	Phone                string
+	CategoryName         string
+	FinancialAccountName string
 }
 
 // New creates a Contribution enforcing domain invariants.
@@ -0,0 +1,25 @@
+package contribution
+
+import (
+	"errors"
+	"time"
+)
+
+type EventType string
+
+const (
+	EventCreated EventType = "contribution.created"
+	EventUpdated EventType = "contribution.updated"
+	EventDeleted EventType = "contribution.deleted"
+)
+
+type Event struct {
+	Type         EventType
+	Contribution Contribution
+	OccurredAt   time.Time
+}
+
+// ErrNotPosted reports that the contribution was saved but a subscriber, such as
+// the ledger, failed to process its event. Rebuilding the ledger from its
+// source documents brings it back in line.
+var ErrNotPosted = errors.New("contribution saved but its ledger entry could not be updated")
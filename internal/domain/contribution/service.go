@@ -2,6 +2,7 @@ package contribution
 
 import (
 	"context"
+	"fmt"
 	"time"
 )
 
@@ -20,13 +21,26 @@ type Repository interface {
 	FindDetailedByContributorAndYear(ctx context.Context, contributorID int64, year int) ([]ContributionDetail, error)
 }
 
+// EventPublisher is the outbound port for contribution event dispatch.
+type EventPublisher interface {
+	Publish(ctx context.Context, event Event) error
+}
+
+// PeriodGuard is the outbound port that rejects changes dated in a closed
+// accounting period.
+type PeriodGuard interface {
+	EnsureOpen(ctx context.Context, date time.Time) error
+}
+
 // Service orchestrates contribution use cases.
 type Service struct {
-	repo Repository
+	repo    Repository
+	events  EventPublisher
+	periods PeriodGuard
 }
 
-func NewService(repo Repository) *Service {
-	return &Service{repo: repo}
+func NewService(repo Repository, events EventPublisher, periods PeriodGuard) *Service {
+	return &Service{repo: repo, events: events, periods: periods}
 }
 
 func (s *Service) CreateContribution(
@@ -39,14 +53,26 @@ func (s *Service) CreateContribution(
 	year int,
 	paymentDate time.Time,
 	paymentMethod PaymentMethod,
+	financialAccountID int64,
 ) (*Contribution, error) {
 	c, err := New(callerID, contributorID, categoryID, amount, month, year, paymentDate, paymentMethod)
 	if err != nil {
 		return nil, err
 	}
+	c.FinancialAccountID = financialAccountID
+	if err := s.periods.EnsureOpen(ctx, c.PaymentDate); err != nil {
+		return nil, err
+	}
 	if err := s.repo.Save(ctx, c); err != nil {
 		return nil, err
 	}
+	if err := s.events.Publish(ctx, Event{
+		Type:         EventCreated,
+		Contribution: *c,
+		OccurredAt:   time.Now(),
+	}); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrNotPosted, err)
+	}
 	return c, nil
 }
 
@@ -71,6 +97,7 @@ func (s *Service) UpdateContribution(
 	year int,
 	paymentDate time.Time,
 	paymentMethod PaymentMethod,
+	financialAccountID int64,
 ) (*Contribution, error) {
 	existing, err := s.repo.FindByID(ctx, id)
 	if err != nil {
@@ -95,6 +122,12 @@ func (s *Service) UpdateContribution(
 	if !paymentMethod.Valid() {
 		return nil, ErrInvalidPaymentMethod
 	}
+	// Both the period the payment leaves and the one it moves to must be open.
+	for _, d := range []time.Time{existing.PaymentDate, paymentDate} {
+		if err := s.periods.EnsureOpen(ctx, d); err != nil {
+			return nil, err
+		}
+	}
 
 	existing.ContributorID = contributorID
 	existing.CategoryID = categoryID
@@ -103,14 +136,41 @@ func (s *Service) UpdateContribution(
 	existing.Year = year
 	existing.PaymentDate = paymentDate
 	existing.PaymentMethod = paymentMethod
+	if financialAccountID > 0 {
+		existing.FinancialAccountID = financialAccountID
+	}
 	existing.UpdatedAt = time.Now()
 
 	if err := s.repo.Update(ctx, existing); err != nil {
 		return nil, err
 	}
+	if err := s.events.Publish(ctx, Event{
+		Type:         EventUpdated,
+		Contribution: *existing,
+		OccurredAt:   time.Now(),
+	}); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrNotPosted, err)
+	}
 	return existing, nil
 }
 
 func (s *Service) DeleteContribution(ctx context.Context, id int64) error {
-	return s.repo.Delete(ctx, id)
+	c, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := s.periods.EnsureOpen(ctx, c.PaymentDate); err != nil {
+		return err
+	}
+	if err := s.repo.Delete(ctx, id); err != nil {
+		return err
+	}
+	if err := s.events.Publish(ctx, Event{
+		Type:         EventDeleted,
+		Contribution: *c,
+		OccurredAt:   time.Now(),
+	}); err != nil {
+		return fmt.Errorf("%w: %w", ErrNotPosted, err)
+	}
+	return nil
 }
@@ -0,0 +1,219 @@
+package contribution_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ivsanmendez/ControlDeContabilidad/internal/domain/contribution"
+)
+
+// --- Fakes ---
+
+type fakeRepo struct {
+	data   map[int64]*contribution.Contribution
+	nextID int64
+}
+
+func newFakeRepo() *fakeRepo {
+	return &fakeRepo{data: make(map[int64]*contribution.Contribution), nextID: 1}
+}
+
+func (r *fakeRepo) Save(_ context.Context, c *contribution.Contribution) error {
+	c.ID = r.nextID
+	r.nextID++
+	cp := *c
+	r.data[c.ID] = &cp
+	return nil
+}
+
+func (r *fakeRepo) Update(_ context.Context, c *contribution.Contribution) error {
+	if _, ok := r.data[c.ID]; !ok {
+		return contribution.ErrNotFound
+	}
+	cp := *c
+	r.data[c.ID] = &cp
+	return nil
+}
+
+func (r *fakeRepo) FindByID(_ context.Context, id int64) (*contribution.Contribution, error) {
+	c, ok := r.data[id]
+	if !ok {
+		return nil, contribution.ErrNotFound
+	}
+	cp := *c
+	return &cp, nil
+}
+
+func (r *fakeRepo) FindAll(_ context.Context) ([]contribution.Contribution, error) {
+	result := make([]contribution.Contribution, 0, len(r.data))
+	for _, c := range r.data {
+		result = append(result, *c)
+	}
+	return result, nil
+}
+
+func (r *fakeRepo) FindByContributorAndYear(_ context.Context, contributorID int64, year int) ([]contribution.Contribution, error) {
+	var result []contribution.Contribution
+	for _, c := range r.data {
+		if c.ContributorID == contributorID && c.Year == year {
+			result = append(result, *c)
+		}
+	}
+	return result, nil
+}
+
+func (r *fakeRepo) Delete(_ context.Context, id int64) error {
+	if _, ok := r.data[id]; !ok {
+		return contribution.ErrNotFound
+	}
+	delete(r.data, id)
+	return nil
+}
+
+func (r *fakeRepo) FindDetailedByID(_ context.Context, _ int64) (*contribution.ContributionDetail, error) {
+	return nil, nil
+}
+
+func (r *fakeRepo) FindAllDetailed(_ context.Context) ([]contribution.ContributionDetail, error) {
+	return nil, nil
+}
+
+func (r *fakeRepo) FindDetailedByContributorAndYear(_ context.Context, _ int64, _ int) ([]contribution.ContributionDetail, error) {
+	return nil, nil
+}
+
+// fakePublisher records published events.
+type fakePublisher struct {
+	events []contribution.Event
+}
+
+func (p *fakePublisher) Publish(_ context.Context, e contribution.Event) error {
+	p.events = append(p.events, e)
+	return nil
+}
+
+// fakePeriods locks the months listed in closed.
+type fakePeriods struct {
+	closed map[time.Month]bool
+}
+
+func (p *fakePeriods) EnsureOpen(_ context.Context, date time.Time) error {
+	if p.closed[date.Month()] {
+		return errPeriodClosed
+	}
+	return nil
+}
+
+var errPeriodClosed = errors.New("period closed")
+
+func newService() (*contribution.Service, *fakeRepo, *fakePublisher, *fakePeriods) {
+	repo := newFakeRepo()
+	pub := &fakePublisher{}
+	periods := &fakePeriods{closed: map[time.Month]bool{}}
+	return contribution.NewService(repo, pub, periods), repo, pub, periods
+}
+
+const (
+	userID        int64 = 1
+	contributorID int64 = 10
+	categoryID    int64 = 20
+)
+
+var (
+	ctx      = context.Background()
+	february = time.Date(2026, 2, 5, 0, 0, 0, 0, time.UTC)
+	march    = time.Date(2026, 3, 5, 0, 0, 0, 0, time.UTC)
+)
+
+// --- Tests ---
+
+func TestClosedPeriod_BlocksCreate(t *testing.T) {
+	svc, repo, pub, periods := newService()
+	periods.closed[time.February] = true
+
+	_, err := svc.CreateContribution(ctx, userID, contributorID, categoryID, 500, 2, 2026, february, contribution.PaymentCash, 0)
+	if !errors.Is(err, errPeriodClosed) {
+		t.Fatalf("expected period closed, got %v", err)
+	}
+	if len(repo.data) != 0 || len(pub.events) != 0 {
+		t.Errorf("closed period must be left untouched: %d contributions, %d events", len(repo.data), len(pub.events))
+	}
+}
+
+func TestClosedPeriod_BlocksUpdate(t *testing.T) {
+	tests := []struct {
+		name    string
+		closed  time.Month
+		paidOn  time.Time
+		movesTo time.Time
+	}{
+		{"within the closed month", time.February, february, february.AddDate(0, 0, 3)},
+		{"out of the closed month", time.February, february, march},
+		{"into the closed month", time.February, march, february},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, repo, pub, periods := newService()
+			created, err := svc.CreateContribution(ctx, userID, contributorID, categoryID, 500, 2, 2026, tt.paidOn, contribution.PaymentCash, 0)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			periods.closed[tt.closed] = true
+
+			_, err = svc.UpdateContribution(ctx, created.ID, contributorID, categoryID, 650, 2, 2026, tt.movesTo, contribution.PaymentCash, 0)
+			if !errors.Is(err, errPeriodClosed) {
+				t.Fatalf("expected period closed, got %v", err)
+			}
+			if got := repo.data[created.ID]; got.Amount != 500 || !got.PaymentDate.Equal(tt.paidOn) {
+				t.Errorf("contribution must be left untouched, got %+v", got)
+			}
+			if len(pub.events) != 1 {
+				t.Errorf("expected no update event, got %d events", len(pub.events))
+			}
+		})
+	}
+}
+
+func TestClosedPeriod_BlocksDelete(t *testing.T) {
+	svc, repo, pub, periods := newService()
+	created, _ := svc.CreateContribution(ctx, userID, contributorID, categoryID, 500, 2, 2026, february, contribution.PaymentCash, 0)
+	periods.closed[time.February] = true
+
+	if err := svc.DeleteContribution(ctx, created.ID); !errors.Is(err, errPeriodClosed) {
+		t.Fatalf("expected period closed, got %v", err)
+	}
+	if _, ok := repo.data[created.ID]; !ok || len(pub.events) != 1 {
+		t.Errorf("closed period must be left untouched: %d events", len(pub.events))
+	}
+}
+
+func TestOpenPeriod_AllowsUpdateAndDelete(t *testing.T) {
+	svc, repo, pub, periods := newService()
+	created, _ := svc.CreateContribution(ctx, userID, contributorID, categoryID, 500, 2, 2026, february, contribution.PaymentCash, 0)
+	periods.closed[time.January] = true
+
+	updated, err := svc.UpdateContribution(ctx, created.ID, contributorID, categoryID, 650, 2, 2026, march, contribution.PaymentTransfer, 0)
+	if err != nil {
+		t.Fatalf("update: unexpected error: %v", err)
+	}
+	if updated.Amount != 650 || !updated.PaymentDate.Equal(march) {
+		t.Errorf("unexpected update result: %+v", updated)
+	}
+	if err := svc.DeleteContribution(ctx, created.ID); err != nil {
+		t.Fatalf("delete: unexpected error: %v", err)
+	}
+	if len(repo.data) != 0 {
+		t.Errorf("expected the contribution deleted, got %d", len(repo.data))
+	}
+	want := []contribution.EventType{contribution.EventCreated, contribution.EventUpdated, contribution.EventDeleted}
+	if len(pub.events) != len(want) {
+		t.Fatalf("expected %d events, got %d", len(want), len(pub.events))
+	}
+	for i, e := range pub.events {
+		if e.Type != want[i] {
+			t.Errorf("event %d: expected %s, got %s", i, want[i], e.Type)
+		}
+	}
+}
@@ -1,6 +1,9 @@
 package expense
 
-import "time"
+import (
+	"errors"
+	"time"
+)
 
 type EventType string
 
@@ -14,4 +17,9 @@ type Event struct {
 	Type       EventType
 	Expense    Expense
 	OccurredAt time.Time
-}
\ No newline at end of file
+}
+
+// ErrNotPosted reports that the expense was saved but a subscriber, such as
+// the ledger, failed to process its event. Rebuilding the ledger from its
+// source documents brings it back in line.
+var ErrNotPosted = errors.New("expense saved but its ledger entry could not be updated")
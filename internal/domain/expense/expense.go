@@ -2,6 +2,7 @@ package expense
 
 import (
 	"errors"
+	"math"
 	"time"
 )
 
@@ -11,7 +12,14 @@ var (
 	ErrEmptyDescription  = errors.New("description cannot be empty")
 	ErrInvalidUserID     = errors.New("user ID must be positive")
 	ErrInvalidCategoryID = errors.New("category ID must be positive")
+	ErrInvalidSupplierID = errors.New("supplier ID must be positive")
 	ErrForbidden         = errors.New("access denied")
+	ErrPettyCashCharge   = errors.New("expense was paid from petty cash and cannot be changed or deleted")
+
+	ErrInvalidLineAmount     = errors.New("expense line amount must be positive")
+	ErrInvalidLineCategoryID = errors.New("expense line category ID must be positive")
+	ErrLinesTotalMismatch    = errors.New("expense lines must sum to the expense amount")
+	ErrLinesRequired         = errors.New("expense lines are required to change the amount of a split expense")
 )
 
 type Expense struct {
@@ -20,12 +28,36 @@ type Expense struct {
 	Description string
 	Amount      float64
 	CategoryID  int64
+	SupplierID  *int64
 	Date        time.Time
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
+	Lines       []Line
+	// FinancialAccountID is the cash box or bank account the money left.
+	// Zero on create means the default account.
+	FinancialAccountID int64
+	// PettyCashCharge marks an expense paid from a petty cash fund. The
+	// fund's balance and replenishments read its amount, so it is frozen.
+	PettyCashCharge bool
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
 }
 
-// ExpenseDetail includes denormalized category name for list views.
+// Line is one category bucket of an expense, e.g. the paint and the plumbing
+// items of a single hardware-store ticket. Every expense has at least one line;
+// the lines always sum to the expense Amount.
+type Line struct {
+	ID          int64
+	CategoryID  int64
+	Description string
+	Amount      float64
+}
+
+// LineDetail includes the denormalized category name of a line.
+type LineDetail struct {
+	Line
+	CategoryName string
+}
+
+// ExpenseDetail includes denormalized category and supplier names for list views.
 type ExpenseDetail struct {
 	ID           int64
 	UserID       int64
@@ -33,13 +65,23 @@ type ExpenseDetail struct {
 	Amount       float64
 	CategoryID   int64
 	CategoryName string
+	SupplierID   *int64
+	SupplierName string
 	Date         time.Time
-	CreatedAt    time.Time
-	UpdatedAt    time.Time
+	Lines        []LineDetail
+	// FinancialAccountID and FinancialAccountName identify the cash box or
+	// bank account the money left.
+	FinancialAccountID   int64
+	FinancialAccountName string
+	CreatedAt            time.Time
+	UpdatedAt            time.Time
 }
 
 // New creates an Expense enforcing domain invariants.
-func New(userID int64, description string, amount float64, categoryID int64, date time.Time) (*Expense, error) {
+// supplierID is optional; nil means the payee is not in the supplier catalog.
+// When lines is empty the expense gets a single line mirroring the header;
+// otherwise categoryID may be zero and defaults to the largest line's category.
+func New(userID int64, description string, amount float64, categoryID int64, supplierID *int64, lines []Line, date time.Time) (*Expense, error) {
 	if userID <= 0 {
 		return nil, ErrInvalidUserID
 	}
@@ -49,8 +91,12 @@ func New(userID int64, description string, amount float64, categoryID int64, dat
 	if amount <= 0 {
 		return nil, ErrInvalidAmount
 	}
-	if categoryID <= 0 {
-		return nil, ErrInvalidCategoryID
+	lines, categoryID, err := NormalizeLines(description, amount, categoryID, lines)
+	if err != nil {
+		return nil, err
+	}
+	if supplierID != nil && *supplierID <= 0 {
+		return nil, ErrInvalidSupplierID
 	}
 	now := time.Now()
 	return &Expense{
@@ -58,8 +104,52 @@ func New(userID int64, description string, amount float64, categoryID int64, dat
 		Description: description,
 		Amount:      amount,
 		CategoryID:  categoryID,
+		SupplierID:  supplierID,
 		Date:        date,
+		Lines:       lines,
 		CreatedAt:   now,
 		UpdatedAt:   now,
 	}, nil
-}
\ No newline at end of file
+}
+
+// NormalizeLines validates the expense lines against the header amount and
+// returns the lines to persist together with the effective header category.
+func NormalizeLines(description string, amount float64, categoryID int64, lines []Line) ([]Line, int64, error) {
+	if len(lines) == 0 {
+		if categoryID <= 0 {
+			return nil, 0, ErrInvalidCategoryID
+		}
+		return []Line{{CategoryID: categoryID, Description: description, Amount: amount}}, categoryID, nil
+	}
+	if categoryID < 0 {
+		return nil, 0, ErrInvalidCategoryID
+	}
+
+	out := make([]Line, len(lines))
+	var total, largest float64
+	for i, l := range lines {
+		if l.Amount <= 0 {
+			return nil, 0, ErrInvalidLineAmount
+		}
+		if l.CategoryID <= 0 {
+			return nil, 0, ErrInvalidLineCategoryID
+		}
+		if l.Description == "" {
+			l.Description = description
+		}
+		total += l.Amount
+		if categoryID == 0 && l.Amount > largest {
+			largest = l.Amount
+			categoryID = l.CategoryID
+		}
+		out[i] = l
+	}
+	if toCents(total) != toCents(amount) {
+		return nil, 0, ErrLinesTotalMismatch
+	}
+	return out, categoryID, nil
+}
+
+func toCents(v float64) int64 {
+	return int64(math.Round(v * 100))
+}
@@ -9,7 +9,7 @@ import (
 
 func TestNew_Valid(t *testing.T) {
 	date := time.Date(2026, 2, 17, 0, 0, 0, 0, time.UTC)
-	e, err := expense.New(1, "Groceries", 50.00, 1, date)
+	e, err := expense.New(1, "Groceries", 50.00, 1, nil, nil, date)
 	if err != nil {
 		t.Fatalf("expected no error, got %v", err)
 	}
@@ -37,36 +37,108 @@ func TestNew_Valid(t *testing.T) {
 }
 
 func TestNew_InvalidUserID(t *testing.T) {
-	_, err := expense.New(0, "Coffee", 5.00, 1, time.Now())
+	_, err := expense.New(0, "Coffee", 5.00, 1, nil, nil, time.Now())
 	if err != expense.ErrInvalidUserID {
 		t.Errorf("expected ErrInvalidUserID, got %v", err)
 	}
 }
 
 func TestNew_EmptyDescription(t *testing.T) {
-	_, err := expense.New(1, "", 50.00, 1, time.Now())
+	_, err := expense.New(1, "", 50.00, 1, nil, nil, time.Now())
 	if err != expense.ErrEmptyDescription {
 		t.Errorf("expected ErrEmptyDescription, got %v", err)
 	}
 }
 
 func TestNew_ZeroAmount(t *testing.T) {
-	_, err := expense.New(1, "Coffee", 0, 1, time.Now())
+	_, err := expense.New(1, "Coffee", 0, 1, nil, nil, time.Now())
 	if err != expense.ErrInvalidAmount {
 		t.Errorf("expected ErrInvalidAmount, got %v", err)
 	}
 }
 
 func TestNew_NegativeAmount(t *testing.T) {
-	_, err := expense.New(1, "Coffee", -10.00, 1, time.Now())
+	_, err := expense.New(1, "Coffee", -10.00, 1, nil, nil, time.Now())
 	if err != expense.ErrInvalidAmount {
 		t.Errorf("expected ErrInvalidAmount, got %v", err)
 	}
 }
 
 func TestNew_InvalidCategoryID(t *testing.T) {
-	_, err := expense.New(1, "Coffee", 5.00, 0, time.Now())
+	_, err := expense.New(1, "Coffee", 5.00, 0, nil, nil, time.Now())
 	if err != expense.ErrInvalidCategoryID {
 		t.Errorf("expected ErrInvalidCategoryID, got %v", err)
 	}
 }
+
+func TestNew_WithSupplier(t *testing.T) {
+	supplierID := int64(7)
+	e, err := expense.New(1, "Jardinería", 800.00, 1, &supplierID, nil, time.Now())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if e.SupplierID == nil || *e.SupplierID != supplierID {
+		t.Errorf("supplierID = %v, want %d", e.SupplierID, supplierID)
+	}
+}
+
+func TestNew_InvalidSupplierID(t *testing.T) {
+	supplierID := int64(0)
+	_, err := expense.New(1, "Coffee", 5.00, 1, &supplierID, nil, time.Now())
+	if err != expense.ErrInvalidSupplierID {
+		t.Errorf("expected ErrInvalidSupplierID, got %v", err)
+	}
+}
+
+func TestNew_DefaultSingleLine(t *testing.T) {
+	e, err := expense.New(1, "Groceries", 50.00, 4, nil, nil, time.Now())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(e.Lines) != 1 {
+		t.Fatalf("expected 1 line, got %d", len(e.Lines))
+	}
+	l := e.Lines[0]
+	if l.CategoryID != 4 || l.Amount != 50.00 || l.Description != "Groceries" {
+		t.Errorf("line = %+v, want header mirror", l)
+	}
+}
+
+func TestNew_MultiLine(t *testing.T) {
+	lines := []expense.Line{
+		{CategoryID: 2, Description: "Pintura", Amount: 450.10},
+		{CategoryID: 3, Amount: 149.90},
+	}
+	e, err := expense.New(1, "Ferretería", 600.00, 0, nil, lines, time.Now())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if e.CategoryID != 2 {
+		t.Errorf("categoryID = %d, want category of largest line (2)", e.CategoryID)
+	}
+	if e.Lines[1].Description != "Ferretería" {
+		t.Errorf("line description = %q, want header description", e.Lines[1].Description)
+	}
+}
+
+func TestNew_LinesTotalMismatch(t *testing.T) {
+	lines := []expense.Line{
+		{CategoryID: 2, Amount: 450},
+		{CategoryID: 3, Amount: 100},
+	}
+	_, err := expense.New(1, "Ferretería", 600.00, 0, nil, lines, time.Now())
+	if err != expense.ErrLinesTotalMismatch {
+		t.Errorf("expected ErrLinesTotalMismatch, got %v", err)
+	}
+}
+
+func TestNew_InvalidLine(t *testing.T) {
+	_, err := expense.New(1, "Ferretería", 10, 0, nil, []expense.Line{{CategoryID: 0, Amount: 10}}, time.Now())
+	if err != expense.ErrInvalidLineCategoryID {
+		t.Errorf("expected ErrInvalidLineCategoryID, got %v", err)
+	}
+	_, err = expense.New(1, "Ferretería", 10, 0, nil, []expense.Line{{CategoryID: 1, Amount: 0}}, time.Now())
+	if err != expense.ErrInvalidLineAmount {
+		t.Errorf("expected ErrInvalidLineAmount, got %v", err)
+	}
+}
@@ -2,6 +2,7 @@ package expense
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/ivsanmendez/ControlDeContabilidad/internal/domain/user"
@@ -24,29 +25,49 @@ type EventPublisher interface {
 	Publish(ctx context.Context, event Event) error
 }
 
+// PeriodGuard is the outbound port that rejects changes dated in a closed
+// accounting period.
+type PeriodGuard interface {
+	EnsureOpen(ctx context.Context, date time.Time) error
+}
+
 // Service orchestrates expense use cases.
 type Service struct {
-	repo   Repository
-	events EventPublisher
+	repo    Repository
+	events  EventPublisher
+	periods PeriodGuard
 }
 
-func NewService(repo Repository, events EventPublisher) *Service {
-	return &Service{repo: repo, events: events}
+func NewService(repo Repository, events EventPublisher, periods PeriodGuard) *Service {
+	return &Service{repo: repo, events: events, periods: periods}
 }
 
-func (s *Service) CreateExpense(ctx context.Context, callerID int64, description string, amount float64, categoryID int64, date time.Time) (*Expense, error) {
-	e, err := New(callerID, description, amount, categoryID, date)
+func (s *Service) CreateExpense(ctx context.Context, callerID int64, description string, amount float64, categoryID int64, supplierID *int64, financialAccountID int64, lines []Line, date time.Time) (*Expense, error) {
+	return s.CreateExpenseWith(ctx, callerID, description, amount, categoryID, supplierID, financialAccountID, lines, date, s.repo.Save)
+}
+
+// CreateExpenseWith is CreateExpense with persistence delegated to save, so a
+// caller can store the expense in the same transaction as records of its own.
+// The created event is published only once save succeeds.
+func (s *Service) CreateExpenseWith(ctx context.Context, callerID int64, description string, amount float64, categoryID int64, supplierID *int64, financialAccountID int64, lines []Line, date time.Time, save func(context.Context, *Expense) error) (*Expense, error) {
+	e, err := New(callerID, description, amount, categoryID, supplierID, lines, date)
 	if err != nil {
 		return nil, err
 	}
-	if err := s.repo.Save(ctx, e); err != nil {
+	e.FinancialAccountID = financialAccountID
+	if err := s.periods.EnsureOpen(ctx, e.Date); err != nil {
 		return nil, err
 	}
-	_ = s.events.Publish(ctx, Event{
+	if err := save(ctx, e); err != nil {
+		return nil, err
+	}
+	if err := s.events.Publish(ctx, Event{
 		Type:       EventCreated,
 		Expense:    *e,
 		OccurredAt: time.Now(),
-	})
+	}); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrNotPosted, err)
+	}
 	return e, nil
 }
 
@@ -68,7 +89,7 @@ func (s *Service) ListExpenses(ctx context.Context, callerID int64, callerRole u
 	return s.repo.FindAllDetailedByUser(ctx, callerID)
 }
 
-func (s *Service) UpdateExpense(ctx context.Context, callerID int64, callerRole user.Role, id int64, description string, amount float64, categoryID int64, date time.Time) (*Expense, error) {
+func (s *Service) UpdateExpense(ctx context.Context, callerID int64, callerRole user.Role, id int64, description string, amount float64, categoryID int64, supplierID *int64, financialAccountID int64, lines []Line, date time.Time) (*Expense, error) {
 	existing, err := s.repo.FindByID(ctx, id)
 	if err != nil {
 		return nil, err
@@ -76,6 +97,9 @@ func (s *Service) UpdateExpense(ctx context.Context, callerID int64, callerRole
 	if callerRole != user.RoleAdmin && existing.UserID != callerID {
 		return nil, ErrForbidden
 	}
+	if existing.PettyCashCharge {
+		return nil, ErrPettyCashCharge
+	}
 
 	if description == "" {
 		return nil, ErrEmptyDescription
@@ -83,24 +107,49 @@ func (s *Service) UpdateExpense(ctx context.Context, callerID int64, callerRole
 	if amount <= 0 {
 		return nil, ErrInvalidAmount
 	}
-	if categoryID <= 0 {
-		return nil, ErrInvalidCategoryID
+	// Nil lines means the client left them out, as the web form does: a split
+	// expense keeps its lines unless the new amount no longer matches them.
+	if lines == nil && len(existing.Lines) > 1 {
+		if toCents(amount) != toCents(existing.Amount) {
+			return nil, ErrLinesRequired
+		}
+		lines = existing.Lines
+	}
+	lines, categoryID, err = NormalizeLines(description, amount, categoryID, lines)
+	if err != nil {
+		return nil, err
+	}
+	if supplierID != nil && *supplierID <= 0 {
+		return nil, ErrInvalidSupplierID
+	}
+	// Both the period the expense leaves and the one it moves to must be open.
+	for _, d := range []time.Time{existing.Date, date} {
+		if err := s.periods.EnsureOpen(ctx, d); err != nil {
+			return nil, err
+		}
 	}
 
 	existing.Description = description
 	existing.Amount = amount
 	existing.CategoryID = categoryID
+	existing.SupplierID = supplierID
+	if financialAccountID > 0 {
+		existing.FinancialAccountID = financialAccountID
+	}
+	existing.Lines = lines
 	existing.Date = date
 	existing.UpdatedAt = time.Now()
 
 	if err := s.repo.Update(ctx, existing); err != nil {
 		return nil, err
 	}
-	_ = s.events.Publish(ctx, Event{
+	if err := s.events.Publish(ctx, Event{
 		Type:       EventUpdated,
 		Expense:    *existing,
 		OccurredAt: time.Now(),
-	})
+	}); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrNotPosted, err)
+	}
 	return existing, nil
 }
 
@@ -112,13 +161,18 @@ func (s *Service) DeleteExpense(ctx context.Context, callerID int64, callerRole
 	if callerRole != user.RoleAdmin && e.UserID != callerID {
 		return ErrForbidden
 	}
+	if err := s.periods.EnsureOpen(ctx, e.Date); err != nil {
+		return err
+	}
 	if err := s.repo.Delete(ctx, id); err != nil {
 		return err
 	}
-	_ = s.events.Publish(ctx, Event{
+	if err := s.events.Publish(ctx, Event{
 		Type:       EventDeleted,
 		Expense:    *e,
 		OccurredAt: time.Now(),
-	})
+	}); err != nil {
+		return fmt.Errorf("%w: %w", ErrNotPosted, err)
+	}
 	return nil
 }
@@ -102,21 +102,42 @@ func (r *fakeRepo) Delete(_ context.Context, id int64) error {
 	return nil
 }
 
-// fakePublisher records published events.
+// fakePublisher records published events and fails with err when set.
 type fakePublisher struct {
 	events []expense.Event
+	err    error
 }
 
 func (p *fakePublisher) Publish(_ context.Context, e expense.Event) error {
 	p.events = append(p.events, e)
+	return p.err
+}
+
+// fakePeriods locks the months listed in closed.
+type fakePeriods struct {
+	closed map[time.Month]bool
+}
+
+func (p *fakePeriods) EnsureOpen(_ context.Context, date time.Time) error {
+	if p.closed[date.Month()] {
+		return errPeriodClosed
+	}
 	return nil
 }
 
+var errPeriodClosed = errors.New("period closed")
+
 func newService() (*expense.Service, *fakeRepo, *fakePublisher) {
+	svc, repo, pub, _ := newServiceWithPeriods()
+	return svc, repo, pub
+}
+
+func newServiceWithPeriods() (*expense.Service, *fakeRepo, *fakePublisher, *fakePeriods) {
 	repo := newFakeRepo()
 	pub := &fakePublisher{}
-	svc := expense.NewService(repo, pub)
-	return svc, repo, pub
+	periods := &fakePeriods{closed: map[time.Month]bool{}}
+	svc := expense.NewService(repo, pub, periods)
+	return svc, repo, pub, periods
 }
 
 var (
@@ -133,7 +154,7 @@ const (
 func TestCreateExpense_HappyPath(t *testing.T) {
 	svc, repo, pub := newService()
 
-	e, err := svc.CreateExpense(ctx, userID1, "Lunch", 12.50, categoryID, testDate)
+	e, err := svc.CreateExpense(ctx, userID1, "Lunch", 12.50, categoryID, nil, 0, nil, testDate)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -154,7 +175,7 @@ func TestCreateExpense_HappyPath(t *testing.T) {
 func TestCreateExpense_InvalidInput(t *testing.T) {
 	svc, _, pub := newService()
 
-	_, err := svc.CreateExpense(ctx, userID1, "", 12.50, categoryID, testDate)
+	_, err := svc.CreateExpense(ctx, userID1, "", 12.50, categoryID, nil, 0, nil, testDate)
 	if !errors.Is(err, expense.ErrEmptyDescription) {
 		t.Errorf("expected ErrEmptyDescription, got %v", err)
 	}
@@ -166,7 +187,7 @@ func TestCreateExpense_InvalidInput(t *testing.T) {
 func TestCreateExpense_InvalidCategoryID(t *testing.T) {
 	svc, _, pub := newService()
 
-	_, err := svc.CreateExpense(ctx, userID1, "Lunch", 12.50, 0, testDate)
+	_, err := svc.CreateExpense(ctx, userID1, "Lunch", 12.50, 0, nil, 0, nil, testDate)
 	if !errors.Is(err, expense.ErrInvalidCategoryID) {
 		t.Errorf("expected ErrInvalidCategoryID, got %v", err)
 	}
@@ -179,15 +200,28 @@ func TestCreateExpense_RepoError(t *testing.T) {
 	svc, repo, _ := newService()
 	repo.saveErr = errors.New("db unavailable")
 
-	_, err := svc.CreateExpense(ctx, userID1, "Taxi", 8.00, categoryID, testDate)
+	_, err := svc.CreateExpense(ctx, userID1, "Taxi", 8.00, categoryID, nil, 0, nil, testDate)
 	if err == nil {
 		t.Fatal("expected error from repo, got nil")
 	}
 }
 
+func TestCreateExpense_SubscriberErrorReachesCaller(t *testing.T) {
+	svc, repo, pub := newService()
+	pub.err = errors.New("ledger unavailable")
+
+	_, err := svc.CreateExpense(ctx, userID1, "Lunch", 12.50, categoryID, nil, 0, nil, testDate)
+	if !errors.Is(err, expense.ErrNotPosted) || !errors.Is(err, pub.err) {
+		t.Fatalf("expected ErrNotPosted wrapping the subscriber error, got %v", err)
+	}
+	if len(repo.data) != 1 {
+		t.Errorf("expected the expense to stay saved, got %d", len(repo.data))
+	}
+}
+
 func TestGetExpense_OwnerCanAccess(t *testing.T) {
 	svc, _, _ := newService()
-	created, _ := svc.CreateExpense(ctx, userID1, "Bus", 2.50, categoryID, testDate)
+	created, _ := svc.CreateExpense(ctx, userID1, "Bus", 2.50, categoryID, nil, 0, nil, testDate)
 
 	got, err := svc.GetExpense(ctx, userID1, user.RoleUser, created.ID)
 	if err != nil {
@@ -200,7 +234,7 @@ func TestGetExpense_OwnerCanAccess(t *testing.T) {
 
 func TestGetExpense_AdminCanAccessAny(t *testing.T) {
 	svc, _, _ := newService()
-	created, _ := svc.CreateExpense(ctx, userID1, "Bus", 2.50, categoryID, testDate)
+	created, _ := svc.CreateExpense(ctx, userID1, "Bus", 2.50, categoryID, nil, 0, nil, testDate)
 
 	got, err := svc.GetExpense(ctx, userID2, user.RoleAdmin, created.ID)
 	if err != nil {
@@ -213,7 +247,7 @@ func TestGetExpense_AdminCanAccessAny(t *testing.T) {
 
 func TestGetExpense_NonOwnerForbidden(t *testing.T) {
 	svc, _, _ := newService()
-	created, _ := svc.CreateExpense(ctx, userID1, "Bus", 2.50, categoryID, testDate)
+	created, _ := svc.CreateExpense(ctx, userID1, "Bus", 2.50, categoryID, nil, 0, nil, testDate)
 
 	_, err := svc.GetExpense(ctx, userID2, user.RoleUser, created.ID)
 	if !errors.Is(err, expense.ErrForbidden) {
@@ -232,8 +266,8 @@ func TestGetExpense_NotFound(t *testing.T) {
 
 func TestListExpenses_UserSeesOnlyOwn(t *testing.T) {
 	svc, _, _ := newService()
-	svc.CreateExpense(ctx, userID1, "Coffee", 3.00, categoryID, testDate)
-	svc.CreateExpense(ctx, userID2, "Metro", 1.50, categoryID, testDate)
+	svc.CreateExpense(ctx, userID1, "Coffee", 3.00, categoryID, nil, 0, nil, testDate)
+	svc.CreateExpense(ctx, userID2, "Metro", 1.50, categoryID, nil, 0, nil, testDate)
 
 	list, err := svc.ListExpenses(ctx, userID1, user.RoleUser)
 	if err != nil {
@@ -246,8 +280,8 @@ func TestListExpenses_UserSeesOnlyOwn(t *testing.T) {
 
 func TestListExpenses_AdminSeesAll(t *testing.T) {
 	svc, _, _ := newService()
-	svc.CreateExpense(ctx, userID1, "Coffee", 3.00, categoryID, testDate)
-	svc.CreateExpense(ctx, userID2, "Metro", 1.50, categoryID, testDate)
+	svc.CreateExpense(ctx, userID1, "Coffee", 3.00, categoryID, nil, 0, nil, testDate)
+	svc.CreateExpense(ctx, userID2, "Metro", 1.50, categoryID, nil, 0, nil, testDate)
 
 	list, err := svc.ListExpenses(ctx, userID1, user.RoleAdmin)
 	if err != nil {
@@ -260,7 +294,7 @@ func TestListExpenses_AdminSeesAll(t *testing.T) {
 
 func TestDeleteExpense_OwnerCanDelete(t *testing.T) {
 	svc, repo, pub := newService()
-	created, _ := svc.CreateExpense(ctx, userID1, "Dinner", 30.00, categoryID, testDate)
+	created, _ := svc.CreateExpense(ctx, userID1, "Dinner", 30.00, categoryID, nil, 0, nil, testDate)
 	pub.events = nil
 
 	err := svc.DeleteExpense(ctx, userID1, user.RoleUser, created.ID)
@@ -277,7 +311,7 @@ func TestDeleteExpense_OwnerCanDelete(t *testing.T) {
 
 func TestDeleteExpense_NonOwnerForbidden(t *testing.T) {
 	svc, _, _ := newService()
-	created, _ := svc.CreateExpense(ctx, userID1, "Dinner", 30.00, categoryID, testDate)
+	created, _ := svc.CreateExpense(ctx, userID1, "Dinner", 30.00, categoryID, nil, 0, nil, testDate)
 
 	err := svc.DeleteExpense(ctx, userID2, user.RoleUser, created.ID)
 	if !errors.Is(err, expense.ErrForbidden) {
@@ -287,7 +321,7 @@ func TestDeleteExpense_NonOwnerForbidden(t *testing.T) {
 
 func TestDeleteExpense_AdminCanDeleteAny(t *testing.T) {
 	svc, repo, _ := newService()
-	created, _ := svc.CreateExpense(ctx, userID1, "Dinner", 30.00, categoryID, testDate)
+	created, _ := svc.CreateExpense(ctx, userID1, "Dinner", 30.00, categoryID, nil, 0, nil, testDate)
 
 	err := svc.DeleteExpense(ctx, userID2, user.RoleAdmin, created.ID)
 	if err != nil {
@@ -306,3 +340,78 @@ func TestDeleteExpense_NotFound(t *testing.T) {
 		t.Errorf("expected ErrNotFound, got %v", err)
 	}
 }
+
+func TestClosedPeriod_BlocksCreateUpdateDelete(t *testing.T) {
+	svc, repo, pub, periods := newServiceWithPeriods()
+	created, _ := svc.CreateExpense(ctx, userID1, "Dinner", 30.00, categoryID, nil, 0, nil, testDate)
+	periods.closed[time.February] = true
+
+	if _, err := svc.CreateExpense(ctx, userID1, "Lunch", 12.50, categoryID, nil, 0, nil, testDate); !errors.Is(err, errPeriodClosed) {
+		t.Errorf("create: expected period closed, got %v", err)
+	}
+	// Moving the expense out of the closed month is also a change to that month.
+	march := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	if _, err := svc.UpdateExpense(ctx, userID1, user.RoleAdmin, created.ID, "Dinner", 30.00, categoryID, nil, 0, nil, march); !errors.Is(err, errPeriodClosed) {
+		t.Errorf("update: expected period closed, got %v", err)
+	}
+	if err := svc.DeleteExpense(ctx, userID1, user.RoleAdmin, created.ID); !errors.Is(err, errPeriodClosed) {
+		t.Errorf("delete: expected period closed, got %v", err)
+	}
+	if len(repo.data) != 1 || len(pub.events) != 1 {
+		t.Errorf("closed period must be left untouched: %d expenses, %d events", len(repo.data), len(pub.events))
+	}
+}
+
+func TestUpdateExpense_PettyCashChargeIsFrozen(t *testing.T) {
+	svc, repo, pub := newService()
+	created, _ := svc.CreateExpense(ctx, userID1, "Garrafones", 90.00, categoryID, nil, 2, nil, testDate)
+	repo.data[created.ID].PettyCashCharge = true
+
+	if _, err := svc.UpdateExpense(ctx, userID1, user.RoleAdmin, created.ID, "Garrafones", 120.00, categoryID, nil, 0, nil, testDate); !errors.Is(err, expense.ErrPettyCashCharge) {
+		t.Fatalf("expected ErrPettyCashCharge, got %v", err)
+	}
+	if got := repo.data[created.ID]; got.Amount != 90.00 || got.FinancialAccountID != 2 {
+		t.Errorf("petty cash expense must be left untouched, got %+v", got)
+	}
+	if len(pub.events) != 1 {
+		t.Errorf("expected no update event, got %d events", len(pub.events))
+	}
+}
+
+func TestUpdateExpense_OmittedLinesKeepSplit(t *testing.T) {
+	svc, repo, _ := newService()
+	split := []expense.Line{
+		{CategoryID: 3, Description: "Cloro", Amount: 70},
+		{CategoryID: 4, Description: "Bomba", Amount: 30},
+	}
+	created, err := svc.CreateExpense(ctx, userID1, "Alberca", 100, 0, nil, 0, split, testDate)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Same amount without lines: the split survives the edit.
+	updated, err := svc.UpdateExpense(ctx, userID1, user.RoleAdmin, created.ID, "Mantenimiento alberca", 100, created.CategoryID, nil, 0, nil, testDate)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(updated.Lines) != 2 || updated.Lines[0].CategoryID != 3 || updated.Lines[1].CategoryID != 4 {
+		t.Errorf("expected the split lines kept, got %+v", updated.Lines)
+	}
+
+	// A new amount without lines cannot be spread over the old split.
+	if _, err := svc.UpdateExpense(ctx, userID1, user.RoleAdmin, created.ID, "Mantenimiento alberca", 120, created.CategoryID, nil, 0, nil, testDate); !errors.Is(err, expense.ErrLinesRequired) {
+		t.Fatalf("expected ErrLinesRequired, got %v", err)
+	}
+	if got := repo.data[created.ID]; got.Amount != 100 || len(got.Lines) != 2 {
+		t.Errorf("expected the expense left untouched, got %+v", got)
+	}
+
+	// An explicit empty list collapses the split into a single line.
+	updated, err = svc.UpdateExpense(ctx, userID1, user.RoleAdmin, created.ID, "Mantenimiento alberca", 120, 3, nil, 0, []expense.Line{}, testDate)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(updated.Lines) != 1 || updated.Lines[0].Amount != 120 {
+		t.Errorf("expected a single line for the new amount, got %+v", updated.Lines)
+	}
+}
@@ -0,0 +1,140 @@
+package ledger
+
+import (
+	"sort"
+	"time"
+)
+
+// natureBalance is debit minus credit for debit-natured accounts and credit
+// minus debit otherwise, rounded to cents.
+func natureBalance(n Nature, debit, credit float64) float64 {
+	if n == NatureDebit {
+		return roundCents(debit - credit)
+	}
+	return roundCents(credit - debit)
+}
+
+// sectionBalance is an account's balance as it counts towards its type's
+// section total: contra accounts, carried on the opposite side of their type
+// (accumulated depreciation under assets), reduce it.
+func sectionBalance(b AccountBalance) float64 {
+	if b.Nature != b.AccountType.DefaultNature() {
+		return -b.Balance
+	}
+	return b.Balance
+}
+
+// buildChartBalance rolls per-account balances up the account tree and groups
+// them by the nearest SAT code found on the account or its ancestors.
+func buildChartBalance(asOf time.Time, accounts []Account, balances []AccountBalance) *ChartBalance {
+	byID := make(map[int64]Account, len(accounts))
+	for _, a := range accounts {
+		byID[a.ID] = a
+	}
+	children := make(map[int64][]Account)
+	var roots []Account
+	for _, a := range accounts {
+		if a.ParentID != nil {
+			if _, ok := byID[*a.ParentID]; ok {
+				children[*a.ParentID] = append(children[*a.ParentID], a)
+				continue
+			}
+		}
+		roots = append(roots, a)
+	}
+	own := make(map[int64]AccountBalance, len(balances))
+	for _, b := range balances {
+		own[b.AccountID] = b
+	}
+
+	var build func(a Account, level int) AccountNode
+	build = func(a Account, level int) AccountNode {
+		n := AccountNode{
+			AccountBalance: AccountBalance{
+				AccountID:   a.ID,
+				AccountCode: a.Code,
+				AccountName: a.Name,
+				AccountType: a.Type,
+				Nature:      a.Nature,
+				SATCode:     a.SATCode,
+				Debit:       own[a.ID].Debit,
+				Credit:      own[a.ID].Credit,
+			},
+			Level:    level,
+			Children: []AccountNode{},
+		}
+		kids := children[a.ID]
+		sortByCode(kids)
+		for _, k := range kids {
+			c := build(k, level+1)
+			n.Debit += c.Debit
+			n.Credit += c.Credit
+			n.Children = append(n.Children, c)
+		}
+		n.Debit = roundCents(n.Debit)
+		n.Credit = roundCents(n.Credit)
+		n.Balance = natureBalance(a.Nature, n.Debit, n.Credit)
+		return n
+	}
+
+	cb := &ChartBalance{
+		AsOf:     asOf,
+		Accounts: make([]AccountNode, 0, len(roots)),
+		BySAT:    []SATBalance{},
+		Unmapped: []AccountBalance{},
+	}
+	sortByCode(roots)
+	for _, r := range roots {
+		cb.Accounts = append(cb.Accounts, build(r, 1))
+	}
+
+	groups := make(map[string]*SATBalance)
+	for _, b := range balances {
+		holder, ok := satHolder(byID, byID[b.AccountID])
+		if !ok {
+			cb.Unmapped = append(cb.Unmapped, b)
+			continue
+		}
+		g, ok := groups[holder.SATCode]
+		if !ok {
+			g = &SATBalance{SATCode: holder.SATCode, Nature: holder.Nature}
+			groups[holder.SATCode] = g
+		}
+		g.Debit += b.Debit
+		g.Credit += b.Credit
+	}
+	for _, g := range groups {
+		g.Debit = roundCents(g.Debit)
+		g.Credit = roundCents(g.Credit)
+		g.Balance = natureBalance(g.Nature, g.Debit, g.Credit)
+		cb.BySAT = append(cb.BySAT, *g)
+	}
+	sort.Slice(cb.BySAT, func(i, j int) bool {
+		return cb.BySAT[i].SATCode < cb.BySAT[j].SATCode
+	})
+	return cb
+}
+
+// satHolder returns the closest account, starting at a, that carries a SAT code.
+func satHolder(byID map[int64]Account, a Account) (Account, bool) {
+	for depth := 0; depth <= len(byID); depth++ {
+		if a.SATCode != "" {
+			return a, true
+		}
+		if a.ParentID == nil {
+			return Account{}, false
+		}
+		parent, ok := byID[*a.ParentID]
+		if !ok {
+			return Account{}, false
+		}
+		a = parent
+	}
+	return Account{}, false
+}
+
+func sortByCode(accounts []Account) {
+	sort.Slice(accounts, func(i, j int) bool {
+		return accounts[i].Code < accounts[j].Code
+	})
+}
@@ -0,0 +1,257 @@
+package ledger
+
+import (
+	"errors"
+	"math"
+	"regexp"
+	"time"
+)
+
+var (
+	ErrAccountNotFound     = errors.New("account not found")
+	ErrEntryNotFound       = errors.New("journal entry not found")
+	ErrDuplicateAccount    = errors.New("account code already exists")
+	ErrEmptyAccountCode    = errors.New("account code must not be empty")
+	ErrEmptyAccountName    = errors.New("account name must not be empty")
+	ErrInvalidAccountType  = errors.New("account type must be asset, liability, equity, income, or expense")
+	ErrEmptyDescription    = errors.New("entry description must not be empty")
+	ErrInvalidUserID       = errors.New("user ID must be positive")
+	ErrTooFewPostings      = errors.New("journal entry needs at least two postings")
+	ErrInvalidPosting      = errors.New("each posting must have a positive debit or credit, not both")
+	ErrInvalidAccountID    = errors.New("posting account ID must be positive")
+	ErrUnbalancedEntry     = errors.New("journal entry debits and credits must be equal")
+	ErrInactiveAccount     = errors.New("cannot post to an inactive account")
+	ErrInvalidDateRange    = errors.New("from date must not be after to date")
+	ErrInvalidNature       = errors.New("account nature must be debit or credit")
+	ErrInvalidSATCode      = errors.New("SAT grouping code must look like 101 or 101.01")
+	ErrParentNotFound      = errors.New("parent account not found")
+	ErrParentTypeMismatch  = errors.New("parent account must have the same type")
+	ErrAccountCycle        = errors.New("an account cannot be its own ancestor")
+	ErrInvalidCategoryKind = errors.New("category kind must be contribution or expense")
+	ErrCategoryNotFound    = errors.New("category not found")
+	ErrCategoryAccountType = errors.New("contribution categories map to income accounts and expense categories to expense accounts")
+)
+
+// satCodePattern matches a SAT código agrupador (Anexo 24): a three-digit
+// major code with an optional two-digit subcode.
+var satCodePattern = regexp.MustCompile(`^[0-9]{3}(\.[0-9]{2})?$`)
+
+type AccountType string
+
+const (
+	AccountAsset     AccountType = "asset"
+	AccountLiability AccountType = "liability"
+	AccountEquity    AccountType = "equity"
+	AccountIncome    AccountType = "income"
+	AccountExpense   AccountType = "expense"
+)
+
+func (t AccountType) Valid() bool {
+	switch t {
+	case AccountAsset, AccountLiability, AccountEquity, AccountIncome, AccountExpense:
+		return true
+	}
+	return false
+}
+
+// DefaultNature is the side the account type normally increases on.
+func (t AccountType) DefaultNature() Nature {
+	if t == AccountAsset || t == AccountExpense {
+		return NatureDebit
+	}
+	return NatureCredit
+}
+
+// Nature (naturaleza) is the side on which an account's balance is carried.
+type Nature string
+
+const (
+	NatureDebit  Nature = "debit"
+	NatureCredit Nature = "credit"
+)
+
+func (n Nature) Valid() bool {
+	return n == NatureDebit || n == NatureCredit
+}
+
+// Account is a ledger account (cuenta contable). Accounts form a tree through
+// ParentID; balances of sub-accounts roll up into their parents.
+type Account struct {
+	ID       int64
+	Code     string
+	Name     string
+	Type     AccountType
+	Nature   Nature
+	ParentID *int64
+	// SATCode is the código agrupador the account reports under in the
+	// electronic accounting filings (e.g. "102.01"). Empty for accounts
+	// that only report through their parent.
+	SATCode   string
+	IsActive  bool
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// ValidSATCode reports whether code is empty or a well-formed código agrupador.
+func ValidSATCode(code string) bool {
+	return code == "" || satCodePattern.MatchString(code)
+}
+
+// Source identifies what produced a journal entry.
+type Source string
+
+const (
+	SourceManual       Source = "manual"
+	SourceContribution Source = "contribution"
+	SourceExpense      Source = "expense"
+	SourceTransfer     Source = "transfer"
+)
+
+// Entry is a balanced journal entry (póliza).
+type Entry struct {
+	ID          int64
+	Date        time.Time
+	Description string
+	Source      Source
+	// SourceID is the contribution, expense or transfer ID for generated entries.
+	SourceID  *int64
+	Postings  []Posting
+	UserID    int64
+	CreatedAt time.Time
+}
+
+// RebuildResult counts the source documents a rebuild regenerated entries
+// for and the orphaned generated entries it removed.
+type RebuildResult struct {
+	Contributions int `json:"contributions"`
+	Expenses      int `json:"expenses"`
+	Transfers     int `json:"transfers"`
+	Removed       int `json:"removed"`
+}
+
+// Posting is one debit or credit line of an Entry.
+type Posting struct {
+	ID        int64
+	AccountID int64
+	Debit     float64
+	Credit    float64
+	Memo      string
+}
+
+// PostingDetail enriches a Posting with account info for display.
+type PostingDetail struct {
+	Posting
+	AccountCode string
+	AccountName string
+}
+
+// EntryDetail is a read-only DTO returned by JOIN queries.
+type EntryDetail struct {
+	Entry
+	Postings []PostingDetail
+}
+
+// NewAccount creates an Account enforcing domain invariants. An empty nature
+// defaults to the one implied by the account type.
+func NewAccount(code, name string, accountType AccountType, nature Nature, parentID *int64, satCode string) (*Account, error) {
+	if code == "" {
+		return nil, ErrEmptyAccountCode
+	}
+	if name == "" {
+		return nil, ErrEmptyAccountName
+	}
+	if !accountType.Valid() {
+		return nil, ErrInvalidAccountType
+	}
+	if nature == "" {
+		nature = accountType.DefaultNature()
+	}
+	if !nature.Valid() {
+		return nil, ErrInvalidNature
+	}
+	if !ValidSATCode(satCode) {
+		return nil, ErrInvalidSATCode
+	}
+
+	now := time.Now()
+	return &Account{
+		Code:      code,
+		Name:      name,
+		Type:      accountType,
+		Nature:    nature,
+		ParentID:  parentID,
+		SATCode:   satCode,
+		IsActive:  true,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}, nil
+}
+
+// CategoryKind tells which catalog a category mapping refers to.
+type CategoryKind string
+
+const (
+	CategoryContribution CategoryKind = "contribution"
+	CategoryExpense      CategoryKind = "expense"
+)
+
+func (k CategoryKind) Valid() bool {
+	return k == CategoryContribution || k == CategoryExpense
+}
+
+// CategoryAccount maps a contribution or expense category to the ledger
+// account its generated postings use. AccountID is nil when the category
+// falls back to the service's default account.
+type CategoryAccount struct {
+	Kind         CategoryKind
+	CategoryID   int64
+	CategoryName string
+	AccountID    *int64
+}
+
+// NewEntry creates an Entry enforcing domain invariants: at least two
+// postings, each one-sided and positive, with debits equal to credits.
+func NewEntry(userID int64, date time.Time, description string, source Source, sourceID *int64, postings []Posting) (*Entry, error) {
+	if userID <= 0 {
+		return nil, ErrInvalidUserID
+	}
+	if description == "" {
+		return nil, ErrEmptyDescription
+	}
+	if len(postings) < 2 {
+		return nil, ErrTooFewPostings
+	}
+
+	var debits, credits int64
+	for _, p := range postings {
+		if p.AccountID <= 0 {
+			return nil, ErrInvalidAccountID
+		}
+		if p.Debit < 0 || p.Credit < 0 || (p.Debit > 0) == (p.Credit > 0) {
+			return nil, ErrInvalidPosting
+		}
+		debits += toCents(p.Debit)
+		credits += toCents(p.Credit)
+	}
+	if debits != credits {
+		return nil, ErrUnbalancedEntry
+	}
+
+	return &Entry{
+		Date:        date,
+		Description: description,
+		Source:      source,
+		SourceID:    sourceID,
+		Postings:    postings,
+		UserID:      userID,
+		CreatedAt:   time.Now(),
+	}, nil
+}
+
+func toCents(v float64) int64 {
+	return int64(math.Round(v * 100))
+}
+
+func roundCents(v float64) float64 {
+	return math.Round(v*100) / 100
+}
@@ -0,0 +1,580 @@
+package ledger
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/ivsanmendez/ControlDeContabilidad/internal/domain/contribution"
+	"github.com/ivsanmendez/ControlDeContabilidad/internal/domain/expense"
+	"github.com/ivsanmendez/ControlDeContabilidad/internal/domain/treasury"
+)
+
+// Repository is the outbound port for ledger persistence.
+type Repository interface {
+	SaveAccount(ctx context.Context, a *Account) error
+	UpdateAccount(ctx context.Context, a *Account) error
+	FindAccountByID(ctx context.Context, id int64) (*Account, error)
+	FindAccountByCode(ctx context.Context, code string) (*Account, error)
+	FindAllAccounts(ctx context.Context) ([]Account, error)
+
+	// SaveEntry inserts the entry and its postings atomically.
+	SaveEntry(ctx context.Context, e *Entry) error
+	// ReplaceSourceEntry atomically removes the entry generated for a source
+	// document and, when e is not nil, inserts e in its place.
+	ReplaceSourceEntry(ctx context.Context, source Source, sourceID int64, e *Entry) error
+	FindEntryByID(ctx context.Context, id int64) (*EntryDetail, error)
+	// FindEntries returns entries dated within [from, to]; a zero bound is open.
+	FindEntries(ctx context.Context, from, to time.Time) ([]EntryDetail, error)
+
+	// SumPostingsByAccount totals debits and credits per account for entries
+	// dated within [from, to]; a zero bound is open.
+	SumPostingsByAccount(ctx context.Context, from, to time.Time) ([]AccountTotal, error)
+
+	FindCategoryAccounts(ctx context.Context) ([]CategoryAccount, error)
+	// FindCategoryAccountID returns the account mapped to a category, or nil.
+	FindCategoryAccountID(ctx context.Context, kind CategoryKind, categoryID int64) (*int64, error)
+	// SetCategoryAccount maps a category to an account; nil clears the mapping.
+	SetCategoryAccount(ctx context.Context, kind CategoryKind, categoryID int64, accountID *int64) error
+
+	// FindFinancialAccountLedgerID returns the ledger account linked to a
+	// financial account (cash box or bank account), or nil.
+	FindFinancialAccountLedgerID(ctx context.Context, financialAccountID int64) (*int64, error)
+
+	// DeleteSourceEntriesExcept removes the entries generated for documents
+	// of source whose ID is not in keep and returns how many it removed.
+	DeleteSourceEntriesExcept(ctx context.Context, source Source, keep []int64) (int, error)
+}
+
+// Sources are the outbound ports for the documents generated entries are
+// derived from; Rebuild reads them to regenerate the ledger.
+type Sources struct {
+	Contributions ContributionSource
+	Expenses      ExpenseSource
+	Transfers     TransferSource
+}
+
+type ContributionSource interface {
+	FindAll(ctx context.Context) ([]contribution.Contribution, error)
+}
+
+type ExpenseSource interface {
+	FindAll(ctx context.Context) ([]expense.Expense, error)
+}
+
+type TransferSource interface {
+	// FindTransfers lists the transfers of one financial account, or all of
+	// them when accountID is 0.
+	FindTransfers(ctx context.Context, accountID int64) ([]treasury.Transfer, error)
+}
+
+// DefaultAccounts are the account codes generated entries post to when a
+// category has no account of its own. They match the chart seeded by the
+// ledger migrations.
+type DefaultAccounts struct {
+	Cash    string
+	Income  string
+	Expense string
+}
+
+var StandardAccounts = DefaultAccounts{
+	Cash:    "1100",
+	Income:  "4100",
+	Expense: "5100",
+}
+
+// Service orchestrates ledger use cases.
+type Service struct {
+	repo     Repository
+	accounts DefaultAccounts
+	sources  Sources
+}
+
+func NewService(repo Repository, accounts DefaultAccounts, sources Sources) *Service {
+	return &Service{repo: repo, accounts: accounts, sources: sources}
+}
+
+func (s *Service) CreateAccount(ctx context.Context, code, name string, accountType AccountType, nature Nature, parentID *int64, satCode string) (*Account, error) {
+	a, err := NewAccount(code, name, accountType, nature, parentID, satCode)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkParent(ctx, a); err != nil {
+		return nil, err
+	}
+	if err := s.repo.SaveAccount(ctx, a); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+func (s *Service) ListAccounts(ctx context.Context) ([]Account, error) {
+	return s.repo.FindAllAccounts(ctx)
+}
+
+// UpdateAccount renames, moves, remaps or (de)activates an account. Code,
+// type and nature are fixed once created so existing postings keep their meaning.
+func (s *Service) UpdateAccount(ctx context.Context, id int64, name string, parentID *int64, satCode string, isActive bool) (*Account, error) {
+	a, err := s.repo.FindAccountByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if name == "" {
+		return nil, ErrEmptyAccountName
+	}
+	if !ValidSATCode(satCode) {
+		return nil, ErrInvalidSATCode
+	}
+
+	a.Name = name
+	a.ParentID = parentID
+	a.SATCode = satCode
+	a.IsActive = isActive
+	a.UpdatedAt = time.Now()
+
+	if err := s.checkParent(ctx, a); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.UpdateAccount(ctx, a); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// CreateManualEntry records an adjusting entry entered by hand.
+func (s *Service) CreateManualEntry(ctx context.Context, callerID int64, date time.Time, description string, postings []Posting) (*Entry, error) {
+	e, err := NewEntry(callerID, date, description, SourceManual, nil, postings)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range e.Postings {
+		a, err := s.repo.FindAccountByID(ctx, p.AccountID)
+		if err != nil {
+			return nil, err
+		}
+		if !a.IsActive {
+			return nil, ErrInactiveAccount
+		}
+	}
+	if err := s.repo.SaveEntry(ctx, e); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+func (s *Service) ListCategoryAccounts(ctx context.Context) ([]CategoryAccount, error) {
+	return s.repo.FindCategoryAccounts(ctx)
+}
+
+// SetCategoryAccount maps a category to the account its generated postings
+// use: income accounts for contribution categories, expense accounts for
+// expense categories. A nil accountID restores the default account.
+func (s *Service) SetCategoryAccount(ctx context.Context, kind CategoryKind, categoryID int64, accountID *int64) error {
+	if !kind.Valid() {
+		return ErrInvalidCategoryKind
+	}
+	if accountID != nil {
+		a, err := s.repo.FindAccountByID(ctx, *accountID)
+		if err != nil {
+			return err
+		}
+		want := AccountIncome
+		if kind == CategoryExpense {
+			want = AccountExpense
+		}
+		if a.Type != want {
+			return ErrCategoryAccountType
+		}
+		if !a.IsActive {
+			return ErrInactiveAccount
+		}
+	}
+	return s.repo.SetCategoryAccount(ctx, kind, categoryID, accountID)
+}
+
+func (s *Service) GetEntry(ctx context.Context, id int64) (*EntryDetail, error) {
+	return s.repo.FindEntryByID(ctx, id)
+}
+
+func (s *Service) ListEntries(ctx context.Context, from, to time.Time) ([]EntryDetail, error) {
+	if !from.IsZero() && !to.IsZero() && from.After(to) {
+		return nil, ErrInvalidDateRange
+	}
+	return s.repo.FindEntries(ctx, from, to)
+}
+
+// HandleContributionEvent keeps the entry generated for a contribution in
+// sync: the receiving account's cash is debited and the category's income
+// account credited on the payment date.
+func (s *Service) HandleContributionEvent(ctx context.Context, ev contribution.Event) error {
+	c := ev.Contribution
+	if ev.Type == contribution.EventDeleted {
+		return s.repo.ReplaceSourceEntry(ctx, SourceContribution, c.ID, nil)
+	}
+
+	cash, err := s.cashAccountID(ctx, c.FinancialAccountID)
+	if err != nil {
+		return err
+	}
+	income, err := s.categoryAccountID(ctx, CategoryContribution, c.CategoryID, s.accounts.Income)
+	if err != nil {
+		return err
+	}
+	id := c.ID
+	e, err := NewEntry(c.UserID, c.PaymentDate,
+		fmt.Sprintf("Contribution #%d (%02d/%d)", c.ID, c.Month, c.Year),
+		SourceContribution, &id,
+		[]Posting{
+			{AccountID: cash, Debit: c.Amount},
+			{AccountID: income, Credit: c.Amount},
+		})
+	if err != nil {
+		return fmt.Errorf("ledger entry for contribution %d: %w", c.ID, err)
+	}
+	return s.repo.ReplaceSourceEntry(ctx, SourceContribution, c.ID, e)
+}
+
+// HandleExpenseEvent keeps the entry generated for an expense in sync: each
+// line debits its category's expense account and the total is credited to
+// the paying account's cash on the expense date.
+func (s *Service) HandleExpenseEvent(ctx context.Context, ev expense.Event) error {
+	x := ev.Expense
+	if ev.Type == expense.EventDeleted {
+		return s.repo.ReplaceSourceEntry(ctx, SourceExpense, x.ID, nil)
+	}
+
+	cash, err := s.cashAccountID(ctx, x.FinancialAccountID)
+	if err != nil {
+		return err
+	}
+	lines := x.Lines
+	if len(lines) == 0 {
+		lines = []expense.Line{{CategoryID: x.CategoryID, Description: x.Description, Amount: x.Amount}}
+	}
+	postings := make([]Posting, 0, len(lines)+1)
+	for _, l := range lines {
+		acc, err := s.categoryAccountID(ctx, CategoryExpense, l.CategoryID, s.accounts.Expense)
+		if err != nil {
+			return err
+		}
+		postings = append(postings, Posting{AccountID: acc, Debit: l.Amount, Memo: l.Description})
+	}
+	postings = append(postings, Posting{AccountID: cash, Credit: x.Amount})
+
+	id := x.ID
+	e, err := NewEntry(x.UserID, x.Date,
+		fmt.Sprintf("Expense #%d: %s", x.ID, x.Description),
+		SourceExpense, &id, postings)
+	if err != nil {
+		return fmt.Errorf("ledger entry for expense %d: %w", x.ID, err)
+	}
+	return s.repo.ReplaceSourceEntry(ctx, SourceExpense, x.ID, e)
+}
+
+// HandleTransferEvent keeps the entry generated for a transfer in sync: the
+// destination account's cash is debited and the source's credited. Transfers
+// between financial accounts sharing a ledger account generate no entry.
+func (s *Service) HandleTransferEvent(ctx context.Context, ev treasury.Event) error {
+	t := ev.Transfer
+	if ev.Type == treasury.EventTransferDeleted {
+		return s.repo.ReplaceSourceEntry(ctx, SourceTransfer, t.ID, nil)
+	}
+
+	from, err := s.cashAccountID(ctx, t.FromAccountID)
+	if err != nil {
+		return err
+	}
+	to, err := s.cashAccountID(ctx, t.ToAccountID)
+	if err != nil {
+		return err
+	}
+	if from == to {
+		return s.repo.ReplaceSourceEntry(ctx, SourceTransfer, t.ID, nil)
+	}
+
+	id := t.ID
+	e, err := NewEntry(t.UserID, t.Date,
+		fmt.Sprintf("Transfer #%d", t.ID),
+		SourceTransfer, &id,
+		[]Posting{
+			{AccountID: to, Debit: t.Amount, Memo: t.Description},
+			{AccountID: from, Credit: t.Amount, Memo: t.Description},
+		})
+	if err != nil {
+		return fmt.Errorf("ledger entry for transfer %d: %w", t.ID, err)
+	}
+	return s.repo.ReplaceSourceEntry(ctx, SourceTransfer, t.ID, e)
+}
+
+// Rebuild regenerates the entry of every contribution, expense and transfer
+// from the source tables and removes generated entries whose document no
+// longer exists. It repairs the ledger after an event failed to post and is
+// safe to run at any time: manual entries are left untouched.
+func (s *Service) Rebuild(ctx context.Context) (*RebuildResult, error) {
+	var res RebuildResult
+
+	contributions, err := s.sources.Contributions.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]int64, 0, len(contributions))
+	for _, c := range contributions {
+		if err := s.HandleContributionEvent(ctx, contribution.Event{Type: contribution.EventUpdated, Contribution: c}); err != nil {
+			return nil, err
+		}
+		ids = append(ids, c.ID)
+	}
+	res.Contributions = len(ids)
+	if res.Removed, err = s.repo.DeleteSourceEntriesExcept(ctx, SourceContribution, ids); err != nil {
+		return nil, err
+	}
+
+	expenses, err := s.sources.Expenses.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ids = make([]int64, 0, len(expenses))
+	for _, x := range expenses {
+		if err := s.HandleExpenseEvent(ctx, expense.Event{Type: expense.EventUpdated, Expense: x}); err != nil {
+			return nil, err
+		}
+		ids = append(ids, x.ID)
+	}
+	res.Expenses = len(ids)
+	removed, err := s.repo.DeleteSourceEntriesExcept(ctx, SourceExpense, ids)
+	if err != nil {
+		return nil, err
+	}
+	res.Removed += removed
+
+	transfers, err := s.sources.Transfers.FindTransfers(ctx, 0)
+	if err != nil {
+		return nil, err
+	}
+	ids = make([]int64, 0, len(transfers))
+	for _, t := range transfers {
+		if err := s.HandleTransferEvent(ctx, treasury.Event{Type: treasury.EventTransferCreated, Transfer: t}); err != nil {
+			return nil, err
+		}
+		ids = append(ids, t.ID)
+	}
+	res.Transfers = len(ids)
+	if removed, err = s.repo.DeleteSourceEntriesExcept(ctx, SourceTransfer, ids); err != nil {
+		return nil, err
+	}
+	res.Removed += removed
+
+	return &res, nil
+}
+
+// GetTrialBalance lists every account's movements up to and including asOf.
+func (s *Service) GetTrialBalance(ctx context.Context, asOf time.Time) (*TrialBalance, error) {
+	balances, err := s.balances(ctx, time.Time{}, asOf)
+	if err != nil {
+		return nil, err
+	}
+
+	tb := &TrialBalance{AsOf: asOf, Accounts: balances}
+	for _, b := range balances {
+		tb.TotalDebit += b.Debit
+		tb.TotalCredit += b.Credit
+	}
+	tb.TotalDebit = roundCents(tb.TotalDebit)
+	tb.TotalCredit = roundCents(tb.TotalCredit)
+	tb.Balanced = toCents(tb.TotalDebit) == toCents(tb.TotalCredit)
+	return tb, nil
+}
+
+// GetBalanceSheet reports assets, liabilities and equity at asOf.
+func (s *Service) GetBalanceSheet(ctx context.Context, asOf time.Time) (*BalanceSheet, error) {
+	balances, err := s.balances(ctx, time.Time{}, asOf)
+	if err != nil {
+		return nil, err
+	}
+
+	bs := &BalanceSheet{
+		AsOf:        asOf,
+		Assets:      []AccountBalance{},
+		Liabilities: []AccountBalance{},
+		Equity:      []AccountBalance{},
+	}
+	for _, b := range balances {
+		switch b.AccountType {
+		case AccountAsset:
+			bs.Assets = append(bs.Assets, b)
+			bs.TotalAssets += sectionBalance(b)
+		case AccountLiability:
+			bs.Liabilities = append(bs.Liabilities, b)
+			bs.TotalLiabilities += sectionBalance(b)
+		case AccountEquity:
+			bs.Equity = append(bs.Equity, b)
+			bs.TotalEquity += sectionBalance(b)
+		case AccountIncome:
+			bs.NetIncome += sectionBalance(b)
+		case AccountExpense:
+			bs.NetIncome -= sectionBalance(b)
+		}
+	}
+	bs.NetIncome = roundCents(bs.NetIncome)
+	bs.TotalAssets = roundCents(bs.TotalAssets)
+	bs.TotalLiabilities = roundCents(bs.TotalLiabilities)
+	bs.TotalEquity = roundCents(bs.TotalEquity + bs.NetIncome)
+	bs.Balanced = toCents(bs.TotalAssets) == toCents(bs.TotalLiabilities)+toCents(bs.TotalEquity)
+	return bs, nil
+}
+
+// GetIncomeStatement reports income and expenses for [from, to].
+func (s *Service) GetIncomeStatement(ctx context.Context, from, to time.Time) (*IncomeStatement, error) {
+	if from.After(to) {
+		return nil, ErrInvalidDateRange
+	}
+	balances, err := s.balances(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	is := &IncomeStatement{
+		From:     from,
+		To:       to,
+		Income:   []AccountBalance{},
+		Expenses: []AccountBalance{},
+	}
+	for _, b := range balances {
+		switch b.AccountType {
+		case AccountIncome:
+			is.Income = append(is.Income, b)
+			is.TotalIncome += sectionBalance(b)
+		case AccountExpense:
+			is.Expenses = append(is.Expenses, b)
+			is.TotalExpenses += sectionBalance(b)
+		}
+	}
+	is.TotalIncome = roundCents(is.TotalIncome)
+	is.TotalExpenses = roundCents(is.TotalExpenses)
+	is.NetIncome = roundCents(is.TotalIncome - is.TotalExpenses)
+	return is, nil
+}
+
+// GetChartBalance reports balances at asOf through the account hierarchy and
+// grouped by SAT código agrupador.
+func (s *Service) GetChartBalance(ctx context.Context, asOf time.Time) (*ChartBalance, error) {
+	balances, err := s.balances(ctx, time.Time{}, asOf)
+	if err != nil {
+		return nil, err
+	}
+	accounts, err := s.repo.FindAllAccounts(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return buildChartBalance(asOf, accounts, balances), nil
+}
+
+// balances joins posting totals with the chart of accounts, ordered by code.
+func (s *Service) balances(ctx context.Context, from, to time.Time) ([]AccountBalance, error) {
+	totals, err := s.repo.SumPostingsByAccount(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+	accounts, err := s.repo.FindAllAccounts(ctx)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[int64]Account, len(accounts))
+	for _, a := range accounts {
+		byID[a.ID] = a
+	}
+
+	result := make([]AccountBalance, 0, len(totals))
+	for _, t := range totals {
+		a, ok := byID[t.AccountID]
+		if !ok {
+			return nil, fmt.Errorf("posting to unknown account %d: %w", t.AccountID, ErrAccountNotFound)
+		}
+		result = append(result, AccountBalance{
+			AccountID:   a.ID,
+			AccountCode: a.Code,
+			AccountName: a.Name,
+			AccountType: a.Type,
+			Nature:      a.Nature,
+			SATCode:     a.SATCode,
+			Debit:       roundCents(t.Debit),
+			Credit:      roundCents(t.Credit),
+			Balance:     natureBalance(a.Nature, t.Debit, t.Credit),
+		})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].AccountCode < result[j].AccountCode
+	})
+	return result, nil
+}
+
+// checkParent validates a's parent: it must exist, share a's type and not
+// be a itself or one of its descendants.
+func (s *Service) checkParent(ctx context.Context, a *Account) error {
+	if a.ParentID == nil {
+		return nil
+	}
+	parent, err := s.repo.FindAccountByID(ctx, *a.ParentID)
+	if errors.Is(err, ErrAccountNotFound) {
+		return ErrParentNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if parent.Type != a.Type {
+		return ErrParentTypeMismatch
+	}
+	if a.ID == 0 {
+		return nil
+	}
+	for p := parent; ; {
+		if p.ID == a.ID {
+			return ErrAccountCycle
+		}
+		if p.ParentID == nil {
+			return nil
+		}
+		if p, err = s.repo.FindAccountByID(ctx, *p.ParentID); err != nil {
+			return err
+		}
+	}
+}
+
+// categoryAccountID returns the account mapped to the category, falling
+// back to the account with the given default code.
+func (s *Service) categoryAccountID(ctx context.Context, kind CategoryKind, categoryID int64, defaultCode string) (int64, error) {
+	id, err := s.repo.FindCategoryAccountID(ctx, kind, categoryID)
+	if err != nil {
+		return 0, err
+	}
+	if id != nil {
+		return *id, nil
+	}
+	return s.accountID(ctx, defaultCode)
+}
+
+// cashAccountID returns the ledger account linked to the financial account,
+// falling back to the default cash account.
+func (s *Service) cashAccountID(ctx context.Context, financialAccountID int64) (int64, error) {
+	if financialAccountID > 0 {
+		id, err := s.repo.FindFinancialAccountLedgerID(ctx, financialAccountID)
+		if err != nil {
+			return 0, err
+		}
+		if id != nil {
+			return *id, nil
+		}
+	}
+	return s.accountID(ctx, s.accounts.Cash)
+}
+
+func (s *Service) accountID(ctx context.Context, code string) (int64, error) {
+	a, err := s.repo.FindAccountByCode(ctx, code)
+	if err != nil {
+		return 0, fmt.Errorf("ledger account %s: %w", code, err)
+	}
+	return a.ID, nil
+}
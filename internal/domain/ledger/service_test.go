@@ -0,0 +1,616 @@
+package ledger_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/ivsanmendez/ControlDeContabilidad/internal/domain/contribution"
+	"github.com/ivsanmendez/ControlDeContabilidad/internal/domain/expense"
+	"github.com/ivsanmendez/ControlDeContabilidad/internal/domain/ledger"
+	"github.com/ivsanmendez/ControlDeContabilidad/internal/domain/treasury"
+)
+
+// --- Fakes ---
+
+type fakeRepo struct {
+	accounts   []ledger.Account
+	entries    []ledger.Entry
+	categories map[ledger.CategoryKind]map[int64]*int64
+	financial  map[int64]*int64
+	nextID     int64
+}
+
+func newFakeRepo() *fakeRepo {
+	r := &fakeRepo{
+		nextID:    1,
+		financial: map[int64]*int64{},
+		categories: map[ledger.CategoryKind]map[int64]*int64{
+			ledger.CategoryContribution: {},
+			ledger.CategoryExpense:      {},
+		},
+	}
+	for _, a := range []ledger.Account{
+		{Code: "1100", Name: "Caja y bancos", Type: ledger.AccountAsset, SATCode: "101.01"},
+		{Code: "2100", Name: "Cuentas por pagar", Type: ledger.AccountLiability, SATCode: "201.01"},
+		{Code: "3100", Name: "Patrimonio", Type: ledger.AccountEquity, SATCode: "302.01"},
+		{Code: "4100", Name: "Ingresos por cuotas", Type: ledger.AccountIncome, SATCode: "401.01"},
+		{Code: "5100", Name: "Gastos de operación", Type: ledger.AccountExpense, SATCode: "601.84"},
+	} {
+		a.Nature = a.Type.DefaultNature()
+		a.IsActive = true
+		r.SaveAccount(ctx, &a)
+	}
+	return r
+}
+
+func (r *fakeRepo) id() int64 {
+	id := r.nextID
+	r.nextID++
+	return id
+}
+
+func (r *fakeRepo) SaveAccount(_ context.Context, a *ledger.Account) error {
+	a.ID = r.id()
+	r.accounts = append(r.accounts, *a)
+	return nil
+}
+
+func (r *fakeRepo) UpdateAccount(_ context.Context, a *ledger.Account) error {
+	for i := range r.accounts {
+		if r.accounts[i].ID == a.ID {
+			r.accounts[i] = *a
+			return nil
+		}
+	}
+	return ledger.ErrAccountNotFound
+}
+
+func (r *fakeRepo) FindAccountByID(_ context.Context, id int64) (*ledger.Account, error) {
+	for _, a := range r.accounts {
+		if a.ID == id {
+			return &a, nil
+		}
+	}
+	return nil, ledger.ErrAccountNotFound
+}
+
+func (r *fakeRepo) FindAccountByCode(_ context.Context, code string) (*ledger.Account, error) {
+	for _, a := range r.accounts {
+		if a.Code == code {
+			return &a, nil
+		}
+	}
+	return nil, ledger.ErrAccountNotFound
+}
+
+func (r *fakeRepo) FindAllAccounts(_ context.Context) ([]ledger.Account, error) {
+	return r.accounts, nil
+}
+
+func (r *fakeRepo) SaveEntry(_ context.Context, e *ledger.Entry) error {
+	e.ID = r.id()
+	r.entries = append(r.entries, *e)
+	return nil
+}
+
+func (r *fakeRepo) ReplaceSourceEntry(_ context.Context, source ledger.Source, sourceID int64, e *ledger.Entry) error {
+	kept := r.entries[:0]
+	for _, x := range r.entries {
+		if x.Source == source && x.SourceID != nil && *x.SourceID == sourceID {
+			continue
+		}
+		kept = append(kept, x)
+	}
+	r.entries = kept
+	if e != nil {
+		return r.SaveEntry(ctx, e)
+	}
+	return nil
+}
+
+func (r *fakeRepo) DeleteSourceEntriesExcept(_ context.Context, source ledger.Source, keep []int64) (int, error) {
+	kept := r.entries[:0]
+	for _, x := range r.entries {
+		if x.Source == source && x.SourceID != nil && !slices.Contains(keep, *x.SourceID) {
+			continue
+		}
+		kept = append(kept, x)
+	}
+	removed := len(r.entries) - len(kept)
+	r.entries = kept
+	return removed, nil
+}
+
+func (r *fakeRepo) FindEntryByID(_ context.Context, id int64) (*ledger.EntryDetail, error) {
+	for _, e := range r.entries {
+		if e.ID == id {
+			return &ledger.EntryDetail{Entry: e}, nil
+		}
+	}
+	return nil, ledger.ErrEntryNotFound
+}
+
+func (r *fakeRepo) FindEntries(_ context.Context, from, to time.Time) ([]ledger.EntryDetail, error) {
+	var result []ledger.EntryDetail
+	for _, e := range r.entries {
+		if inRange(e.Date, from, to) {
+			result = append(result, ledger.EntryDetail{Entry: e})
+		}
+	}
+	return result, nil
+}
+
+func (r *fakeRepo) SumPostingsByAccount(_ context.Context, from, to time.Time) ([]ledger.AccountTotal, error) {
+	totals := map[int64]*ledger.AccountTotal{}
+	var order []int64
+	for _, e := range r.entries {
+		if !inRange(e.Date, from, to) {
+			continue
+		}
+		for _, p := range e.Postings {
+			t, ok := totals[p.AccountID]
+			if !ok {
+				t = &ledger.AccountTotal{AccountID: p.AccountID}
+				totals[p.AccountID] = t
+				order = append(order, p.AccountID)
+			}
+			t.Debit += p.Debit
+			t.Credit += p.Credit
+		}
+	}
+	result := make([]ledger.AccountTotal, 0, len(order))
+	for _, id := range order {
+		result = append(result, *totals[id])
+	}
+	return result, nil
+}
+
+func (r *fakeRepo) FindCategoryAccounts(_ context.Context) ([]ledger.CategoryAccount, error) {
+	var result []ledger.CategoryAccount
+	for kind, m := range r.categories {
+		for id, acc := range m {
+			result = append(result, ledger.CategoryAccount{Kind: kind, CategoryID: id, AccountID: acc})
+		}
+	}
+	return result, nil
+}
+
+func (r *fakeRepo) FindCategoryAccountID(_ context.Context, kind ledger.CategoryKind, categoryID int64) (*int64, error) {
+	return r.categories[kind][categoryID], nil
+}
+
+func (r *fakeRepo) SetCategoryAccount(_ context.Context, kind ledger.CategoryKind, categoryID int64, accountID *int64) error {
+	r.categories[kind][categoryID] = accountID
+	return nil
+}
+
+func (r *fakeRepo) FindFinancialAccountLedgerID(_ context.Context, financialAccountID int64) (*int64, error) {
+	return r.financial[financialAccountID], nil
+}
+
+type fakeContributions []contribution.Contribution
+
+func (f fakeContributions) FindAll(_ context.Context) ([]contribution.Contribution, error) {
+	return f, nil
+}
+
+type fakeExpenses []expense.Expense
+
+func (f fakeExpenses) FindAll(_ context.Context) ([]expense.Expense, error) {
+	return f, nil
+}
+
+type fakeTransfers []treasury.Transfer
+
+func (f fakeTransfers) FindTransfers(_ context.Context, _ int64) ([]treasury.Transfer, error) {
+	return f, nil
+}
+
+func inRange(d, from, to time.Time) bool {
+	return (from.IsZero() || !d.Before(from)) && (to.IsZero() || !d.After(to))
+}
+
+var ctx = context.Background()
+
+func date(m time.Month, d int) time.Time {
+	return time.Date(2026, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+func setup(t *testing.T) (*ledger.Service, *fakeRepo) {
+	t.Helper()
+	repo := newFakeRepo()
+	return ledger.NewService(repo, ledger.StandardAccounts, ledger.Sources{}), repo
+}
+
+func accountID(t *testing.T, repo *fakeRepo, code string) int64 {
+	t.Helper()
+	a, err := repo.FindAccountByCode(ctx, code)
+	if err != nil {
+		t.Fatalf("account %s: %v", code, err)
+	}
+	return a.ID
+}
+
+// --- Tests ---
+
+func TestNewEntry_Validation(t *testing.T) {
+	tests := []struct {
+		name     string
+		postings []ledger.Posting
+		want     error
+	}{
+		{"single posting", []ledger.Posting{{AccountID: 1, Debit: 10}}, ledger.ErrTooFewPostings},
+		{"unbalanced", []ledger.Posting{{AccountID: 1, Debit: 10}, {AccountID: 2, Credit: 9.99}}, ledger.ErrUnbalancedEntry},
+		{"both sides", []ledger.Posting{{AccountID: 1, Debit: 10, Credit: 10}, {AccountID: 2, Credit: 0}}, ledger.ErrInvalidPosting},
+		{"zero amount", []ledger.Posting{{AccountID: 1}, {AccountID: 2}}, ledger.ErrInvalidPosting},
+		{"negative", []ledger.Posting{{AccountID: 1, Debit: -5}, {AccountID: 2, Credit: -5}}, ledger.ErrInvalidPosting},
+		{"missing account", []ledger.Posting{{Debit: 10}, {AccountID: 2, Credit: 10}}, ledger.ErrInvalidAccountID},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ledger.NewEntry(1, date(1, 1), "Ajuste", ledger.SourceManual, nil, tt.postings)
+			if err != tt.want {
+				t.Fatalf("expected %v, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestNewEntry_BalancedAcrossSeveralLines(t *testing.T) {
+	_, err := ledger.NewEntry(1, date(1, 1), "Ajuste", ledger.SourceManual, nil, []ledger.Posting{
+		{AccountID: 1, Debit: 0.1},
+		{AccountID: 1, Debit: 0.2},
+		{AccountID: 2, Credit: 0.3},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestContributionEvents_KeepGeneratedEntryInSync(t *testing.T) {
+	svc, repo := setup(t)
+	c := contribution.Contribution{ID: 7, Amount: 500, Month: 3, Year: 2026, PaymentDate: date(3, 5), UserID: 1}
+
+	if err := svc.HandleContributionEvent(ctx, contribution.Event{Type: contribution.EventCreated, Contribution: c}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	c.Amount = 650
+	if err := svc.HandleContributionEvent(ctx, contribution.Event{Type: contribution.EventUpdated, Contribution: c}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(repo.entries) != 1 {
+		t.Fatalf("expected a single generated entry, got %d", len(repo.entries))
+	}
+	e := repo.entries[0]
+	if e.Source != ledger.SourceContribution || *e.SourceID != 7 || !e.Date.Equal(c.PaymentDate) {
+		t.Fatalf("unexpected entry header: %+v", e)
+	}
+	cash, income := accountID(t, repo, "1100"), accountID(t, repo, "4100")
+	if e.Postings[0].AccountID != cash || e.Postings[0].Debit != 650 ||
+		e.Postings[1].AccountID != income || e.Postings[1].Credit != 650 {
+		t.Fatalf("unexpected postings: %+v", e.Postings)
+	}
+
+	if err := svc.HandleContributionEvent(ctx, contribution.Event{Type: contribution.EventDeleted, Contribution: c}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(repo.entries) != 0 {
+		t.Fatalf("expected entry removed with its contribution, got %d", len(repo.entries))
+	}
+}
+
+func TestExpenseEvent_DebitsExpenseCreditsCash(t *testing.T) {
+	svc, repo := setup(t)
+	x := expense.Expense{ID: 3, Description: "Luz", Amount: 1200, Date: date(3, 10), UserID: 1}
+
+	if err := svc.HandleExpenseEvent(ctx, expense.Event{Type: expense.EventCreated, Expense: x}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	e := repo.entries[0]
+	if e.Postings[0].AccountID != accountID(t, repo, "5100") || e.Postings[0].Debit != 1200 ||
+		e.Postings[1].AccountID != accountID(t, repo, "1100") || e.Postings[1].Credit != 1200 {
+		t.Fatalf("unexpected postings: %+v", e.Postings)
+	}
+}
+
+func TestCreateManualEntry_RejectsInactiveAccount(t *testing.T) {
+	svc, repo := setup(t)
+	payable := accountID(t, repo, "2100")
+	if _, err := svc.UpdateAccount(ctx, payable, "Cuentas por pagar", nil, "201.01", false); err != nil {
+		t.Fatalf("update account: %v", err)
+	}
+
+	_, err := svc.CreateManualEntry(ctx, 1, date(3, 31), "Provisión", []ledger.Posting{
+		{AccountID: accountID(t, repo, "5100"), Debit: 300},
+		{AccountID: payable, Credit: 300},
+	})
+	if !errors.Is(err, ledger.ErrInactiveAccount) {
+		t.Fatalf("expected ErrInactiveAccount, got %v", err)
+	}
+}
+
+func TestStatements(t *testing.T) {
+	svc, repo := setup(t)
+	svc.HandleContributionEvent(ctx, contribution.Event{Type: contribution.EventCreated,
+		Contribution: contribution.Contribution{ID: 1, Amount: 1000, Month: 1, Year: 2026, PaymentDate: date(1, 10), UserID: 1}})
+	svc.HandleContributionEvent(ctx, contribution.Event{Type: contribution.EventCreated,
+		Contribution: contribution.Contribution{ID: 2, Amount: 1000, Month: 2, Year: 2026, PaymentDate: date(2, 10), UserID: 1}})
+	svc.HandleExpenseEvent(ctx, expense.Event{Type: expense.EventCreated,
+		Expense: expense.Expense{ID: 1, Description: "Jardinería", Amount: 400, Date: date(2, 15), UserID: 1}})
+
+	// Adjusting entry: an unpaid invoice accrued as a liability.
+	if _, err := svc.CreateManualEntry(ctx, 1, date(2, 28), "Provisión vigilancia", []ledger.Posting{
+		{AccountID: accountID(t, repo, "5100"), Debit: 250},
+		{AccountID: accountID(t, repo, "2100"), Credit: 250},
+	}); err != nil {
+		t.Fatalf("manual entry: %v", err)
+	}
+
+	tb, err := svc.GetTrialBalance(ctx, date(2, 28))
+	if err != nil {
+		t.Fatalf("trial balance: %v", err)
+	}
+	if !tb.Balanced || tb.TotalDebit != 2650 || tb.TotalCredit != 2650 {
+		t.Fatalf("trial balance = %+v", tb)
+	}
+	if len(tb.Accounts) != 4 || tb.Accounts[0].AccountCode != "1100" || tb.Accounts[0].Balance != 1600 {
+		t.Fatalf("trial balance accounts = %+v", tb.Accounts)
+	}
+
+	bs, err := svc.GetBalanceSheet(ctx, date(2, 28))
+	if err != nil {
+		t.Fatalf("balance sheet: %v", err)
+	}
+	if bs.TotalAssets != 1600 || bs.TotalLiabilities != 250 || bs.NetIncome != 1350 || bs.TotalEquity != 1350 || !bs.Balanced {
+		t.Fatalf("balance sheet = %+v", bs)
+	}
+
+	is, err := svc.GetIncomeStatement(ctx, date(2, 1), date(2, 28))
+	if err != nil {
+		t.Fatalf("income statement: %v", err)
+	}
+	if is.TotalIncome != 1000 || is.TotalExpenses != 650 || is.NetIncome != 350 {
+		t.Fatalf("income statement = %+v", is)
+	}
+
+	if _, err := svc.GetIncomeStatement(ctx, date(3, 1), date(2, 1)); !errors.Is(err, ledger.ErrInvalidDateRange) {
+		t.Fatalf("expected ErrInvalidDateRange, got %v", err)
+	}
+}
+
+func TestStatements_ContraAccounts(t *testing.T) {
+	svc, repo := setup(t)
+	cash := accountID(t, repo, "1100")
+	income := accountID(t, repo, "4100")
+	depreciation, err := svc.CreateAccount(ctx, "1200", "Depreciación acumulada", ledger.AccountAsset, ledger.NatureCredit, nil, "171.01")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	discounts, err := svc.CreateAccount(ctx, "4900", "Descuentos sobre cuotas", ledger.AccountIncome, ledger.NatureDebit, &income, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	svc.HandleContributionEvent(ctx, contribution.Event{Type: contribution.EventCreated,
+		Contribution: contribution.Contribution{ID: 1, Amount: 1000, Month: 1, Year: 2026, PaymentDate: date(1, 10), UserID: 1}})
+	for _, p := range [][]ledger.Posting{
+		{{AccountID: accountID(t, repo, "5100"), Debit: 100}, {AccountID: depreciation.ID, Credit: 100}},
+		{{AccountID: discounts.ID, Debit: 50}, {AccountID: cash, Credit: 50}},
+	} {
+		if _, err := svc.CreateManualEntry(ctx, 1, date(1, 31), "Ajuste", p); err != nil {
+			t.Fatalf("manual entry: %v", err)
+		}
+	}
+
+	bs, err := svc.GetBalanceSheet(ctx, date(1, 31))
+	if err != nil {
+		t.Fatalf("balance sheet: %v", err)
+	}
+	// Cash 950 less 100 of accumulated depreciation; income 1000 less 50 of
+	// discounts and 100 of depreciation expense.
+	if bs.TotalAssets != 850 || bs.NetIncome != 850 || bs.TotalEquity != 850 || !bs.Balanced {
+		t.Fatalf("balance sheet = %+v", bs)
+	}
+
+	is, err := svc.GetIncomeStatement(ctx, date(1, 1), date(1, 31))
+	if err != nil {
+		t.Fatalf("income statement: %v", err)
+	}
+	if is.TotalIncome != 950 || is.TotalExpenses != 100 || is.NetIncome != 850 {
+		t.Fatalf("income statement = %+v", is)
+	}
+}
+
+func TestCreateAccount_Hierarchy(t *testing.T) {
+	svc, repo := setup(t)
+	cash := accountID(t, repo, "1100")
+
+	bank, err := svc.CreateAccount(ctx, "1110", "Banco BBVA", ledger.AccountAsset, "", &cash, "102.01")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if bank.Nature != ledger.NatureDebit {
+		t.Fatalf("nature should default from type, got %q", bank.Nature)
+	}
+
+	if _, err := svc.CreateAccount(ctx, "4110", "Cuotas", ledger.AccountIncome, "", &cash, ""); !errors.Is(err, ledger.ErrParentTypeMismatch) {
+		t.Fatalf("expected ErrParentTypeMismatch, got %v", err)
+	}
+	missing := int64(999)
+	if _, err := svc.CreateAccount(ctx, "1120", "Caja", ledger.AccountAsset, "", &missing, ""); !errors.Is(err, ledger.ErrParentNotFound) {
+		t.Fatalf("expected ErrParentNotFound, got %v", err)
+	}
+	if _, err := svc.CreateAccount(ctx, "1130", "Caja", ledger.AccountAsset, "", nil, "1.1"); !errors.Is(err, ledger.ErrInvalidSATCode) {
+		t.Fatalf("expected ErrInvalidSATCode, got %v", err)
+	}
+	if _, err := svc.UpdateAccount(ctx, cash, "Caja y bancos", &bank.ID, "101.01", true); !errors.Is(err, ledger.ErrAccountCycle) {
+		t.Fatalf("expected ErrAccountCycle, got %v", err)
+	}
+}
+
+func TestCategoryAccounts_RouteGeneratedPostings(t *testing.T) {
+	svc, repo := setup(t)
+	income := accountID(t, repo, "4100")
+	expenses := accountID(t, repo, "5100")
+	maint, _ := svc.CreateAccount(ctx, "4200", "Cuotas de mantenimiento", ledger.AccountIncome, "", &income, "")
+	water, _ := svc.CreateAccount(ctx, "5200", "Agua", ledger.AccountExpense, "", &expenses, "601.50")
+
+	if err := svc.SetCategoryAccount(ctx, ledger.CategoryExpense, 9, &maint.ID); !errors.Is(err, ledger.ErrCategoryAccountType) {
+		t.Fatalf("expected ErrCategoryAccountType, got %v", err)
+	}
+	if err := svc.SetCategoryAccount(ctx, ledger.CategoryContribution, 2, &maint.ID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := svc.SetCategoryAccount(ctx, ledger.CategoryExpense, 9, &water.ID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	svc.HandleContributionEvent(ctx, contribution.Event{Type: contribution.EventCreated,
+		Contribution: contribution.Contribution{ID: 1, CategoryID: 2, Amount: 800, Month: 1, Year: 2026, PaymentDate: date(1, 5), UserID: 1}})
+	if got := repo.entries[0].Postings[1].AccountID; got != maint.ID {
+		t.Fatalf("contribution credited account %d, want %d", got, maint.ID)
+	}
+
+	// One ticket split across a mapped and an unmapped category.
+	svc.HandleExpenseEvent(ctx, expense.Event{Type: expense.EventCreated, Expense: expense.Expense{
+		ID: 1, Description: "Ferretería", Amount: 500, Date: date(1, 20), UserID: 1,
+		Lines: []expense.Line{
+			{CategoryID: 9, Description: "Tubería", Amount: 300},
+			{CategoryID: 4, Description: "Pintura", Amount: 200},
+		},
+	}})
+	p := repo.entries[1].Postings
+	if len(p) != 3 || p[0].AccountID != water.ID || p[0].Debit != 300 ||
+		p[1].AccountID != expenses || p[1].Debit != 200 || p[2].Credit != 500 {
+		t.Fatalf("unexpected expense postings: %+v", p)
+	}
+
+	cb, err := svc.GetChartBalance(ctx, date(1, 31))
+	if err != nil {
+		t.Fatalf("chart balance: %v", err)
+	}
+	var incomeNode ledger.AccountNode
+	for _, n := range cb.Accounts {
+		if n.AccountCode == "4100" {
+			incomeNode = n
+		}
+	}
+	if incomeNode.Balance != 800 || len(incomeNode.Children) != 1 || incomeNode.Children[0].Balance != 800 {
+		t.Fatalf("income should roll up from its sub-account, got %+v", incomeNode)
+	}
+
+	bySAT := map[string]float64{}
+	for _, g := range cb.BySAT {
+		bySAT[g.SATCode] = g.Balance
+	}
+	// 4200 has no SAT code of its own and reports under its parent's.
+	if bySAT["401.01"] != 800 || bySAT["601.50"] != 300 || bySAT["601.84"] != 200 || bySAT["101.01"] != 300 {
+		t.Fatalf("unexpected SAT grouping: %+v", cb.BySAT)
+	}
+}
+
+func TestTransferEvents_MoveCashBetweenLedgerAccounts(t *testing.T) {
+	svc, repo := setup(t)
+	cash := accountID(t, repo, "1100")
+	bank, _ := svc.CreateAccount(ctx, "1120", "Bancos", ledger.AccountAsset, "", &cash, "102.01")
+	repo.financial[2] = &bank.ID
+
+	// Contributions deposited straight into the bank debit its ledger account.
+	svc.HandleContributionEvent(ctx, contribution.Event{Type: contribution.EventCreated,
+		Contribution: contribution.Contribution{ID: 1, CategoryID: 1, Amount: 500, Month: 1, Year: 2026, PaymentDate: date(1, 5), FinancialAccountID: 2, UserID: 1}})
+	if got := repo.entries[0].Postings[0].AccountID; got != bank.ID {
+		t.Fatalf("contribution debited account %d, want %d", got, bank.ID)
+	}
+
+	tr := treasury.Transfer{ID: 7, FromAccountID: 1, ToAccountID: 2, Amount: 200, Date: date(1, 10), UserID: 1}
+	if err := svc.HandleTransferEvent(ctx, treasury.Event{Type: treasury.EventTransferCreated, Transfer: tr}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p := repo.entries[1].Postings
+	if repo.entries[1].Source != ledger.SourceTransfer || p[0].AccountID != bank.ID || p[0].Debit != 200 ||
+		p[1].AccountID != cash || p[1].Credit != 200 {
+		t.Fatalf("unexpected transfer entry: %+v", repo.entries[1])
+	}
+
+	// Both sides on the default cash account: nothing to post.
+	same := treasury.Transfer{ID: 8, FromAccountID: 1, ToAccountID: 3, Amount: 50, Date: date(1, 11), UserID: 1}
+	svc.HandleTransferEvent(ctx, treasury.Event{Type: treasury.EventTransferCreated, Transfer: same})
+	if len(repo.entries) != 2 {
+		t.Fatalf("expected no entry for a transfer within one ledger account, got %d entries", len(repo.entries))
+	}
+
+	svc.HandleTransferEvent(ctx, treasury.Event{Type: treasury.EventTransferDeleted, Transfer: tr})
+	if len(repo.entries) != 1 {
+		t.Fatalf("expected transfer entry removed, got %d entries", len(repo.entries))
+	}
+}
+
+func TestRebuild_RegeneratesEntriesFromSourceDocuments(t *testing.T) {
+	repo := newFakeRepo()
+	cash := accountID(t, repo, "1100")
+	income := accountID(t, repo, "4100")
+	c := contribution.Contribution{ID: 1, CategoryID: 1, Amount: 500, Month: 1, Year: 2026, PaymentDate: date(1, 5), UserID: 1}
+	x := expense.Expense{ID: 2, CategoryID: 1, Description: "Jardinería", Amount: 120, Date: date(1, 8), UserID: 1}
+	tr := treasury.Transfer{ID: 3, FromAccountID: 1, ToAccountID: 2, Amount: 200, Date: date(1, 10), UserID: 1}
+	svc := ledger.NewService(repo, ledger.StandardAccounts, ledger.Sources{
+		Contributions: fakeContributions{c},
+		Expenses:      fakeExpenses{x},
+		Transfers:     fakeTransfers{tr},
+	})
+	bank, _ := svc.CreateAccount(ctx, "1120", "Bancos", ledger.AccountAsset, "", &cash, "102.01")
+	repo.financial[2] = &bank.ID
+
+	// The ledger missed the contribution's amount change and the expense and
+	// transfer events, and still holds the entry of a deleted contribution.
+	stale := c
+	stale.Amount = 450
+	svc.HandleContributionEvent(ctx, contribution.Event{Type: contribution.EventCreated, Contribution: stale})
+	deleted := contribution.Contribution{ID: 9, CategoryID: 1, Amount: 300, Month: 1, Year: 2026, PaymentDate: date(1, 6), UserID: 1}
+	svc.HandleContributionEvent(ctx, contribution.Event{Type: contribution.EventCreated, Contribution: deleted})
+	manual, err := svc.CreateManualEntry(ctx, 1, date(1, 31), "Ajuste", []ledger.Posting{
+		{AccountID: cash, Debit: 10},
+		{AccountID: income, Credit: 10},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	res, err := svc.Rebuild(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := ledger.RebuildResult{Contributions: 1, Expenses: 1, Transfers: 1, Removed: 1}
+	if *res != want {
+		t.Errorf("expected %+v, got %+v", want, *res)
+	}
+
+	bySource := map[ledger.Source][]ledger.Entry{}
+	for _, e := range repo.entries {
+		bySource[e.Source] = append(bySource[e.Source], e)
+	}
+	if got := bySource[ledger.SourceManual]; len(got) != 1 || got[0].ID != manual.ID {
+		t.Errorf("expected the manual entry kept, got %+v", got)
+	}
+	if got := bySource[ledger.SourceContribution]; len(got) != 1 || *got[0].SourceID != 1 || got[0].Postings[0].Debit != 500 {
+		t.Errorf("expected contribution 1 reposted at 500, got %+v", got)
+	}
+	if got := bySource[ledger.SourceExpense]; len(got) != 1 || *got[0].SourceID != 2 {
+		t.Errorf("expected an entry for expense 2, got %+v", got)
+	}
+	if got := bySource[ledger.SourceTransfer]; len(got) != 1 || *got[0].SourceID != 3 || got[0].Postings[0].AccountID != bank.ID {
+		t.Errorf("expected an entry for transfer 3, got %+v", got)
+	}
+
+	// A second run finds nothing left to remove.
+	res, err = svc.Rebuild(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if res.Removed != 0 || len(repo.entries) != 4 {
+		t.Errorf("expected a rebuild to be idempotent, got %+v with %d entries", *res, len(repo.entries))
+	}
+}
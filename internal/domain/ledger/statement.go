@@ -0,0 +1,88 @@
+package ledger
+
+import "time"
+
+// AccountTotal is a raw per-account aggregation row from the database.
+type AccountTotal struct {
+	AccountID int64
+	Debit     float64
+	Credit    float64
+}
+
+// AccountBalance is an account's movements and its balance on the side of
+// the account's nature (debit minus credit for debit-natured accounts).
+type AccountBalance struct {
+	AccountID   int64       `json:"account_id"`
+	AccountCode string      `json:"account_code"`
+	AccountName string      `json:"account_name"`
+	AccountType AccountType `json:"account_type"`
+	Nature      Nature      `json:"nature"`
+	SATCode     string      `json:"sat_code"`
+	Debit       float64     `json:"debit"`
+	Credit      float64     `json:"credit"`
+	Balance     float64     `json:"balance"`
+}
+
+// TrialBalance (balanza de comprobación) lists every account with movements up to AsOf.
+type TrialBalance struct {
+	AsOf        time.Time        `json:"as_of"`
+	Accounts    []AccountBalance `json:"accounts"`
+	TotalDebit  float64          `json:"total_debit"`
+	TotalCredit float64          `json:"total_credit"`
+	Balanced    bool             `json:"balanced"`
+}
+
+// BalanceSheet (estado de posición financiera) at AsOf. NetIncome is the
+// accumulated result of income and expense accounts not yet closed to equity;
+// it is included in TotalEquity.
+type BalanceSheet struct {
+	AsOf             time.Time        `json:"as_of"`
+	Assets           []AccountBalance `json:"assets"`
+	Liabilities      []AccountBalance `json:"liabilities"`
+	Equity           []AccountBalance `json:"equity"`
+	NetIncome        float64          `json:"net_income"`
+	TotalAssets      float64          `json:"total_assets"`
+	TotalLiabilities float64          `json:"total_liabilities"`
+	TotalEquity      float64          `json:"total_equity"`
+	Balanced         bool             `json:"balanced"`
+}
+
+// IncomeStatement (estado de resultados) for the period From..To inclusive.
+type IncomeStatement struct {
+	From          time.Time        `json:"from"`
+	To            time.Time        `json:"to"`
+	Income        []AccountBalance `json:"income"`
+	Expenses      []AccountBalance `json:"expenses"`
+	TotalIncome   float64          `json:"total_income"`
+	TotalExpenses float64          `json:"total_expenses"`
+	NetIncome     float64          `json:"net_income"`
+}
+
+// AccountNode is one account in the chart-of-accounts balance tree. Debit,
+// Credit and Balance include every descendant.
+type AccountNode struct {
+	AccountBalance
+	Level    int           `json:"level"`
+	Children []AccountNode `json:"children"`
+}
+
+// SATBalance totals the accounts reporting under one código agrupador.
+type SATBalance struct {
+	SATCode string  `json:"sat_code"`
+	Nature  Nature  `json:"nature"`
+	Debit   float64 `json:"debit"`
+	Credit  float64 `json:"credit"`
+	Balance float64 `json:"balance"`
+}
+
+// ChartBalance is the balance of the whole chart of accounts at AsOf, both
+// as the account hierarchy and grouped by SAT código agrupador for the
+// electronic accounting (balanza de comprobación) filing.
+type ChartBalance struct {
+	AsOf     time.Time     `json:"as_of"`
+	Accounts []AccountNode `json:"accounts"`
+	BySAT    []SATBalance  `json:"by_sat_code"`
+	// Unmapped lists leaf accounts with movements but no SAT code in their
+	// own or any ancestor account.
+	Unmapped []AccountBalance `json:"unmapped"`
+}
@@ -0,0 +1,59 @@
+package period
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrClosed         = errors.New("accounting period is closed")
+	ErrAlreadyClosed  = errors.New("accounting period is already closed")
+	ErrNotClosed      = errors.New("accounting period is not closed")
+	ErrInvalidYear    = errors.New("year must be >= 2000")
+	ErrInvalidMonth   = errors.New("month must be between 1 and 12, or 0 for the whole year")
+	ErrInvalidUserID  = errors.New("user ID must be positive")
+	ErrReasonRequired = errors.New("a reason is required to reopen a period")
+)
+
+// Period records the closing of a month, or of a whole fiscal year when
+// Month is 0. A closed period stays locked until an admin reopens it; the
+// row is kept afterwards as history.
+type Period struct {
+	ID           int64
+	Year         int
+	Month        int
+	ClosedBy     int64
+	ClosedAt     time.Time
+	ReopenedBy   *int64
+	ReopenedAt   *time.Time
+	ReopenReason string
+}
+
+// Locked reports whether the period is still closed.
+func (p *Period) Locked() bool {
+	return p.ReopenedAt == nil
+}
+
+// Covers reports whether date falls inside the period.
+func (p *Period) Covers(date time.Time) bool {
+	return date.Year() == p.Year && (p.Month == 0 || int(date.Month()) == p.Month)
+}
+
+// New creates a closed Period enforcing domain invariants.
+func New(userID int64, year, month int) (*Period, error) {
+	if userID <= 0 {
+		return nil, ErrInvalidUserID
+	}
+	if year < 2000 {
+		return nil, ErrInvalidYear
+	}
+	if month < 0 || month > 12 {
+		return nil, ErrInvalidMonth
+	}
+	return &Period{
+		Year:     year,
+		Month:    month,
+		ClosedBy: userID,
+		ClosedAt: time.Now(),
+	}, nil
+}
@@ -0,0 +1,127 @@
+package period
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/ivsanmendez/ControlDeContabilidad/internal/domain/user"
+)
+
+// Repository is the outbound port for period persistence.
+type Repository interface {
+	Save(ctx context.Context, p *Period) error
+	Update(ctx context.Context, p *Period) error
+	// FindByYear returns every close of the year, reopened ones included.
+	FindByYear(ctx context.Context, year int) ([]Period, error)
+	// FindLocked returns the periods of the year that are still closed.
+	FindLocked(ctx context.Context, year int) ([]Period, error)
+}
+
+// Service orchestrates period closing and reopening. It also implements the
+// guard contribution and expense services consult before any change.
+type Service struct {
+	repo  Repository
+	audit user.AuditLogger
+}
+
+func NewService(repo Repository, audit user.AuditLogger) *Service {
+	return &Service{repo: repo, audit: audit}
+}
+
+// Close locks a month, or the whole fiscal year when month is 0.
+func (s *Service) Close(ctx context.Context, callerID int64, year, month int, info user.AuditInfo) (*Period, error) {
+	p, err := New(callerID, year, month)
+	if err != nil {
+		return nil, err
+	}
+	if existing, err := s.findLocked(ctx, year, month); err != nil {
+		return nil, err
+	} else if existing != nil {
+		return nil, ErrAlreadyClosed
+	}
+
+	if err := s.repo.Save(ctx, p); err != nil {
+		return nil, err
+	}
+	s.logAudit(ctx, callerID, user.AuditPeriodClose, info, p, nil)
+	return p, nil
+}
+
+// Reopen unlocks a closed month or year. The reason is kept on the period
+// and in the audit log. Reopening a month does not unlock it while its
+// fiscal year remains closed.
+func (s *Service) Reopen(ctx context.Context, callerID int64, year, month int, reason string, info user.AuditInfo) (*Period, error) {
+	if reason == "" {
+		return nil, ErrReasonRequired
+	}
+	p, err := s.findLocked(ctx, year, month)
+	if err != nil {
+		return nil, err
+	}
+	if p == nil {
+		return nil, ErrNotClosed
+	}
+
+	now := time.Now()
+	p.ReopenedBy = &callerID
+	p.ReopenedAt = &now
+	p.ReopenReason = reason
+	if err := s.repo.Update(ctx, p); err != nil {
+		return nil, err
+	}
+	s.logAudit(ctx, callerID, user.AuditPeriodReopen, info, p, map[string]string{"reason": reason})
+	return p, nil
+}
+
+func (s *Service) ListPeriods(ctx context.Context, year int) ([]Period, error) {
+	if year < 2000 {
+		return nil, ErrInvalidYear
+	}
+	return s.repo.FindByYear(ctx, year)
+}
+
+// EnsureOpen returns ErrClosed when date falls in a closed month or year.
+func (s *Service) EnsureOpen(ctx context.Context, date time.Time) error {
+	locked, err := s.repo.FindLocked(ctx, date.Year())
+	if err != nil {
+		return err
+	}
+	for _, p := range locked {
+		if p.Covers(date) {
+			return fmt.Errorf("%w: %s", ErrClosed, date.Format("2006-01"))
+		}
+	}
+	return nil
+}
+
+func (s *Service) findLocked(ctx context.Context, year, month int) (*Period, error) {
+	locked, err := s.repo.FindLocked(ctx, year)
+	if err != nil {
+		return nil, err
+	}
+	for i := range locked {
+		if locked[i].Month == month {
+			return &locked[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// logAudit fires-and-forgets an audit entry. Errors are logged but never returned.
+func (s *Service) logAudit(ctx context.Context, userID int64, action user.AuditAction, info user.AuditInfo, p *Period, extra map[string]string) {
+	metadata := map[string]string{
+		"period_id": strconv.FormatInt(p.ID, 10),
+		"year":      strconv.Itoa(p.Year),
+		"month":     strconv.Itoa(p.Month),
+	}
+	for k, v := range extra {
+		metadata[k] = v
+	}
+	entry := user.NewAuditEntry(&userID, action, info, metadata)
+	if err := s.audit.Log(ctx, entry); err != nil {
+		log.Printf("audit log error: %v", err)
+	}
+}
@@ -0,0 +1,157 @@
+package period_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ivsanmendez/ControlDeContabilidad/internal/domain/period"
+	"github.com/ivsanmendez/ControlDeContabilidad/internal/domain/user"
+)
+
+// --- Fakes ---
+
+type fakeRepo struct {
+	periods []period.Period
+}
+
+func (r *fakeRepo) Save(_ context.Context, p *period.Period) error {
+	p.ID = int64(len(r.periods) + 1)
+	r.periods = append(r.periods, *p)
+	return nil
+}
+
+func (r *fakeRepo) Update(_ context.Context, p *period.Period) error {
+	r.periods[p.ID-1] = *p
+	return nil
+}
+
+func (r *fakeRepo) FindByYear(_ context.Context, year int) ([]period.Period, error) {
+	var result []period.Period
+	for _, p := range r.periods {
+		if p.Year == year {
+			result = append(result, p)
+		}
+	}
+	return result, nil
+}
+
+func (r *fakeRepo) FindLocked(_ context.Context, year int) ([]period.Period, error) {
+	var result []period.Period
+	for _, p := range r.periods {
+		if p.Year == year && p.Locked() {
+			result = append(result, p)
+		}
+	}
+	return result, nil
+}
+
+type fakeAudit struct {
+	entries []user.AuditEntry
+}
+
+func (a *fakeAudit) Log(_ context.Context, e user.AuditEntry) error {
+	a.entries = append(a.entries, e)
+	return nil
+}
+
+var (
+	ctx  = context.Background()
+	info = user.AuditInfo{IP: "127.0.0.1", UserAgent: "test"}
+)
+
+const adminID int64 = 1
+
+func date(m time.Month, d int) time.Time {
+	return time.Date(2026, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+// --- Tests ---
+
+func TestClose_LocksMonth(t *testing.T) {
+	audit := &fakeAudit{}
+	svc := period.NewService(&fakeRepo{}, audit)
+
+	if _, err := svc.Close(ctx, adminID, 2026, 3, info); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := svc.EnsureOpen(ctx, date(3, 31)); !errors.Is(err, period.ErrClosed) {
+		t.Fatalf("expected ErrClosed, got %v", err)
+	}
+	if err := svc.EnsureOpen(ctx, date(4, 1)); err != nil {
+		t.Fatalf("April should stay open, got %v", err)
+	}
+	if _, err := svc.Close(ctx, adminID, 2026, 3, info); !errors.Is(err, period.ErrAlreadyClosed) {
+		t.Fatalf("expected ErrAlreadyClosed, got %v", err)
+	}
+	if len(audit.entries) != 1 || audit.entries[0].Action != user.AuditPeriodClose {
+		t.Fatalf("expected one period_close audit entry, got %+v", audit.entries)
+	}
+}
+
+func TestClose_FiscalYearLocksEveryMonth(t *testing.T) {
+	svc := period.NewService(&fakeRepo{}, &fakeAudit{})
+
+	if _, err := svc.Close(ctx, adminID, 2026, 0, info); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, m := range []time.Month{time.January, time.July, time.December} {
+		if err := svc.EnsureOpen(ctx, date(m, 15)); !errors.Is(err, period.ErrClosed) {
+			t.Fatalf("%s: expected ErrClosed, got %v", m, err)
+		}
+	}
+	if err := svc.EnsureOpen(ctx, time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)); err != nil {
+		t.Fatalf("next year should stay open, got %v", err)
+	}
+}
+
+func TestReopen_RequiresReasonAndIsAudited(t *testing.T) {
+	repo := &fakeRepo{}
+	audit := &fakeAudit{}
+	svc := period.NewService(repo, audit)
+	svc.Close(ctx, adminID, 2026, 5, info)
+
+	if _, err := svc.Reopen(ctx, adminID, 2026, 5, "", info); !errors.Is(err, period.ErrReasonRequired) {
+		t.Fatalf("expected ErrReasonRequired, got %v", err)
+	}
+	if _, err := svc.Reopen(ctx, adminID, 2026, 6, "typo", info); !errors.Is(err, period.ErrNotClosed) {
+		t.Fatalf("expected ErrNotClosed, got %v", err)
+	}
+
+	p, err := svc.Reopen(ctx, adminID, 2026, 5, "Pago mal capturado", info)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if p.Locked() || p.ReopenReason != "Pago mal capturado" {
+		t.Fatalf("unexpected reopened period: %+v", p)
+	}
+	if err := svc.EnsureOpen(ctx, date(5, 10)); err != nil {
+		t.Fatalf("reopened month should accept changes, got %v", err)
+	}
+
+	last := audit.entries[len(audit.entries)-1]
+	if last.Action != user.AuditPeriodReopen || last.Metadata["reason"] != "Pago mal capturado" || last.Metadata["month"] != "5" {
+		t.Fatalf("unexpected reopen audit entry: %+v", last)
+	}
+
+	// The reopened close is kept as history and the month can be closed again.
+	if _, err := svc.Close(ctx, adminID, 2026, 5, info); err != nil {
+		t.Fatalf("re-close: %v", err)
+	}
+	history, _ := svc.ListPeriods(ctx, 2026)
+	if len(history) != 2 {
+		t.Fatalf("expected 2 periods in history, got %d", len(history))
+	}
+}
+
+func TestClose_Validation(t *testing.T) {
+	svc := period.NewService(&fakeRepo{}, &fakeAudit{})
+
+	if _, err := svc.Close(ctx, adminID, 1999, 1, info); !errors.Is(err, period.ErrInvalidYear) {
+		t.Fatalf("expected ErrInvalidYear, got %v", err)
+	}
+	if _, err := svc.Close(ctx, adminID, 2026, 13, info); !errors.Is(err, period.ErrInvalidMonth) {
+		t.Fatalf("expected ErrInvalidMonth, got %v", err)
+	}
+}
@@ -0,0 +1,152 @@
+package pettycash
+
+import (
+	"errors"
+	"math"
+	"time"
+)
+
+var (
+	ErrNotFound              = errors.New("petty cash fund not found")
+	ErrReplenishmentNotFound = errors.New("petty cash replenishment not found")
+	ErrEmptyName             = errors.New("fund name must not be empty")
+	ErrInvalidFloat          = errors.New("fund float amount must be positive")
+	ErrInvalidCustodian      = errors.New("custodian user ID must be positive")
+	ErrInvalidAccount        = errors.New("fund must be held in an active cash financial account")
+	ErrInvalidUserID         = errors.New("user ID must be positive")
+	ErrInvalidAmount         = errors.New("amount must be positive")
+	ErrInvalidCountedAmount  = errors.New("counted amount cannot be negative")
+	ErrFundInactive          = errors.New("petty cash fund is inactive")
+	ErrInsufficientFunds     = errors.New("charge exceeds the petty cash book balance")
+	ErrNoOpenCharges         = errors.New("no charges pending replenishment")
+	ErrNotPending            = errors.New("replenishment is not pending")
+	ErrForbidden             = errors.New("only the fund custodian or an admin can operate this fund")
+)
+
+// Fund is a petty-cash (caja chica) imprest fund: a fixed float held in cash
+// by a custodian. Small expenses are charged against it and replenishments
+// bring the cash on hand back up to the float.
+type Fund struct {
+	ID              int64
+	Name            string
+	CustodianUserID int64
+	FloatAmount     float64
+	// FinancialAccountID is the cash box the float is held in: charges are
+	// paid out of it and replenishments transfer money into it.
+	FinancialAccountID int64
+	IsActive           bool
+	UserID             int64
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
+}
+
+// FundStatus is a fund with its computed book balance.
+type FundStatus struct {
+	Fund
+	// OpenCharges is the total charged and not yet covered by a completed replenishment.
+	OpenCharges float64
+	// BookBalance is the cash that should be in the box: FloatAmount - OpenCharges.
+	BookBalance float64
+}
+
+// Charge links an expense paid out of the fund. Description, Amount and Date
+// are read from the underlying expense.
+type Charge struct {
+	ID              int64
+	FundID          int64
+	ExpenseID       int64
+	Description     string
+	Amount          float64
+	Date            time.Time
+	ReplenishmentID *int64
+	UserID          int64
+	CreatedAt       time.Time
+}
+
+type ReplenishmentStatus string
+
+const (
+	ReplenishmentRequested ReplenishmentStatus = "requested"
+	ReplenishmentCompleted ReplenishmentStatus = "completed"
+)
+
+// Replenishment reimburses the fund for a batch of charges so its cash on
+// hand returns to the float.
+type Replenishment struct {
+	ID          int64
+	FundID      int64
+	Amount      float64
+	ChargeCount int
+	Status      ReplenishmentStatus
+	RequestedBy int64
+	RequestedAt time.Time
+	CompletedBy *int64
+	CompletedAt *time.Time
+}
+
+// Reconciliation (arqueo) records a physical cash count against the book balance.
+type Reconciliation struct {
+	ID            int64
+	FundID        int64
+	CountedAmount float64
+	BookBalance   float64
+	Difference    float64
+	Notes         string
+	UserID        int64
+	CountedAt     time.Time
+}
+
+// NewFund creates a Fund enforcing domain invariants.
+func NewFund(userID int64, name string, custodianUserID int64, floatAmount float64, financialAccountID int64) (*Fund, error) {
+	if userID <= 0 {
+		return nil, ErrInvalidUserID
+	}
+	if name == "" {
+		return nil, ErrEmptyName
+	}
+	if custodianUserID <= 0 {
+		return nil, ErrInvalidCustodian
+	}
+	if floatAmount <= 0 {
+		return nil, ErrInvalidFloat
+	}
+	if financialAccountID <= 0 {
+		return nil, ErrInvalidAccount
+	}
+
+	now := time.Now()
+	return &Fund{
+		Name:               name,
+		CustodianUserID:    custodianUserID,
+		FloatAmount:        floatAmount,
+		FinancialAccountID: financialAccountID,
+		IsActive:           true,
+		UserID:             userID,
+		CreatedAt:          now,
+		UpdatedAt:          now,
+	}, nil
+}
+
+// NewReconciliation records a cash count; Difference is positive when there
+// is more cash than expected (sobrante) and negative when short (faltante).
+func NewReconciliation(userID, fundID int64, counted, bookBalance float64, notes string) (*Reconciliation, error) {
+	if userID <= 0 {
+		return nil, ErrInvalidUserID
+	}
+	if counted < 0 {
+		return nil, ErrInvalidCountedAmount
+	}
+	return &Reconciliation{
+		FundID:        fundID,
+		CountedAmount: counted,
+		BookBalance:   bookBalance,
+		Difference:    roundCents(counted - bookBalance),
+		Notes:         notes,
+		UserID:        userID,
+		CountedAt:     time.Now(),
+	}, nil
+}
+
+func roundCents(v float64) float64 {
+	return math.Round(v*100) / 100
+}
@@ -0,0 +1,331 @@
+package pettycash
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ivsanmendez/ControlDeContabilidad/internal/domain/expense"
+	"github.com/ivsanmendez/ControlDeContabilidad/internal/domain/treasury"
+	"github.com/ivsanmendez/ControlDeContabilidad/internal/domain/user"
+)
+
+// Repository is the outbound port for petty cash persistence.
+type Repository interface {
+	SaveFund(ctx context.Context, f *Fund) error
+	UpdateFund(ctx context.Context, f *Fund) error
+	FindFundByID(ctx context.Context, id int64) (*Fund, error)
+	FindAllFunds(ctx context.Context) ([]Fund, error)
+
+	// SaveCharge inserts e and the charge linking it to the fund in one
+	// transaction. It locks the fund and re-checks its book balance first,
+	// returning ErrInsufficientFunds so concurrent charges cannot overdraw it.
+	SaveCharge(ctx context.Context, c *Charge, e *expense.Expense) error
+	FindChargesByFund(ctx context.Context, fundID int64) ([]Charge, error)
+	// OpenChargesTotal sums charges not covered by a completed replenishment.
+	OpenChargesTotal(ctx context.Context, fundID int64) (float64, error)
+	// FindUnrequestedCharges returns charges not yet included in any replenishment.
+	FindUnrequestedCharges(ctx context.Context, fundID int64) ([]Charge, error)
+
+	// SaveReplenishment inserts r and assigns it to the given charges atomically.
+	SaveReplenishment(ctx context.Context, r *Replenishment, chargeIDs []int64) error
+	UpdateReplenishment(ctx context.Context, r *Replenishment) error
+	FindReplenishmentByID(ctx context.Context, id int64) (*Replenishment, error)
+	FindReplenishmentsByFund(ctx context.Context, fundID int64) ([]Replenishment, error)
+
+	SaveReconciliation(ctx context.Context, r *Reconciliation) error
+	FindReconciliationsByFund(ctx context.Context, fundID int64) ([]Reconciliation, error)
+}
+
+// ExpenseRecorder is the subset of expense use cases petty cash depends on.
+// Charges are booked as regular expenses so they appear in the monthly balance,
+// against the fund's financial account. The expense is persisted by save,
+// together with its charge.
+type ExpenseRecorder interface {
+	CreateExpenseWith(ctx context.Context, callerID int64, description string, amount float64, categoryID int64, supplierID *int64, financialAccountID int64, lines []expense.Line, date time.Time, save func(context.Context, *expense.Expense) error) (*expense.Expense, error)
+}
+
+// Treasury is the subset of financial account use cases petty cash depends
+// on. Completing a replenishment moves its amount from the default account
+// into the fund's cash box.
+type Treasury interface {
+	ListAccounts(ctx context.Context) ([]treasury.Account, error)
+	CreateTransfer(ctx context.Context, callerID, fromAccountID, toAccountID int64, amount float64, date time.Time, description string) (*treasury.Transfer, error)
+	DeleteTransfer(ctx context.Context, id int64) error
+}
+
+// Service orchestrates petty cash use cases.
+type Service struct {
+	repo     Repository
+	expenses ExpenseRecorder
+	treasury Treasury
+}
+
+func NewService(repo Repository, expenses ExpenseRecorder, treasury Treasury) *Service {
+	return &Service{repo: repo, expenses: expenses, treasury: treasury}
+}
+
+func (s *Service) CreateFund(ctx context.Context, callerID int64, name string, custodianUserID int64, floatAmount float64, financialAccountID int64) (*Fund, error) {
+	f, err := NewFund(callerID, name, custodianUserID, floatAmount, financialAccountID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.ensureCashAccount(ctx, financialAccountID); err != nil {
+		return nil, err
+	}
+	if err := s.repo.SaveFund(ctx, f); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+func (s *Service) ListFunds(ctx context.Context) ([]Fund, error) {
+	return s.repo.FindAllFunds(ctx)
+}
+
+// GetFundStatus returns the fund together with its current book balance.
+func (s *Service) GetFundStatus(ctx context.Context, id int64) (*FundStatus, error) {
+	f, err := s.repo.FindFundByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return s.status(ctx, f)
+}
+
+func (s *Service) UpdateFund(ctx context.Context, id int64, name string, custodianUserID int64, floatAmount float64, financialAccountID int64, isActive bool) (*Fund, error) {
+	f, err := s.repo.FindFundByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if name == "" {
+		return nil, ErrEmptyName
+	}
+	if custodianUserID <= 0 {
+		return nil, ErrInvalidCustodian
+	}
+	if floatAmount <= 0 {
+		return nil, ErrInvalidFloat
+	}
+	if err := s.ensureCashAccount(ctx, financialAccountID); err != nil {
+		return nil, err
+	}
+
+	f.Name = name
+	f.CustodianUserID = custodianUserID
+	f.FloatAmount = floatAmount
+	f.FinancialAccountID = financialAccountID
+	f.IsActive = isActive
+	f.UpdatedAt = time.Now()
+
+	if err := s.repo.UpdateFund(ctx, f); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// ChargeExpense pays a small expense out of the fund. The expense is created
+// through the expense service and stored atomically with its charge.
+func (s *Service) ChargeExpense(ctx context.Context, callerID int64, callerRole user.Role, fundID int64, description string, amount float64, categoryID int64, supplierID *int64, date time.Time) (*Charge, error) {
+	f, err := s.operableFund(ctx, callerID, callerRole, fundID)
+	if err != nil {
+		return nil, err
+	}
+	if amount <= 0 {
+		return nil, ErrInvalidAmount
+	}
+
+	st, err := s.status(ctx, f)
+	if err != nil {
+		return nil, err
+	}
+	if roundCents(amount) > roundCents(st.BookBalance) {
+		return nil, ErrInsufficientFunds
+	}
+
+	var c *Charge
+	_, err = s.expenses.CreateExpenseWith(ctx, callerID, description, amount, categoryID, supplierID, f.FinancialAccountID, nil, date,
+		func(ctx context.Context, e *expense.Expense) error {
+			c = &Charge{
+				FundID:      f.ID,
+				Description: e.Description,
+				Amount:      e.Amount,
+				Date:        e.Date,
+				UserID:      callerID,
+				CreatedAt:   time.Now(),
+			}
+			return s.repo.SaveCharge(ctx, c, e)
+		})
+	if err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func (s *Service) ListCharges(ctx context.Context, fundID int64) ([]Charge, error) {
+	return s.repo.FindChargesByFund(ctx, fundID)
+}
+
+// RequestReplenishment bundles every charge not yet requested into a new
+// replenishment for the amount spent.
+func (s *Service) RequestReplenishment(ctx context.Context, callerID int64, callerRole user.Role, fundID int64) (*Replenishment, error) {
+	f, err := s.operableFund(ctx, callerID, callerRole, fundID)
+	if err != nil {
+		return nil, err
+	}
+
+	charges, err := s.repo.FindUnrequestedCharges(ctx, f.ID)
+	if err != nil {
+		return nil, err
+	}
+	if len(charges) == 0 {
+		return nil, ErrNoOpenCharges
+	}
+
+	ids := make([]int64, len(charges))
+	var total float64
+	for i, c := range charges {
+		ids[i] = c.ID
+		total += c.Amount
+	}
+
+	r := &Replenishment{
+		FundID:      f.ID,
+		Amount:      roundCents(total),
+		ChargeCount: len(charges),
+		Status:      ReplenishmentRequested,
+		RequestedBy: callerID,
+		RequestedAt: time.Now(),
+	}
+	if err := s.repo.SaveReplenishment(ctx, r, ids); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// CompleteReplenishment records that the cash was handed to the custodian,
+// restoring the fund's book balance by the replenished amount. The money is
+// transferred from the default financial account into the fund's, unless the
+// fund is held in the default account itself.
+func (s *Service) CompleteReplenishment(ctx context.Context, callerID int64, id int64) (*Replenishment, error) {
+	r, err := s.repo.FindReplenishmentByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if r.Status != ReplenishmentRequested {
+		return nil, ErrNotPending
+	}
+	f, err := s.repo.FindFundByID(ctx, r.FundID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	transfer, err := s.transferReplenishment(ctx, callerID, f, r, now)
+	if err != nil {
+		return nil, err
+	}
+
+	r.Status = ReplenishmentCompleted
+	r.CompletedBy = &callerID
+	r.CompletedAt = &now
+
+	if err := s.repo.UpdateReplenishment(ctx, r); err != nil {
+		if transfer != nil {
+			_ = s.treasury.DeleteTransfer(ctx, transfer.ID)
+		}
+		return nil, err
+	}
+	return r, nil
+}
+
+func (s *Service) transferReplenishment(ctx context.Context, callerID int64, f *Fund, r *Replenishment, at time.Time) (*treasury.Transfer, error) {
+	accounts, err := s.treasury.ListAccounts(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, a := range accounts {
+		if !a.IsDefault {
+			continue
+		}
+		if a.ID == f.FinancialAccountID {
+			return nil, nil
+		}
+		return s.treasury.CreateTransfer(ctx, callerID, a.ID, f.FinancialAccountID, r.Amount, at,
+			fmt.Sprintf("Reposición de caja chica %s #%d", f.Name, r.ID))
+	}
+	return nil, treasury.ErrNotFound
+}
+
+func (s *Service) ListReplenishments(ctx context.Context, fundID int64) ([]Replenishment, error) {
+	return s.repo.FindReplenishmentsByFund(ctx, fundID)
+}
+
+// Reconcile records a cash count (arqueo) against the current book balance.
+func (s *Service) Reconcile(ctx context.Context, callerID int64, callerRole user.Role, fundID int64, counted float64, notes string) (*Reconciliation, error) {
+	f, err := s.operableFund(ctx, callerID, callerRole, fundID)
+	if err != nil {
+		return nil, err
+	}
+
+	st, err := s.status(ctx, f)
+	if err != nil {
+		return nil, err
+	}
+
+	rec, err := NewReconciliation(callerID, f.ID, counted, st.BookBalance, notes)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.SaveReconciliation(ctx, rec); err != nil {
+		return nil, err
+	}
+	return rec, nil
+}
+
+func (s *Service) ListReconciliations(ctx context.Context, fundID int64) ([]Reconciliation, error) {
+	return s.repo.FindReconciliationsByFund(ctx, fundID)
+}
+
+// ensureCashAccount checks that id is an active cash financial account.
+func (s *Service) ensureCashAccount(ctx context.Context, id int64) error {
+	if id <= 0 {
+		return ErrInvalidAccount
+	}
+	accounts, err := s.treasury.ListAccounts(ctx)
+	if err != nil {
+		return err
+	}
+	for _, a := range accounts {
+		if a.ID == id && a.IsActive && a.Kind == treasury.KindCash {
+			return nil
+		}
+	}
+	return ErrInvalidAccount
+}
+
+// operableFund loads an active fund the caller may operate.
+func (s *Service) operableFund(ctx context.Context, callerID int64, callerRole user.Role, fundID int64) (*Fund, error) {
+	f, err := s.repo.FindFundByID(ctx, fundID)
+	if err != nil {
+		return nil, err
+	}
+	if !f.IsActive {
+		return nil, ErrFundInactive
+	}
+	if callerRole != user.RoleAdmin && f.CustodianUserID != callerID {
+		return nil, ErrForbidden
+	}
+	return f, nil
+}
+
+func (s *Service) status(ctx context.Context, f *Fund) (*FundStatus, error) {
+	open, err := s.repo.OpenChargesTotal(ctx, f.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &FundStatus{
+		Fund:        *f,
+		OpenCharges: roundCents(open),
+		BookBalance: roundCents(f.FloatAmount - open),
+	}, nil
+}
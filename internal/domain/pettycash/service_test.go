@@ -0,0 +1,438 @@
+package pettycash_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ivsanmendez/ControlDeContabilidad/internal/domain/expense"
+	"github.com/ivsanmendez/ControlDeContabilidad/internal/domain/pettycash"
+	"github.com/ivsanmendez/ControlDeContabilidad/internal/domain/treasury"
+	"github.com/ivsanmendez/ControlDeContabilidad/internal/domain/user"
+)
+
+// --- Fakes ---
+
+type fakeRepo struct {
+	funds          map[int64]*pettycash.Fund
+	charges        []pettycash.Charge
+	replenishments map[int64]*pettycash.Replenishment
+	reconciliation []pettycash.Reconciliation
+	expenses       []expense.Expense
+	failCharge     error
+	nextID         int64
+}
+
+func newFakeRepo() *fakeRepo {
+	return &fakeRepo{
+		funds:          make(map[int64]*pettycash.Fund),
+		replenishments: make(map[int64]*pettycash.Replenishment),
+		nextID:         1,
+	}
+}
+
+func (r *fakeRepo) id() int64 {
+	id := r.nextID
+	r.nextID++
+	return id
+}
+
+func (r *fakeRepo) SaveFund(_ context.Context, f *pettycash.Fund) error {
+	f.ID = r.id()
+	cp := *f
+	r.funds[f.ID] = &cp
+	return nil
+}
+
+func (r *fakeRepo) UpdateFund(_ context.Context, f *pettycash.Fund) error {
+	cp := *f
+	r.funds[f.ID] = &cp
+	return nil
+}
+
+func (r *fakeRepo) FindFundByID(_ context.Context, id int64) (*pettycash.Fund, error) {
+	f, ok := r.funds[id]
+	if !ok {
+		return nil, pettycash.ErrNotFound
+	}
+	cp := *f
+	return &cp, nil
+}
+
+func (r *fakeRepo) FindAllFunds(_ context.Context) ([]pettycash.Fund, error) {
+	var result []pettycash.Fund
+	for _, f := range r.funds {
+		result = append(result, *f)
+	}
+	return result, nil
+}
+
+func (r *fakeRepo) SaveCharge(ctx context.Context, c *pettycash.Charge, e *expense.Expense) error {
+	if r.failCharge != nil {
+		return r.failCharge
+	}
+	open, _ := r.OpenChargesTotal(ctx, c.FundID)
+	if c.Amount > r.funds[c.FundID].FloatAmount-open {
+		return pettycash.ErrInsufficientFunds
+	}
+	e.ID = r.id()
+	r.expenses = append(r.expenses, *e)
+	c.ID = r.id()
+	c.ExpenseID = e.ID
+	r.charges = append(r.charges, *c)
+	return nil
+}
+
+func (r *fakeRepo) FindChargesByFund(_ context.Context, fundID int64) ([]pettycash.Charge, error) {
+	var result []pettycash.Charge
+	for _, c := range r.charges {
+		if c.FundID == fundID {
+			result = append(result, c)
+		}
+	}
+	return result, nil
+}
+
+func (r *fakeRepo) OpenChargesTotal(_ context.Context, fundID int64) (float64, error) {
+	var total float64
+	for _, c := range r.charges {
+		if c.FundID != fundID {
+			continue
+		}
+		if c.ReplenishmentID != nil && r.replenishments[*c.ReplenishmentID].Status == pettycash.ReplenishmentCompleted {
+			continue
+		}
+		total += c.Amount
+	}
+	return total, nil
+}
+
+func (r *fakeRepo) FindUnrequestedCharges(_ context.Context, fundID int64) ([]pettycash.Charge, error) {
+	var result []pettycash.Charge
+	for _, c := range r.charges {
+		if c.FundID == fundID && c.ReplenishmentID == nil {
+			result = append(result, c)
+		}
+	}
+	return result, nil
+}
+
+func (r *fakeRepo) SaveReplenishment(_ context.Context, rp *pettycash.Replenishment, chargeIDs []int64) error {
+	rp.ID = r.id()
+	cp := *rp
+	r.replenishments[rp.ID] = &cp
+	for _, id := range chargeIDs {
+		for i := range r.charges {
+			if r.charges[i].ID == id {
+				rid := rp.ID
+				r.charges[i].ReplenishmentID = &rid
+			}
+		}
+	}
+	return nil
+}
+
+func (r *fakeRepo) UpdateReplenishment(_ context.Context, rp *pettycash.Replenishment) error {
+	cp := *rp
+	r.replenishments[rp.ID] = &cp
+	return nil
+}
+
+func (r *fakeRepo) FindReplenishmentByID(_ context.Context, id int64) (*pettycash.Replenishment, error) {
+	rp, ok := r.replenishments[id]
+	if !ok {
+		return nil, pettycash.ErrReplenishmentNotFound
+	}
+	cp := *rp
+	return &cp, nil
+}
+
+func (r *fakeRepo) FindReplenishmentsByFund(_ context.Context, fundID int64) ([]pettycash.Replenishment, error) {
+	var result []pettycash.Replenishment
+	for _, rp := range r.replenishments {
+		if rp.FundID == fundID {
+			result = append(result, *rp)
+		}
+	}
+	return result, nil
+}
+
+func (r *fakeRepo) SaveReconciliation(_ context.Context, rec *pettycash.Reconciliation) error {
+	rec.ID = r.id()
+	r.reconciliation = append(r.reconciliation, *rec)
+	return nil
+}
+
+func (r *fakeRepo) FindReconciliationsByFund(_ context.Context, fundID int64) ([]pettycash.Reconciliation, error) {
+	return r.reconciliation, nil
+}
+
+// fakeExpenses records expenses created through the expense service port.
+type fakeExpenses struct {
+	created []expense.Expense
+}
+
+func (f *fakeExpenses) CreateExpenseWith(ctx context.Context, callerID int64, description string, amount float64, categoryID int64, supplierID *int64, financialAccountID int64, lines []expense.Line, date time.Time, save func(context.Context, *expense.Expense) error) (*expense.Expense, error) {
+	e, err := expense.New(callerID, description, amount, categoryID, supplierID, lines, date)
+	if err != nil {
+		return nil, err
+	}
+	e.FinancialAccountID = financialAccountID
+	if err := save(ctx, e); err != nil {
+		return nil, err
+	}
+	f.created = append(f.created, *e)
+	return e, nil
+}
+
+// fakeTreasury holds the default checking account (1), a cash box (2) and an
+// investment account (3).
+type fakeTreasury struct {
+	accounts  []treasury.Account
+	transfers []treasury.Transfer
+}
+
+func newFakeTreasury() *fakeTreasury {
+	return &fakeTreasury{accounts: []treasury.Account{
+		{ID: 1, Name: "Cuenta de cheques", Kind: treasury.KindChecking, IsDefault: true, IsActive: true},
+		{ID: cashBoxID, Name: "Caja chica", Kind: treasury.KindCash, IsActive: true},
+		{ID: 3, Name: "Inversión", Kind: treasury.KindInvestment, IsActive: true},
+	}}
+}
+
+func (f *fakeTreasury) ListAccounts(context.Context) ([]treasury.Account, error) {
+	return f.accounts, nil
+}
+
+func (f *fakeTreasury) CreateTransfer(_ context.Context, callerID, from, to int64, amount float64, date time.Time, description string) (*treasury.Transfer, error) {
+	t, err := treasury.NewTransfer(callerID, from, to, amount, date, description)
+	if err != nil {
+		return nil, err
+	}
+	t.ID = int64(len(f.transfers) + 1)
+	f.transfers = append(f.transfers, *t)
+	return t, nil
+}
+
+func (f *fakeTreasury) DeleteTransfer(_ context.Context, id int64) error {
+	kept := f.transfers[:0]
+	for _, t := range f.transfers {
+		if t.ID != id {
+			kept = append(kept, t)
+		}
+	}
+	f.transfers = kept
+	return nil
+}
+
+const (
+	adminID     int64 = 1
+	custodianID int64 = 2
+	otherID     int64 = 3
+
+	cashBoxID int64 = 2
+)
+
+var (
+	ctx      = context.Background()
+	testDate = time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)
+)
+
+func setup(t *testing.T) (*pettycash.Service, *fakeRepo, *fakeExpenses, *pettycash.Fund) {
+	t.Helper()
+	svc, repo, exp, _, f := setupWithTreasury(t)
+	return svc, repo, exp, f
+}
+
+func setupWithTreasury(t *testing.T) (*pettycash.Service, *fakeRepo, *fakeExpenses, *fakeTreasury, *pettycash.Fund) {
+	t.Helper()
+	repo := newFakeRepo()
+	exp := &fakeExpenses{}
+	tr := newFakeTreasury()
+	svc := pettycash.NewService(repo, exp, tr)
+	f, err := svc.CreateFund(ctx, adminID, "Caja chica", custodianID, 2000, cashBoxID)
+	if err != nil {
+		t.Fatalf("create fund: %v", err)
+	}
+	return svc, repo, exp, tr, f
+}
+
+// --- Tests ---
+
+func TestNewFund_Validation(t *testing.T) {
+	if _, err := pettycash.NewFund(1, "", 2, 100, cashBoxID); err != pettycash.ErrEmptyName {
+		t.Errorf("expected ErrEmptyName, got %v", err)
+	}
+	if _, err := pettycash.NewFund(1, "Caja", 0, 100, cashBoxID); err != pettycash.ErrInvalidCustodian {
+		t.Errorf("expected ErrInvalidCustodian, got %v", err)
+	}
+	if _, err := pettycash.NewFund(1, "Caja", 2, 0, cashBoxID); err != pettycash.ErrInvalidFloat {
+		t.Errorf("expected ErrInvalidFloat, got %v", err)
+	}
+	if _, err := pettycash.NewFund(1, "Caja", 2, 100, 0); err != pettycash.ErrInvalidAccount {
+		t.Errorf("expected ErrInvalidAccount, got %v", err)
+	}
+}
+
+func TestCreateFund_RequiresCashAccount(t *testing.T) {
+	svc, _, _, _ := setup(t)
+	for _, id := range []int64{1, 3, 99} {
+		if _, err := svc.CreateFund(ctx, adminID, "Otra caja", custodianID, 500, id); !errors.Is(err, pettycash.ErrInvalidAccount) {
+			t.Errorf("account %d: expected ErrInvalidAccount, got %v", id, err)
+		}
+	}
+}
+
+func TestChargeExpense_CreatesExpenseAndReducesBalance(t *testing.T) {
+	svc, _, exp, f := setup(t)
+
+	c, err := svc.ChargeExpense(ctx, custodianID, user.RoleUser, f.ID, "Garrafones", 350, 1, nil, testDate)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(exp.created) != 1 || c.ExpenseID != exp.created[0].ID {
+		t.Fatalf("expected charge linked to a new expense, got %+v / %+v", c, exp.created)
+	}
+	if exp.created[0].FinancialAccountID != cashBoxID {
+		t.Fatalf("expense paid from account %d, want the fund's cash box", exp.created[0].FinancialAccountID)
+	}
+
+	st, err := svc.GetFundStatus(ctx, f.ID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if st.BookBalance != 1650 || st.OpenCharges != 350 {
+		t.Fatalf("status = %+v, want book balance 1650 and open charges 350", st)
+	}
+}
+
+func TestChargeExpense_InsufficientFunds(t *testing.T) {
+	svc, _, exp, f := setup(t)
+
+	_, err := svc.ChargeExpense(ctx, custodianID, user.RoleUser, f.ID, "Bomba", 2500, 1, nil, testDate)
+	if !errors.Is(err, pettycash.ErrInsufficientFunds) {
+		t.Fatalf("expected ErrInsufficientFunds, got %v", err)
+	}
+	if len(exp.created) != 0 {
+		t.Fatal("no expense should be created when the fund cannot cover it")
+	}
+}
+
+func TestChargeExpense_FailedChargeLeavesNoExpense(t *testing.T) {
+	svc, repo, exp, f := setup(t)
+	repo.failCharge = errors.New("connection reset")
+
+	if _, err := svc.ChargeExpense(ctx, custodianID, user.RoleUser, f.ID, "Garrafones", 350, 1, nil, testDate); err == nil {
+		t.Fatal("expected the charge to fail")
+	}
+	if len(exp.created) != 0 || len(repo.expenses) != 0 {
+		t.Fatal("the expense must not outlive a failed charge")
+	}
+}
+
+func TestChargeExpense_OnlyCustodianOrAdmin(t *testing.T) {
+	svc, _, _, f := setup(t)
+
+	if _, err := svc.ChargeExpense(ctx, otherID, user.RoleUser, f.ID, "Focos", 80, 1, nil, testDate); !errors.Is(err, pettycash.ErrForbidden) {
+		t.Fatalf("expected ErrForbidden, got %v", err)
+	}
+	if _, err := svc.ChargeExpense(ctx, adminID, user.RoleAdmin, f.ID, "Focos", 80, 1, nil, testDate); err != nil {
+		t.Fatalf("admin should be able to charge, got %v", err)
+	}
+}
+
+func TestChargeExpense_InactiveFund(t *testing.T) {
+	svc, _, _, f := setup(t)
+	if _, err := svc.UpdateFund(ctx, f.ID, f.Name, custodianID, f.FloatAmount, f.FinancialAccountID, false); err != nil {
+		t.Fatalf("update fund: %v", err)
+	}
+
+	_, err := svc.ChargeExpense(ctx, custodianID, user.RoleUser, f.ID, "Focos", 80, 1, nil, testDate)
+	if !errors.Is(err, pettycash.ErrFundInactive) {
+		t.Fatalf("expected ErrFundInactive, got %v", err)
+	}
+}
+
+func TestReplenishmentCycle_RestoresFloat(t *testing.T) {
+	svc, _, _, tr, f := setupWithTreasury(t)
+	svc.ChargeExpense(ctx, custodianID, user.RoleUser, f.ID, "Garrafones", 350, 1, nil, testDate)
+	svc.ChargeExpense(ctx, custodianID, user.RoleUser, f.ID, "Focos", 120.50, 1, nil, testDate)
+
+	rp, err := svc.RequestReplenishment(ctx, custodianID, user.RoleUser, f.ID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if rp.Amount != 470.50 || rp.ChargeCount != 2 || rp.Status != pettycash.ReplenishmentRequested {
+		t.Fatalf("replenishment = %+v", rp)
+	}
+
+	// Requested but not completed: cash is still out of the box.
+	st, _ := svc.GetFundStatus(ctx, f.ID)
+	if st.BookBalance != 1529.50 {
+		t.Fatalf("book balance before completion = %v, want 1529.50", st.BookBalance)
+	}
+
+	if _, err := svc.RequestReplenishment(ctx, custodianID, user.RoleUser, f.ID); !errors.Is(err, pettycash.ErrNoOpenCharges) {
+		t.Fatalf("expected ErrNoOpenCharges on second request, got %v", err)
+	}
+
+	done, err := svc.CompleteReplenishment(ctx, adminID, rp.ID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if done.Status != pettycash.ReplenishmentCompleted || done.CompletedAt == nil {
+		t.Fatalf("replenishment not completed: %+v", done)
+	}
+	if len(tr.transfers) != 1 || tr.transfers[0].FromAccountID != 1 || tr.transfers[0].ToAccountID != cashBoxID || tr.transfers[0].Amount != 470.50 {
+		t.Fatalf("expected a transfer from the default account into the cash box, got %+v", tr.transfers)
+	}
+
+	st, _ = svc.GetFundStatus(ctx, f.ID)
+	if st.BookBalance != f.FloatAmount {
+		t.Fatalf("book balance after completion = %v, want float %v", st.BookBalance, f.FloatAmount)
+	}
+
+	if _, err := svc.CompleteReplenishment(ctx, adminID, rp.ID); !errors.Is(err, pettycash.ErrNotPending) {
+		t.Fatalf("expected ErrNotPending, got %v", err)
+	}
+}
+
+func TestCompleteReplenishment_FundOnDefaultAccount(t *testing.T) {
+	repo, tr := newFakeRepo(), newFakeTreasury()
+	tr.accounts[0].Kind = treasury.KindCash
+	svc := pettycash.NewService(repo, &fakeExpenses{}, tr)
+	f, err := svc.CreateFund(ctx, adminID, "Caja chica", custodianID, 2000, 1)
+	if err != nil {
+		t.Fatalf("create fund: %v", err)
+	}
+	svc.ChargeExpense(ctx, custodianID, user.RoleUser, f.ID, "Garrafones", 350, 1, nil, testDate)
+	rp, _ := svc.RequestReplenishment(ctx, custodianID, user.RoleUser, f.ID)
+
+	if _, err := svc.CompleteReplenishment(ctx, adminID, rp.ID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(tr.transfers) != 0 {
+		t.Fatalf("a fund held in the default account needs no transfer, got %+v", tr.transfers)
+	}
+}
+
+func TestReconcile_RecordsDifference(t *testing.T) {
+	svc, repo, _, f := setup(t)
+	svc.ChargeExpense(ctx, custodianID, user.RoleUser, f.ID, "Garrafones", 350, 1, nil, testDate)
+
+	rec, err := svc.Reconcile(ctx, custodianID, user.RoleUser, f.ID, 1640, "faltan 10 pesos")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if rec.BookBalance != 1650 || rec.Difference != -10 {
+		t.Fatalf("reconciliation = %+v, want book 1650 and difference -10", rec)
+	}
+	if len(repo.reconciliation) != 1 {
+		t.Fatal("reconciliation should be persisted")
+	}
+
+	if _, err := svc.Reconcile(ctx, custodianID, user.RoleUser, f.ID, -1, ""); !errors.Is(err, pettycash.ErrInvalidCountedAmount) {
+		t.Fatalf("expected ErrInvalidCountedAmount, got %v", err)
+	}
+}
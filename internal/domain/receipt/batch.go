@@ -0,0 +1,200 @@
+package receipt
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"time"
+)
+
+// MaxBatchSize bounds a single batch; a year-end run covers every house once.
+const MaxBatchSize = 500
+
+var (
+	ErrBatchEmpty             = errors.New("batch must include at least one contributor")
+	ErrBatchTooLarge          = errors.New("batch must not exceed 500 contributors")
+	ErrInvalidContributorID   = errors.New("contributor IDs must be positive")
+	ErrInvalidReceiptYear     = errors.New("receipt year must be positive")
+	ErrBatchScopeContribution = errors.New("a batch cannot be scoped to a single contribution")
+	ErrBatchRunning           = errors.New("batch is still running")
+)
+
+// BatchStatus is the lifecycle state of a batch.
+type BatchStatus string
+
+const (
+	BatchRunning   BatchStatus = "running"
+	BatchCompleted BatchStatus = "completed"
+)
+
+// ItemStatus is the outcome of one contributor within a batch.
+type ItemStatus string
+
+const (
+	ItemPending ItemStatus = "pending"
+	ItemSigned  ItemStatus = "signed"
+	ItemFailed  ItemStatus = "failed"
+	ItemSkipped ItemStatus = "skipped" // nothing within the scope to acknowledge
+)
+
+// BatchItem records what happened to one contributor.
+type BatchItem struct {
+	ContributorID int64      `json:"contributor_id"`
+	Status        ItemStatus `json:"status"`
+	Folio         string     `json:"folio,omitempty"`
+	Error         string     `json:"error,omitempty"`
+}
+
+// BatchProgress is a point-in-time snapshot of a batch.
+type BatchProgress struct {
+	ID         string      `json:"id"`
+	Status     BatchStatus `json:"status"`
+	UserID     int64       `json:"user_id"`
+	Year       int         `json:"year"`
+	Scope      Scope       `json:"scope"`
+	Total      int         `json:"total"`
+	Processed  int         `json:"processed"`
+	Signed     int         `json:"signed"`
+	Failed     int         `json:"failed"`
+	Skipped    int         `json:"skipped"`
+	Items      []BatchItem `json:"items"`
+	StartedAt  time.Time   `json:"started_at"`
+	FinishedAt *time.Time  `json:"finished_at,omitempty"`
+}
+
+// SignFunc issues the receipt of one contributor and returns its folio.
+// Returning ErrEmptyScope marks the contributor as skipped.
+type SignFunc func(ctx context.Context, contributorID int64) (string, error)
+
+// Batch signs one receipt per contributor under a single password entry.
+// A failure is recorded against its contributor and the batch moves on.
+// Batch is safe for concurrent use: Run updates it while readers poll Progress.
+type Batch struct {
+	mu         sync.Mutex
+	id         string
+	userID     int64
+	year       int
+	scope      Scope
+	items      []BatchItem
+	startedAt  time.Time
+	finishedAt time.Time
+}
+
+// NewBatch creates a Batch enforcing domain invariants. Duplicate contributor
+// IDs are signed once, in order of first appearance.
+func NewBatch(id string, userID int64, year int, scope Scope, contributorIDs []int64) (*Batch, error) {
+	if userID <= 0 {
+		return nil, ErrInvalidUserID
+	}
+	if year <= 0 {
+		return nil, ErrInvalidReceiptYear
+	}
+	if scope.Kind == ScopeContribution {
+		return nil, ErrBatchScopeContribution
+	}
+	var items []BatchItem
+	seen := make(map[int64]bool, len(contributorIDs))
+	for _, cid := range contributorIDs {
+		if cid <= 0 {
+			return nil, ErrInvalidContributorID
+		}
+		if seen[cid] {
+			continue
+		}
+		seen[cid] = true
+		items = append(items, BatchItem{ContributorID: cid, Status: ItemPending})
+	}
+	if len(items) == 0 {
+		return nil, ErrBatchEmpty
+	}
+	if len(items) > MaxBatchSize {
+		return nil, ErrBatchTooLarge
+	}
+	return &Batch{id: id, userID: userID, year: year, scope: scope, items: items, startedAt: time.Now().UTC()}, nil
+}
+
+// Run signs each contributor in turn. If ctx ends, the remaining contributors
+// are marked failed with its error.
+func (b *Batch) Run(ctx context.Context, sign SignFunc) {
+	for i := range b.items {
+		item := BatchItem{ContributorID: b.items[i].ContributorID}
+		if err := ctx.Err(); err != nil {
+			item.Status, item.Error = ItemFailed, err.Error()
+		} else if folio, err := sign(ctx, item.ContributorID); errors.Is(err, ErrEmptyScope) {
+			item.Status, item.Error = ItemSkipped, err.Error()
+		} else if err != nil {
+			item.Status, item.Error = ItemFailed, err.Error()
+		} else {
+			item.Status, item.Folio = ItemSigned, folio
+		}
+
+		b.mu.Lock()
+		b.items[i] = item
+		b.mu.Unlock()
+	}
+
+	b.mu.Lock()
+	b.finishedAt = time.Now().UTC()
+	b.mu.Unlock()
+}
+
+// Progress returns a snapshot that later updates do not affect.
+func (b *Batch) Progress() BatchProgress {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	p := BatchProgress{
+		ID:        b.id,
+		Status:    BatchRunning,
+		UserID:    b.userID,
+		Year:      b.year,
+		Scope:     b.scope,
+		Total:     len(b.items),
+		Items:     slices.Clone(b.items),
+		StartedAt: b.startedAt,
+	}
+	if !b.finishedAt.IsZero() {
+		p.Status = BatchCompleted
+		finished := b.finishedAt
+		p.FinishedAt = &finished
+	}
+	for _, it := range b.items {
+		switch it.Status {
+		case ItemSigned:
+			p.Signed++
+		case ItemFailed:
+			p.Failed++
+		case ItemSkipped:
+			p.Skipped++
+		}
+	}
+	p.Processed = p.Signed + p.Failed + p.Skipped
+	return p
+}
+
+// Folios lists the receipts the batch signed, in contributor order. They are
+// only available once the batch has completed.
+func (b *Batch) Folios() ([]string, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.finishedAt.IsZero() {
+		return nil, ErrBatchRunning
+	}
+	var folios []string
+	for _, it := range b.items {
+		if it.Status == ItemSigned {
+			folios = append(folios, it.Folio)
+		}
+	}
+	return folios, nil
+}
+
+// FinishedBefore reports whether the batch completed before t, so old batches
+// can be expired.
+func (b *Batch) FinishedBefore(t time.Time) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return !b.finishedAt.IsZero() && b.finishedAt.Before(t)
+}
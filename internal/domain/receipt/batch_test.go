@@ -0,0 +1,115 @@
+package receipt_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/ivsanmendez/ControlDeContabilidad/internal/domain/receipt"
+)
+
+func TestNewBatch(t *testing.T) {
+	contribution := int64(3)
+	tests := []struct {
+		name  string
+		user  int64
+		year  int
+		scope receipt.Scope
+		ids   []int64
+		want  error
+	}{
+		{"valid", 1, 2026, receipt.YearScope(), []int64{1, 2}, nil},
+		{"no user", 0, 2026, receipt.YearScope(), []int64{1}, receipt.ErrInvalidUserID},
+		{"no year", 1, 0, receipt.YearScope(), []int64{1}, receipt.ErrInvalidReceiptYear},
+		{"no contributors", 1, 2026, receipt.YearScope(), nil, receipt.ErrBatchEmpty},
+		{"bad contributor", 1, 2026, receipt.YearScope(), []int64{1, -2}, receipt.ErrInvalidContributorID},
+		{"contribution scope", 1, 2026, receipt.Scope{Kind: receipt.ScopeContribution, ContributionID: &contribution}, []int64{1}, receipt.ErrBatchScopeContribution},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := receipt.NewBatch("b1", tt.user, tt.year, tt.scope, tt.ids)
+			if !errors.Is(err, tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, err)
+			}
+		})
+	}
+
+	ids := make([]int64, receipt.MaxBatchSize+1)
+	for i := range ids {
+		ids[i] = int64(i + 1)
+	}
+	if _, err := receipt.NewBatch("b1", 1, 2026, receipt.YearScope(), ids); !errors.Is(err, receipt.ErrBatchTooLarge) {
+		t.Errorf("expected ErrBatchTooLarge, got %v", err)
+	}
+}
+
+func TestBatch_Run(t *testing.T) {
+	b, err := receipt.NewBatch("b1", 1, 2026, receipt.YearScope(), []int64{10, 20, 10, 30, 40})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	p := b.Progress()
+	if p.Status != receipt.BatchRunning || p.Total != 4 || p.Processed != 0 {
+		t.Errorf("unexpected initial progress: %+v", p)
+	}
+	if _, err := b.Folios(); !errors.Is(err, receipt.ErrBatchRunning) {
+		t.Errorf("expected ErrBatchRunning, got %v", err)
+	}
+
+	var signed []int64
+	b.Run(context.Background(), func(_ context.Context, id int64) (string, error) {
+		signed = append(signed, id)
+		switch id {
+		case 20:
+			return "", errors.New("contributor not found")
+		case 30:
+			return "", receipt.ErrEmptyScope
+		}
+		return fmt.Sprintf("REC-%d", id), nil
+	})
+
+	if len(signed) != 4 {
+		t.Errorf("expected every contributor attempted once, got %v", signed)
+	}
+	p = b.Progress()
+	if p.Status != receipt.BatchCompleted || p.FinishedAt == nil {
+		t.Errorf("expected completed batch, got %+v", p)
+	}
+	if p.Processed != 4 || p.Signed != 2 || p.Failed != 1 || p.Skipped != 1 {
+		t.Errorf("unexpected counts: %+v", p)
+	}
+	if it := p.Items[1]; it.Status != receipt.ItemFailed || it.Error != "contributor not found" {
+		t.Errorf("unexpected failed item: %+v", it)
+	}
+	if it := p.Items[2]; it.Status != receipt.ItemSkipped {
+		t.Errorf("expected skipped item, got %+v", it)
+	}
+
+	folios, err := b.Folios()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(folios) != 2 || folios[0] != "REC-10" || folios[1] != "REC-40" {
+		t.Errorf("unexpected folios: %v", folios)
+	}
+}
+
+func TestBatch_RunCancelled(t *testing.T) {
+	b, err := receipt.NewBatch("b1", 1, 2026, receipt.YearScope(), []int64{1, 2, 3})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	b.Run(ctx, func(_ context.Context, id int64) (string, error) {
+		cancel()
+		return fmt.Sprintf("REC-%d", id), nil
+	})
+
+	p := b.Progress()
+	if p.Signed != 1 || p.Failed != 2 || p.Status != receipt.BatchCompleted {
+		t.Errorf("expected remaining contributors failed after cancel, got %+v", p)
+	}
+}
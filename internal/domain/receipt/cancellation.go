@@ -0,0 +1,46 @@
+package receipt
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrAlreadyCancelled     = errors.New("receipt is already cancelled")
+	ErrReasonRequired       = errors.New("cancellation reason is required")
+	ErrPasswordRequired     = errors.New("certificate password is required")
+	ErrSigningUnavailable   = errors.New("signing certificate is not configured")
+	ErrInvalidPassword      = errors.New("invalid certificate password")
+	ErrInvalidUserID        = errors.New("user ID must be positive")
+	ErrReplacementNotFound  = errors.New("replacement receipt folio not found")
+	ErrReplacementIsSelf    = errors.New("a receipt cannot replace itself")
+	ErrReplacementMismatch  = errors.New("replacement receipt belongs to another contributor")
+	ErrReplacementCancelled = errors.New("replacement receipt is cancelled")
+)
+
+// Cancellation revokes an issued receipt. The receipt row is never deleted;
+// the cancellation is its own signed record so it can be verified like the
+// receipt it voids.
+type Cancellation struct {
+	ID               int64
+	ReceiptFolioID   int64
+	Folio            string
+	Reason           string
+	UserID           int64
+	ReplacementFolio string
+	CanonicalJSON    []byte
+	Signature        []byte
+	Certificate      []byte
+	CancelledAt      time.Time
+}
+
+// CancellationContent is the signed body of a cancellation. The digest ties
+// it to the exact receipt content it voids.
+type CancellationContent struct {
+	Folio            string    `json:"folio"`
+	ReceiptSHA256    string    `json:"receipt_sha256"`
+	Reason           string    `json:"reason"`
+	ReplacementFolio string    `json:"replacement_folio,omitempty"`
+	CancelledBy      int64     `json:"cancelled_by"`
+	CancelledAt      time.Time `json:"cancelled_at"`
+}
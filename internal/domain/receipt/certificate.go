@@ -0,0 +1,30 @@
+package receipt
+
+import (
+	"crypto/x509"
+	"fmt"
+)
+
+// CertificateSerial returns the serial number of a DER-encoded certificate.
+// SAT certificates store their 20-digit "número de certificado" as ASCII
+// bytes inside the serial, so those are returned as text; any other serial
+// is returned in hex.
+func CertificateSerial(der []byte) (string, error) {
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return "", fmt.Errorf("parse certificate: %w", err)
+	}
+
+	raw := cert.SerialNumber.Bytes()
+	printable := len(raw) > 0
+	for _, b := range raw {
+		if b < '0' || b > '9' {
+			printable = false
+			break
+		}
+	}
+	if printable {
+		return string(raw), nil
+	}
+	return fmt.Sprintf("%X", raw), nil
+}
@@ -0,0 +1,59 @@
+package receipt_test
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ivsanmendez/ControlDeContabilidad/internal/domain/receipt"
+)
+
+func selfSigned(t *testing.T, serial *big.Int) []byte {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: "Tesorería"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return der
+}
+
+func TestCertificateSerial_SATDigits(t *testing.T) {
+	sat := new(big.Int).SetBytes([]byte("30001000000500003416"))
+	got, err := receipt.CertificateSerial(selfSigned(t, sat))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != "30001000000500003416" {
+		t.Errorf("expected SAT certificate number, got %q", got)
+	}
+}
+
+func TestCertificateSerial_Hex(t *testing.T) {
+	got, err := receipt.CertificateSerial(selfSigned(t, big.NewInt(0xBEEF)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != "BEEF" {
+		t.Errorf("expected hex serial, got %q", got)
+	}
+}
+
+func TestCertificateSerial_Invalid(t *testing.T) {
+	if _, err := receipt.CertificateSerial([]byte("not a certificate")); err == nil {
+		t.Error("expected an error for invalid DER")
+	}
+}
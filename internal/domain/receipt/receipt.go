@@ -18,12 +18,14 @@ type ReceiptFolio struct {
 	UUIDSuffix     string
 	ContributorID  int64
 	ReceiptYear    int
+	Scope          Scope
 	SignerName     string
 	UserID         int64
 	CanonicalJSON  []byte
 	Signature      []byte
 	Certificate    []byte
 	SignedAt       time.Time
+	Cancellation   *Cancellation // nil while the receipt stands
 }
 
 // GenerateFolio formats a folio string: REC-YYYY-NNNNNN-XXXXXXXX
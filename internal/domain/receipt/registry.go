@@ -0,0 +1,67 @@
+package receipt
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	DefaultPageSize = 50
+	MaxPageSize     = 200
+)
+
+var (
+	ErrInvalidPage      = errors.New("limit must be between 1 and 200 and offset must not be negative")
+	ErrInvalidDateRange = errors.New("from must not be after to")
+)
+
+// Filter narrows the receipt registry. Zero values match everything; From and
+// To bound the signing date, both days inclusive.
+type Filter struct {
+	ContributorID int64
+	ReceiptYear   int
+	SignerName    string // case-insensitive substring
+	UserID        int64
+	From          time.Time
+	To            time.Time
+	Limit         int
+	Offset        int
+}
+
+// Summary is a registry row: enough to identify a receipt without its blobs.
+type Summary struct {
+	ID              int64      `json:"id"`
+	Folio           string     `json:"folio"`
+	ContributorID   int64      `json:"contributor_id"`
+	HouseNumber     string     `json:"house_number"`
+	ContributorName string     `json:"contributor_name"`
+	ReceiptYear     int        `json:"receipt_year"`
+	Scope           Scope      `json:"scope"`
+	SignerName      string     `json:"signer_name"`
+	UserID          int64      `json:"user_id"`
+	SignedAt        time.Time  `json:"signed_at"`
+	Cancelled       bool       `json:"cancelled"`
+	CancelledAt     *time.Time `json:"cancelled_at,omitempty"`
+}
+
+// Page is one page of the registry, newest receipts first.
+type Page struct {
+	Items  []Summary `json:"items"`
+	Total  int       `json:"total"`
+	Limit  int       `json:"limit"`
+	Offset int       `json:"offset"`
+}
+
+// normalize applies the default page size and checks the filter bounds.
+func (f *Filter) normalize() error {
+	if f.Limit == 0 {
+		f.Limit = DefaultPageSize
+	}
+	if f.Limit < 0 || f.Limit > MaxPageSize || f.Offset < 0 {
+		return ErrInvalidPage
+	}
+	if !f.From.IsZero() && !f.To.IsZero() && f.From.After(f.To) {
+		return ErrInvalidDateRange
+	}
+	return nil
+}
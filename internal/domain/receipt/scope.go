@@ -0,0 +1,78 @@
+package receipt
+
+import "errors"
+
+var (
+	ErrInvalidScopeKind          = errors.New("scope kind must be year, contribution, months, or category")
+	ErrScopeContributionRequired = errors.New("contribution scope requires a contribution ID")
+	ErrScopeCategoryRequired     = errors.New("category scope requires a category ID")
+	ErrInvalidScopeMonths        = errors.New("month range must be within 1-12 and from <= to")
+	ErrEmptyScope                = errors.New("no contributions fall within the receipt scope")
+)
+
+// ScopeKind selects which of a contributor's contributions in the receipt
+// year a receipt covers.
+type ScopeKind string
+
+const (
+	ScopeYear         ScopeKind = "year"
+	ScopeContribution ScopeKind = "contribution"
+	ScopeMonths       ScopeKind = "months"
+	ScopeCategory     ScopeKind = "category"
+)
+
+// Scope is part of the signed canonical JSON, so a receipt states exactly
+// which payments it acknowledges.
+type Scope struct {
+	Kind           ScopeKind `json:"kind"`
+	ContributionID *int64    `json:"contribution_id,omitempty"`
+	FromMonth      int       `json:"from_month,omitempty"`
+	ToMonth        int       `json:"to_month,omitempty"`
+	CategoryID     *int64    `json:"category_id,omitempty"`
+}
+
+// YearScope covers every contribution of the receipt year.
+func YearScope() Scope {
+	return Scope{Kind: ScopeYear}
+}
+
+// NewScope creates a Scope enforcing domain invariants. Only the fields
+// relevant to kind are kept; an empty kind means the whole year.
+func NewScope(kind ScopeKind, contributionID *int64, fromMonth, toMonth int, categoryID *int64) (Scope, error) {
+	switch kind {
+	case "", ScopeYear:
+		return YearScope(), nil
+	case ScopeContribution:
+		if contributionID == nil || *contributionID <= 0 {
+			return Scope{}, ErrScopeContributionRequired
+		}
+		return Scope{Kind: kind, ContributionID: contributionID}, nil
+	case ScopeMonths:
+		if fromMonth < 1 || toMonth > 12 || fromMonth > toMonth {
+			return Scope{}, ErrInvalidScopeMonths
+		}
+		return Scope{Kind: kind, FromMonth: fromMonth, ToMonth: toMonth}, nil
+	case ScopeCategory:
+		if categoryID == nil || *categoryID <= 0 {
+			return Scope{}, ErrScopeCategoryRequired
+		}
+		return Scope{Kind: kind, CategoryID: categoryID}, nil
+	default:
+		return Scope{}, ErrInvalidScopeKind
+	}
+}
+
+// Includes reports whether a contribution of the receipt year falls within
+// the scope.
+func (s Scope) Includes(contributionID, categoryID int64, month int) bool {
+	switch s.Kind {
+	case ScopeContribution:
+		return s.ContributionID != nil && *s.ContributionID == contributionID
+	case ScopeMonths:
+		return month >= s.FromMonth && month <= s.ToMonth
+	case ScopeCategory:
+		return s.CategoryID != nil && *s.CategoryID == categoryID
+	default:
+		return true
+	}
+}
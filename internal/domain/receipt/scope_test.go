@@ -0,0 +1,87 @@
+package receipt_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ivsanmendez/ControlDeContabilidad/internal/domain/receipt"
+)
+
+func ptr(v int64) *int64 { return &v }
+
+func TestNewScope_DefaultsToYear(t *testing.T) {
+	s, err := receipt.NewScope("", ptr(5), 1, 3, ptr(2))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if s != receipt.YearScope() {
+		t.Errorf("expected a bare year scope, got %+v", s)
+	}
+}
+
+func TestNewScope_KeepsOnlyRelevantFields(t *testing.T) {
+	s, err := receipt.NewScope(receipt.ScopeMonths, ptr(5), 2, 4, ptr(2))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if s.ContributionID != nil || s.CategoryID != nil || s.FromMonth != 2 || s.ToMonth != 4 {
+		t.Errorf("unexpected scope: %+v", s)
+	}
+}
+
+func TestNewScope_Invalid(t *testing.T) {
+	tests := []struct {
+		name           string
+		kind           receipt.ScopeKind
+		contributionID *int64
+		from, to       int
+		categoryID     *int64
+		want           error
+	}{
+		{"unknown kind", "week", nil, 0, 0, nil, receipt.ErrInvalidScopeKind},
+		{"contribution missing", receipt.ScopeContribution, nil, 0, 0, nil, receipt.ErrScopeContributionRequired},
+		{"contribution zero", receipt.ScopeContribution, ptr(0), 0, 0, nil, receipt.ErrScopeContributionRequired},
+		{"category missing", receipt.ScopeCategory, nil, 0, 0, nil, receipt.ErrScopeCategoryRequired},
+		{"months reversed", receipt.ScopeMonths, nil, 5, 2, nil, receipt.ErrInvalidScopeMonths},
+		{"months out of range", receipt.ScopeMonths, nil, 0, 13, nil, receipt.ErrInvalidScopeMonths},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := receipt.NewScope(tt.kind, tt.contributionID, tt.from, tt.to, tt.categoryID)
+			if !errors.Is(err, tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestScope_Includes(t *testing.T) {
+	months, _ := receipt.NewScope(receipt.ScopeMonths, nil, 3, 5, nil)
+	single, _ := receipt.NewScope(receipt.ScopeContribution, ptr(42), 0, 0, nil)
+	category, _ := receipt.NewScope(receipt.ScopeCategory, nil, 0, 0, ptr(7))
+
+	tests := []struct {
+		name  string
+		scope receipt.Scope
+		id    int64
+		cat   int64
+		month int
+		want  bool
+	}{
+		{"year covers all", receipt.YearScope(), 1, 1, 12, true},
+		{"months lower bound", months, 1, 1, 3, true},
+		{"months upper bound", months, 1, 1, 5, true},
+		{"months outside", months, 1, 1, 6, false},
+		{"single match", single, 42, 1, 1, true},
+		{"single other", single, 43, 1, 1, false},
+		{"category match", category, 1, 7, 1, true},
+		{"category other", category, 1, 8, 1, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.scope.Includes(tt.id, tt.cat, tt.month); got != tt.want {
+				t.Errorf("Includes(%d, %d, %d) = %v, want %v", tt.id, tt.cat, tt.month, got, tt.want)
+			}
+		})
+	}
+}
@@ -1,21 +1,49 @@
 package receipt
 
-import "context"
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/ivsanmendez/ControlDeContabilidad/internal/domain/user"
+)
 
 // Repository is the outbound port for receipt folio persistence.
 type Repository interface {
 	NextSequence(ctx context.Context, year int) (int, error)
 	Save(ctx context.Context, rf *ReceiptFolio) error
+	// FindByFolio loads the receipt together with its cancellation, if any.
 	FindByFolio(ctx context.Context, folio string) (*ReceiptFolio, error)
+	// SaveCancellation returns ErrAlreadyCancelled if the receipt already has one.
+	SaveCancellation(ctx context.Context, c *Cancellation) error
+	// List returns the filtered page and the number of matches across all pages.
+	List(ctx context.Context, f Filter) ([]Summary, int, error)
+}
+
+// Signer signs cancellation records with the canceller's SAT certificate.
+// Sign fails with an error wrapping ErrInvalidPassword when password does
+// not decrypt the private key.
+type Signer interface {
+	Sign(data []byte, password string) ([]byte, error)
+	Certificate() []byte
+	Available() bool
 }
 
 // Service implements receipt folio use cases.
 type Service struct {
-	repo Repository
+	repo  Repository
+	audit user.AuditLogger
+	now   func() time.Time
 }
 
-func NewService(repo Repository) *Service {
-	return &Service{repo: repo}
+func NewService(repo Repository, audit user.AuditLogger) *Service {
+	return &Service{repo: repo, audit: audit, now: time.Now}
 }
 
 // GenerateNewFolio atomically obtains the next sequence for the given year,
@@ -44,3 +72,137 @@ func (s *Service) SaveFolio(ctx context.Context, rf *ReceiptFolio) error {
 func (s *Service) VerifyFolio(ctx context.Context, folio string) (*ReceiptFolio, error) {
 	return s.repo.FindByFolio(ctx, folio)
 }
+
+// VerifySignature looks up a receipt folio and checks its seal against the
+// certificate stored with it.
+func (s *Service) VerifySignature(ctx context.Context, folio string) (*ReceiptFolio, *Verdict, error) {
+	rf, err := s.repo.FindByFolio(ctx, folio)
+	if err != nil {
+		return nil, nil, err
+	}
+	v := Verify(rf)
+	return rf, &v, nil
+}
+
+// ListFolios searches the registry of issued receipts, cancelled ones included.
+func (s *Service) ListFolios(ctx context.Context, f Filter) (*Page, error) {
+	if err := f.normalize(); err != nil {
+		return nil, err
+	}
+	items, total, err := s.repo.List(ctx, f)
+	if err != nil {
+		return nil, err
+	}
+	if items == nil {
+		items = []Summary{}
+	}
+	return &Page{Items: items, Total: total, Limit: f.Limit, Offset: f.Offset}, nil
+}
+
+// Cancel voids an issued receipt with a signed cancellation record, optionally
+// pointing to the receipt that replaces it. The original receipt is kept.
+// The record is signed with signer, the certificate the caller chose.
+func (s *Service) Cancel(ctx context.Context, signer Signer, callerID int64, folio, reason, replacementFolio, password string, info user.AuditInfo) (*Cancellation, error) {
+	if callerID <= 0 {
+		return nil, ErrInvalidUserID
+	}
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return nil, ErrReasonRequired
+	}
+	if password == "" {
+		return nil, ErrPasswordRequired
+	}
+	if !signer.Available() {
+		return nil, ErrSigningUnavailable
+	}
+
+	rf, err := s.repo.FindByFolio(ctx, folio)
+	if err != nil {
+		return nil, err
+	}
+	if rf.Cancellation != nil {
+		return nil, ErrAlreadyCancelled
+	}
+	replacementFolio = strings.TrimSpace(replacementFolio)
+	if replacementFolio != "" {
+		if err := s.checkReplacement(ctx, rf, replacementFolio); err != nil {
+			return nil, err
+		}
+	}
+
+	now := s.now().UTC()
+	digest := sha256.Sum256(rf.CanonicalJSON)
+	canonical, err := json.Marshal(CancellationContent{
+		Folio:            rf.Folio,
+		ReceiptSHA256:    hex.EncodeToString(digest[:]),
+		Reason:           reason,
+		ReplacementFolio: replacementFolio,
+		CancelledBy:      callerID,
+		CancelledAt:      now,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("serialize receipt cancellation: %w", err)
+	}
+
+	sig, err := signer.Sign(canonical, password)
+	if err != nil {
+		if errors.Is(err, ErrInvalidPassword) {
+			return nil, ErrInvalidPassword
+		}
+		return nil, fmt.Errorf("sign receipt cancellation: %w", err)
+	}
+
+	c := &Cancellation{
+		ReceiptFolioID:   rf.ID,
+		Folio:            rf.Folio,
+		Reason:           reason,
+		UserID:           callerID,
+		ReplacementFolio: replacementFolio,
+		CanonicalJSON:    canonical,
+		Signature:        sig,
+		Certificate:      signer.Certificate(),
+		CancelledAt:      now,
+	}
+	if err := s.repo.SaveCancellation(ctx, c); err != nil {
+		return nil, err
+	}
+	s.logAudit(ctx, callerID, info, c)
+	return c, nil
+}
+
+// checkReplacement accepts only a standing receipt of the same contributor.
+func (s *Service) checkReplacement(ctx context.Context, rf *ReceiptFolio, replacementFolio string) error {
+	if replacementFolio == rf.Folio {
+		return ErrReplacementIsSelf
+	}
+	repl, err := s.repo.FindByFolio(ctx, replacementFolio)
+	if errors.Is(err, ErrNotFound) {
+		return ErrReplacementNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if repl.ContributorID != rf.ContributorID {
+		return ErrReplacementMismatch
+	}
+	if repl.Cancellation != nil {
+		return ErrReplacementCancelled
+	}
+	return nil
+}
+
+// logAudit fires-and-forgets an audit entry. Errors are logged but never returned.
+func (s *Service) logAudit(ctx context.Context, userID int64, info user.AuditInfo, c *Cancellation) {
+	metadata := map[string]string{
+		"folio":  c.Folio,
+		"reason": c.Reason,
+	}
+	if c.ReplacementFolio != "" {
+		metadata["replacement_folio"] = c.ReplacementFolio
+	}
+	entry := user.NewAuditEntry(&userID, user.AuditReceiptCancel, info, metadata)
+	if err := s.audit.Log(ctx, entry); err != nil {
+		log.Printf("audit log error: %v", err)
+	}
+}
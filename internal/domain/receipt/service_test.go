@@ -0,0 +1,264 @@
+package receipt_test
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/ivsanmendez/ControlDeContabilidad/internal/domain/receipt"
+	"github.com/ivsanmendez/ControlDeContabilidad/internal/domain/user"
+)
+
+// --- Fakes ---
+
+type fakeRepo struct {
+	folios        map[string]*receipt.ReceiptFolio
+	cancellations []receipt.Cancellation
+	lastFilter    receipt.Filter
+}
+
+func newFakeRepo(folios ...*receipt.ReceiptFolio) *fakeRepo {
+	r := &fakeRepo{folios: map[string]*receipt.ReceiptFolio{}}
+	for i, rf := range folios {
+		rf.ID = int64(i + 1)
+		r.folios[rf.Folio] = rf
+	}
+	return r
+}
+
+func (f *fakeRepo) NextSequence(_ context.Context, _ int) (int, error) { return 1, nil }
+
+func (f *fakeRepo) Save(_ context.Context, rf *receipt.ReceiptFolio) error {
+	f.folios[rf.Folio] = rf
+	return nil
+}
+
+func (f *fakeRepo) FindByFolio(_ context.Context, folio string) (*receipt.ReceiptFolio, error) {
+	rf, ok := f.folios[folio]
+	if !ok {
+		return nil, receipt.ErrNotFound
+	}
+	return rf, nil
+}
+
+func (f *fakeRepo) SaveCancellation(_ context.Context, c *receipt.Cancellation) error {
+	rf := f.folios[c.Folio]
+	if rf.Cancellation != nil {
+		return receipt.ErrAlreadyCancelled
+	}
+	c.ID = int64(len(f.cancellations) + 1)
+	f.cancellations = append(f.cancellations, *c)
+	rf.Cancellation = c
+	return nil
+}
+
+func (f *fakeRepo) List(_ context.Context, filter receipt.Filter) ([]receipt.Summary, int, error) {
+	f.lastFilter = filter
+	var all []receipt.Summary
+	for _, rf := range f.folios {
+		if filter.ContributorID == 0 || rf.ContributorID == filter.ContributorID {
+			all = append(all, receipt.Summary{ID: rf.ID, Folio: rf.Folio, ContributorID: rf.ContributorID})
+		}
+	}
+	end := min(filter.Offset+filter.Limit, len(all))
+	if filter.Offset >= end {
+		return nil, len(all), nil
+	}
+	return all[filter.Offset:end], len(all), nil
+}
+
+type fakeSigner struct {
+	key  *rsa.PrivateKey
+	cert []byte
+}
+
+func newFakeSigner(t *testing.T) *fakeSigner {
+	key, cert := keyPair(t, signedAt.AddDate(-1, 0, 0), signedAt.AddDate(2, 0, 0))
+	return &fakeSigner{key: key, cert: cert}
+}
+
+func (s *fakeSigner) Sign(data []byte, password string) ([]byte, error) {
+	if password != "secret" {
+		return nil, fmt.Errorf("certsigner: decrypt key: %w", receipt.ErrInvalidPassword)
+	}
+	digest := sha256.Sum256(data)
+	return rsa.SignPKCS1v15(nil, s.key, crypto.SHA256, digest[:])
+}
+
+func (s *fakeSigner) Certificate() []byte { return s.cert }
+func (s *fakeSigner) Available() bool     { return true }
+
+type fakeAudit struct {
+	entries []user.AuditEntry
+}
+
+func (a *fakeAudit) Log(_ context.Context, e user.AuditEntry) error {
+	a.entries = append(a.entries, e)
+	return nil
+}
+
+var (
+	ctx  = context.Background()
+	info = user.AuditInfo{IP: "127.0.0.1", UserAgent: "test"}
+)
+
+// --- Tests ---
+
+func TestVerifySignature(t *testing.T) {
+	rf := validFolio(t)
+	svc := receipt.NewService(newFakeRepo(rf), &fakeAudit{})
+
+	got, v, err := svc.VerifySignature(ctx, rf.Folio)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != rf || !v.Valid {
+		t.Errorf("expected the stored folio with a valid verdict, got %+v", v)
+	}
+
+	if _, _, err := svc.VerifySignature(ctx, "REC-0000-000000-00000000"); !errors.Is(err, receipt.ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestCancel(t *testing.T) {
+	rf := validFolio(t)
+	rf.ContributorID = 9
+	repl := validFolio(t)
+	repl.Folio = "REC-2026-000002-BEEF0001"
+	repl.ContributorID = 9
+	audit := &fakeAudit{}
+	signer := newFakeSigner(t)
+	svc := receipt.NewService(newFakeRepo(rf, repl), audit)
+
+	c, err := svc.Cancel(ctx, signer, 1, rf.Folio, "  wrong amount  ", repl.Folio, "secret", info)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if c.Reason != "wrong amount" || c.ReplacementFolio != repl.Folio || c.ReceiptFolioID != rf.ID {
+		t.Errorf("unexpected cancellation: %+v", c)
+	}
+	if !bytes.Equal(c.Certificate, signer.cert) {
+		t.Error("expected the cancellation to carry the chosen signer's certificate")
+	}
+
+	var content receipt.CancellationContent
+	if err := json.Unmarshal(c.CanonicalJSON, &content); err != nil {
+		t.Fatalf("cancellation content: %v", err)
+	}
+	digest := sha256.Sum256(rf.CanonicalJSON)
+	if content.Folio != rf.Folio || content.CancelledBy != 1 || content.ReceiptSHA256 != hex.EncodeToString(digest[:]) {
+		t.Errorf("unexpected signed content: %+v", content)
+	}
+
+	v := receipt.Verify(rf)
+	if !v.Cancelled || v.Valid {
+		t.Errorf("expected the receipt to verify as cancelled, got %+v", v)
+	}
+
+	if len(audit.entries) != 1 || audit.entries[0].Action != user.AuditReceiptCancel {
+		t.Fatalf("expected one receipt_cancel audit entry, got %+v", audit.entries)
+	}
+	if audit.entries[0].Metadata["replacement_folio"] != repl.Folio {
+		t.Errorf("expected replacement in audit metadata, got %v", audit.entries[0].Metadata)
+	}
+
+	if _, err := svc.Cancel(ctx, signer, 1, rf.Folio, "again", "", "secret", info); !errors.Is(err, receipt.ErrAlreadyCancelled) {
+		t.Errorf("expected ErrAlreadyCancelled, got %v", err)
+	}
+	if _, err := svc.Cancel(ctx, signer, 1, repl.Folio, "undo", rf.Folio, "secret", info); !errors.Is(err, receipt.ErrReplacementCancelled) {
+		t.Errorf("expected ErrReplacementCancelled, got %v", err)
+	}
+}
+
+func TestCancel_Invalid(t *testing.T) {
+	rf := validFolio(t)
+	rf.ContributorID = 9
+	other := validFolio(t)
+	other.Folio = "REC-2026-000003-CAFE0001"
+	other.ContributorID = 10
+	signer := newFakeSigner(t)
+
+	tests := []struct {
+		name        string
+		folio       string
+		reason      string
+		replacement string
+		password    string
+		want        error
+	}{
+		{"missing reason", rf.Folio, " ", "", "secret", receipt.ErrReasonRequired},
+		{"missing password", rf.Folio, "duplicate", "", "", receipt.ErrPasswordRequired},
+		{"wrong password", rf.Folio, "duplicate", "", "nope", receipt.ErrInvalidPassword},
+		{"unknown folio", "REC-0000-000000-00000000", "duplicate", "", "secret", receipt.ErrNotFound},
+		{"replaces itself", rf.Folio, "duplicate", rf.Folio, "secret", receipt.ErrReplacementIsSelf},
+		{"unknown replacement", rf.Folio, "duplicate", "REC-0000-000000-00000000", "secret", receipt.ErrReplacementNotFound},
+		{"other contributor", rf.Folio, "duplicate", other.Folio, "secret", receipt.ErrReplacementMismatch},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			audit := &fakeAudit{}
+			svc := receipt.NewService(newFakeRepo(rf, other), audit)
+			if _, err := svc.Cancel(ctx, signer, 1, tt.folio, tt.reason, tt.replacement, tt.password, info); !errors.Is(err, tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, err)
+			}
+			if rf.Cancellation != nil || len(audit.entries) != 0 {
+				t.Error("a rejected cancellation must not be stored or audited")
+			}
+		})
+	}
+}
+
+func TestListFolios(t *testing.T) {
+	a, b := validFolio(t), validFolio(t)
+	b.Folio = "REC-2026-000002-BEEF0001"
+	a.ContributorID, b.ContributorID = 9, 9
+	repo := newFakeRepo(a, b)
+	svc := receipt.NewService(repo, &fakeAudit{})
+
+	page, err := svc.ListFolios(ctx, receipt.Filter{ContributorID: 9})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if page.Total != 2 || len(page.Items) != 2 || page.Limit != receipt.DefaultPageSize {
+		t.Errorf("unexpected page: %+v", page)
+	}
+
+	page, err = svc.ListFolios(ctx, receipt.Filter{ContributorID: 9, Limit: 1, Offset: 5})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if page.Total != 2 || page.Items == nil || len(page.Items) != 0 {
+		t.Errorf("expected an empty, non-nil page past the end, got %+v", page)
+	}
+}
+
+func TestListFolios_Invalid(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2026, 3, d, 0, 0, 0, 0, time.UTC) }
+	tests := []struct {
+		name   string
+		filter receipt.Filter
+		want   error
+	}{
+		{"negative limit", receipt.Filter{Limit: -1}, receipt.ErrInvalidPage},
+		{"limit too large", receipt.Filter{Limit: receipt.MaxPageSize + 1}, receipt.ErrInvalidPage},
+		{"negative offset", receipt.Filter{Offset: -1}, receipt.ErrInvalidPage},
+		{"reversed dates", receipt.Filter{From: day(10), To: day(9)}, receipt.ErrInvalidDateRange},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := receipt.NewService(newFakeRepo(), &fakeAudit{})
+			if _, err := svc.ListFolios(ctx, tt.filter); !errors.Is(err, tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,124 @@
+package receipt
+
+import (
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"time"
+)
+
+// Problem identifies one reason a stored receipt fails verification.
+type Problem string
+
+const (
+	ProblemMalformedCertificate   Problem = "malformed_certificate"
+	ProblemUnsupportedKey         Problem = "unsupported_key"
+	ProblemSignatureMismatch      Problem = "signature_mismatch"
+	ProblemCertificateNotYetValid Problem = "certificate_not_yet_valid"
+	ProblemCertificateExpired     Problem = "certificate_expired"
+	ProblemCancelled              Problem = "cancelled"
+	// The cancellation record's own seal does not check out.
+	ProblemCancellationMismatch Problem = "cancellation_signature_mismatch"
+)
+
+// Verdict is the outcome of checking a receipt's seal: the signature over the
+// canonical JSON and the certificate's validity window at signing time. A
+// cancelled receipt is never valid, whatever its seal.
+type Verdict struct {
+	Valid             bool      `json:"valid"`
+	SignatureValid    bool      `json:"signature_valid"`
+	CertificateValid  bool      `json:"certificate_valid"`
+	CertificateSerial string    `json:"certificate_serial,omitempty"`
+	CertificateHolder string    `json:"certificate_holder,omitempty"`
+	NotBefore         time.Time `json:"not_before,omitzero"`
+	NotAfter          time.Time `json:"not_after,omitzero"`
+	SignedAt          time.Time `json:"signed_at"`
+	Problems          []Problem `json:"problems"`
+
+	Cancelled          bool       `json:"cancelled"`
+	CancelledAt        *time.Time `json:"cancelled_at,omitempty"`
+	CancellationReason string     `json:"cancellation_reason,omitempty"`
+	ReplacementFolio   string     `json:"replacement_folio,omitempty"`
+}
+
+// Verify checks that Signature is an RSA PKCS#1 v1.5 SHA-256 signature of
+// CanonicalJSON made with the key of the stored Certificate, and that the
+// certificate was within its validity window at SignedAt. Expiry after
+// signing does not invalidate the receipt.
+func Verify(rf *ReceiptFolio) Verdict {
+	v := verifySeal(rf.Certificate, rf.CanonicalJSON, rf.Signature, rf.SignedAt)
+	if c := rf.Cancellation; c != nil {
+		v.Cancelled = true
+		v.CancelledAt = &c.CancelledAt
+		v.CancellationReason = c.Reason
+		v.ReplacementFolio = c.ReplacementFolio
+		v.Problems = append(v.Problems, ProblemCancelled)
+		if !signedBy(c.Certificate, c.CanonicalJSON, c.Signature) {
+			v.Problems = append(v.Problems, ProblemCancellationMismatch)
+		}
+	}
+	v.Valid = len(v.Problems) == 0
+	return v
+}
+
+// VerifySeal checks a seal on its own: that sig is an RSA PKCS#1 v1.5
+// SHA-256 signature of content under the key of the DER certificate, and
+// that the certificate was valid at signedAt. Other signed documents, such
+// as annual reports, share the receipts' seal and are checked with it.
+func VerifySeal(certDER, content, sig []byte, signedAt time.Time) Verdict {
+	v := verifySeal(certDER, content, sig, signedAt)
+	v.Valid = len(v.Problems) == 0
+	return v
+}
+
+func verifySeal(certDER, content, sig []byte, signedAt time.Time) Verdict {
+	v := Verdict{SignedAt: signedAt, Problems: []Problem{}}
+
+	cert, err := x509.ParseCertificate(certDER)
+	if err != nil {
+		v.Problems = append(v.Problems, ProblemMalformedCertificate)
+		return v
+	}
+	v.CertificateSerial, _ = CertificateSerial(certDER)
+	v.CertificateHolder = cert.Subject.CommonName
+	v.NotBefore = cert.NotBefore
+	v.NotAfter = cert.NotAfter
+
+	switch {
+	case signedAt.Before(cert.NotBefore):
+		v.Problems = append(v.Problems, ProblemCertificateNotYetValid)
+	case signedAt.After(cert.NotAfter):
+		v.Problems = append(v.Problems, ProblemCertificateExpired)
+	default:
+		v.CertificateValid = true
+	}
+
+	pub, ok := cert.PublicKey.(*rsa.PublicKey)
+	if !ok {
+		v.Problems = append(v.Problems, ProblemUnsupportedKey)
+		return v
+	}
+	digest := sha256.Sum256(content)
+	if err := rsa.VerifyPKCS1v15(pub, crypto.SHA256, digest[:], sig); err != nil {
+		v.Problems = append(v.Problems, ProblemSignatureMismatch)
+	} else {
+		v.SignatureValid = true
+	}
+	return v
+}
+
+// signedBy reports whether sig is a PKCS#1 v1.5 SHA-256 signature of content
+// under the RSA key of the DER certificate.
+func signedBy(certDER, content, sig []byte) bool {
+	cert, err := x509.ParseCertificate(certDER)
+	if err != nil {
+		return false
+	}
+	pub, ok := cert.PublicKey.(*rsa.PublicKey)
+	if !ok {
+		return false
+	}
+	digest := sha256.Sum256(content)
+	return rsa.VerifyPKCS1v15(pub, crypto.SHA256, digest[:], sig) == nil
+}
@@ -0,0 +1,171 @@
+package receipt_test
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/ivsanmendez/ControlDeContabilidad/internal/domain/receipt"
+)
+
+var signedAt = time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+
+// keyPair returns a fresh key and its self-signed certificate, valid from
+// notBefore to notAfter.
+func keyPair(t *testing.T, notBefore, notAfter time.Time) (*rsa.PrivateKey, []byte) {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: new(big.Int).SetBytes([]byte("30001000000500003416")),
+		Subject:      pkix.Name{CommonName: "María López"},
+		NotBefore:    notBefore,
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key, der
+}
+
+// signedFolio returns a receipt signed with a fresh key whose certificate is
+// valid from notBefore to notAfter.
+func signedFolio(t *testing.T, notBefore, notAfter time.Time) *receipt.ReceiptFolio {
+	t.Helper()
+	key, der := keyPair(t, notBefore, notAfter)
+	content := []byte(`{"folio":"REC-2026-000001-ABCD1234","total":500}`)
+	digest := sha256.Sum256(content)
+	sig, err := rsa.SignPKCS1v15(nil, key, crypto.SHA256, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &receipt.ReceiptFolio{
+		Folio:         "REC-2026-000001-ABCD1234",
+		CanonicalJSON: content,
+		Signature:     sig,
+		Certificate:   der,
+		SignedAt:      signedAt,
+	}
+}
+
+func validFolio(t *testing.T) *receipt.ReceiptFolio {
+	return signedFolio(t, signedAt.AddDate(-1, 0, 0), signedAt.AddDate(1, 0, 0))
+}
+
+func TestVerify_Valid(t *testing.T) {
+	v := receipt.Verify(validFolio(t))
+	if !v.Valid || !v.SignatureValid || !v.CertificateValid {
+		t.Fatalf("expected a valid verdict, got %+v", v)
+	}
+	if len(v.Problems) != 0 {
+		t.Errorf("expected no problems, got %v", v.Problems)
+	}
+	if v.CertificateSerial != "30001000000500003416" || v.CertificateHolder != "María López" {
+		t.Errorf("unexpected certificate details: %q %q", v.CertificateSerial, v.CertificateHolder)
+	}
+}
+
+func TestVerify_TamperedContent(t *testing.T) {
+	rf := validFolio(t)
+	rf.CanonicalJSON = []byte(`{"folio":"REC-2026-000001-ABCD1234","total":5000}`)
+	v := receipt.Verify(rf)
+	if v.Valid || v.SignatureValid {
+		t.Fatal("expected tampered content to fail verification")
+	}
+	if !slices.Contains(v.Problems, receipt.ProblemSignatureMismatch) {
+		t.Errorf("expected signature mismatch, got %v", v.Problems)
+	}
+}
+
+func TestVerify_OtherCertificate(t *testing.T) {
+	rf := validFolio(t)
+	rf.Certificate = validFolio(t).Certificate
+	if v := receipt.Verify(rf); v.Valid || v.SignatureValid {
+		t.Error("expected a signature from another key to fail verification")
+	}
+}
+
+func TestVerify_CertificateExpiredAtSigning(t *testing.T) {
+	rf := signedFolio(t, signedAt.AddDate(-2, 0, 0), signedAt.AddDate(0, 0, -1))
+	v := receipt.Verify(rf)
+	if v.Valid || v.CertificateValid {
+		t.Fatal("expected a certificate expired at signing to fail")
+	}
+	if !v.SignatureValid {
+		t.Error("signature itself should still verify")
+	}
+	if !slices.Equal(v.Problems, []receipt.Problem{receipt.ProblemCertificateExpired}) {
+		t.Errorf("expected certificate_expired, got %v", v.Problems)
+	}
+}
+
+func TestVerify_CertificateNotYetValidAtSigning(t *testing.T) {
+	rf := signedFolio(t, signedAt.AddDate(0, 0, 1), signedAt.AddDate(2, 0, 0))
+	v := receipt.Verify(rf)
+	if !slices.Equal(v.Problems, []receipt.Problem{receipt.ProblemCertificateNotYetValid}) {
+		t.Errorf("expected certificate_not_yet_valid, got %v", v.Problems)
+	}
+}
+
+func TestVerify_CertificateExpiredAfterSigning(t *testing.T) {
+	// A certificate that has since expired still vouches for receipts signed
+	// while it was valid.
+	rf := signedFolio(t, signedAt.AddDate(-1, 0, 0), signedAt.AddDate(0, 0, 1))
+	if v := receipt.Verify(rf); !v.Valid {
+		t.Errorf("expected valid verdict, got %v", v.Problems)
+	}
+}
+
+func TestVerify_MalformedCertificate(t *testing.T) {
+	rf := validFolio(t)
+	rf.Certificate = []byte("garbage")
+	v := receipt.Verify(rf)
+	if v.Valid || !slices.Equal(v.Problems, []receipt.Problem{receipt.ProblemMalformedCertificate}) {
+		t.Errorf("expected malformed_certificate, got %+v", v)
+	}
+}
+
+func TestVerify_Cancelled(t *testing.T) {
+	rf := validFolio(t)
+	signer := newFakeSigner(t)
+	content := []byte(`{"folio":"REC-2026-000001-ABCD1234","reason":"duplicate"}`)
+	sig, _ := signer.Sign(content, "secret")
+	rf.Cancellation = &receipt.Cancellation{
+		Reason:           "duplicate",
+		ReplacementFolio: "REC-2026-000002-BEEF0001",
+		CanonicalJSON:    content,
+		Signature:        sig,
+		Certificate:      signer.Certificate(),
+		CancelledAt:      signedAt.AddDate(0, 1, 0),
+	}
+
+	v := receipt.Verify(rf)
+	if v.Valid || !v.Cancelled {
+		t.Fatalf("expected a cancelled, invalid verdict, got %+v", v)
+	}
+	if !v.SignatureValid {
+		t.Error("the receipt's own seal should still verify")
+	}
+	if !slices.Equal(v.Problems, []receipt.Problem{receipt.ProblemCancelled}) {
+		t.Errorf("expected only cancelled, got %v", v.Problems)
+	}
+	if v.ReplacementFolio != "REC-2026-000002-BEEF0001" || v.CancellationReason != "duplicate" {
+		t.Errorf("unexpected cancellation details: %+v", v)
+	}
+
+	rf.Cancellation.Reason = "tampered"
+	rf.Cancellation.CanonicalJSON = []byte(`{"folio":"REC-2026-000001-ABCD1234","reason":"tampered"}`)
+	if v := receipt.Verify(rf); !slices.Contains(v.Problems, receipt.ProblemCancellationMismatch) {
+		t.Errorf("expected cancellation signature mismatch, got %v", v.Problems)
+	}
+}
@@ -0,0 +1,226 @@
+package report
+
+import (
+	"context"
+	"errors"
+	"math"
+	"time"
+)
+
+var (
+	ErrInvalidHorizon = errors.New("forecast horizon must be between 3 and 12 months")
+	ErrInvalidItem    = errors.New("forecast expense needs a description and a positive amount")
+	// ErrInvalidItemMonth is returned for a one-off expense without a month
+	// or outside the months being forecast.
+	ErrInvalidItemMonth  = errors.New("one-off forecast expense must fall within the forecast horizon")
+	ErrInvalidAdjustment = errors.New("income adjustment cannot be below -100%")
+)
+
+const (
+	MinForecastMonths     = 3
+	MaxForecastMonths     = 12
+	DefaultForecastMonths = 6
+	// forecastHistoryMonths is how far back fees, collection rates and
+	// recurring expenses are averaged.
+	forecastHistoryMonths = 12
+)
+
+// CategoryHistory is a raw per-category summary of the contributions that
+// covered the history window.
+type CategoryHistory struct {
+	CategoryID    int64
+	CategoryName  string
+	AverageAmount float64
+	// Payments counts distinct house-months paid.
+	Payments int
+}
+
+// ExpenseCategoryTotal is a raw per-expense-category total over the history window.
+type ExpenseCategoryTotal struct {
+	CategoryID   int64
+	CategoryName string
+	Amount       float64
+}
+
+// ForecastItem is an expense the forecast has to cover. Month is only used
+// by one-off expenses and may be any day of the month they fall in.
+type ForecastItem struct {
+	Description string    `json:"description"`
+	Amount      float64   `json:"amount"`
+	Month       time.Time `json:"month,omitzero"`
+}
+
+// ForecastScenario tunes the forecast.
+//
+// Months defaults to DefaultForecastMonths. With WeightByCollectionRate
+// expected income is scaled by each category's historical collection rate;
+// without it every house is assumed to pay. IncomeAdjustmentPct shifts the
+// expected income, e.g. -10 for a pessimistic scenario, and cannot go below
+// -100. One-off expenses must fall in one of the forecast months. A nil
+// RecurringExpenses uses the historical monthly average of each expense
+// category; an empty, non-nil slice forecasts no recurring expenses.
+type ForecastScenario struct {
+	Months                 int
+	WeightByCollectionRate bool
+	IncomeAdjustmentPct    float64
+	RecurringExpenses      []ForecastItem
+	OneOffExpenses         []ForecastItem
+}
+
+// ForecastIncome is the income one contribution category is expected to
+// bring each month. CollectionRate is a percentage.
+type ForecastIncome struct {
+	CategoryID      int64   `json:"category_id"`
+	CategoryName    string  `json:"category_name"`
+	AverageAmount   float64 `json:"average_amount"`
+	CollectionRate  float64 `json:"collection_rate"`
+	MonthlyExpected float64 `json:"monthly_expected"`
+}
+
+// ForecastMonth is the projected movement of one future month.
+type ForecastMonth struct {
+	Year              int     `json:"year"`
+	Month             int     `json:"month"`
+	OpeningBalance    float64 `json:"opening_balance"`
+	ExpectedIncome    float64 `json:"expected_income"`
+	RecurringExpenses float64 `json:"recurring_expenses"`
+	OneOffExpenses    float64 `json:"one_off_expenses"`
+	ClosingBalance    float64 `json:"closing_balance"`
+}
+
+// ForecastReport projects month-end balances from the month after AsOf.
+// LowestBalance is the lowest projected month-end balance, the figure to
+// check before committing to a large expense.
+type ForecastReport struct {
+	AsOf              time.Time        `json:"as_of"`
+	CurrentBalance    float64          `json:"current_balance"`
+	Weighted          bool             `json:"weighted"`
+	Income            []ForecastIncome `json:"income"`
+	RecurringExpenses []ForecastItem   `json:"recurring_expenses"`
+	Months            []ForecastMonth  `json:"months"`
+	LowestBalance     float64          `json:"lowest_balance"`
+	LowestMonth       string           `json:"lowest_month"`
+}
+
+// GetForecast projects the month-end balance for the months following asOf.
+// Fees, collection rates and the default recurring expenses are averaged
+// over the twelve months before asOf's month.
+func (s *Service) GetForecast(ctx context.Context, asOf time.Time, sc ForecastScenario) (*ForecastReport, error) {
+	if sc.Months == 0 {
+		sc.Months = DefaultForecastMonths
+	}
+	if sc.Months < MinForecastMonths || sc.Months > MaxForecastMonths {
+		return nil, ErrInvalidHorizon
+	}
+	if sc.IncomeAdjustmentPct < -100 {
+		return nil, ErrInvalidAdjustment
+	}
+	for _, it := range append(append([]ForecastItem{}, sc.RecurringExpenses...), sc.OneOffExpenses...) {
+		if it.Description == "" || it.Amount <= 0 {
+			return nil, ErrInvalidItem
+		}
+	}
+
+	asOf = truncateDay(asOf)
+	thisMonth := time.Date(asOf.Year(), asOf.Month(), 1, 0, 0, 0, 0, time.UTC)
+	historyFrom := thisMonth.AddDate(0, -forecastHistoryMonths, 0)
+
+	horizonEnd := thisMonth.AddDate(0, sc.Months, 0)
+	for _, it := range sc.OneOffExpenses {
+		if it.Month.IsZero() {
+			return nil, ErrInvalidItemMonth
+		}
+		m := time.Date(it.Month.Year(), it.Month.Month(), 1, 0, 0, 0, 0, time.UTC)
+		if !m.After(thisMonth) || m.After(horizonEnd) {
+			return nil, ErrInvalidItemMonth
+		}
+	}
+
+	current, err := s.repo.BalanceBefore(ctx, asOf.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, err
+	}
+	roster, err := s.repo.Roster(ctx)
+	if err != nil {
+		return nil, err
+	}
+	history, err := s.repo.ContributionHistory(ctx, historyFrom, thisMonth)
+	if err != nil {
+		return nil, err
+	}
+
+	rpt := &ForecastReport{
+		AsOf:           asOf,
+		CurrentBalance: current,
+		Weighted:       sc.WeightByCollectionRate,
+		Income:         make([]ForecastIncome, 0, len(history)),
+		Months:         make([]ForecastMonth, 0, sc.Months),
+	}
+
+	var monthlyIncome float64
+	for _, h := range history {
+		fi := ForecastIncome{
+			CategoryID:     h.CategoryID,
+			CategoryName:   h.CategoryName,
+			AverageAmount:  h.AverageAmount,
+			CollectionRate: collectionRate(h.Payments, len(roster)*forecastHistoryMonths),
+		}
+		expected := h.AverageAmount * float64(len(roster))
+		if sc.WeightByCollectionRate {
+			expected *= fi.CollectionRate / 100
+		}
+		fi.MonthlyExpected = roundCents(expected * (1 + sc.IncomeAdjustmentPct/100))
+		monthlyIncome += fi.MonthlyExpected
+		rpt.Income = append(rpt.Income, fi)
+	}
+
+	rpt.RecurringExpenses = sc.RecurringExpenses
+	if rpt.RecurringExpenses == nil {
+		totals, err := s.repo.ExpenseTotalsByCategory(ctx, historyFrom, thisMonth)
+		if err != nil {
+			return nil, err
+		}
+		rpt.RecurringExpenses = make([]ForecastItem, 0, len(totals))
+		for _, t := range totals {
+			rpt.RecurringExpenses = append(rpt.RecurringExpenses, ForecastItem{
+				Description: t.CategoryName,
+				Amount:      roundCents(t.Amount / forecastHistoryMonths),
+			})
+		}
+	}
+	var monthlyRecurring float64
+	for _, it := range rpt.RecurringExpenses {
+		monthlyRecurring += it.Amount
+	}
+
+	balance := current
+	for i := 1; i <= sc.Months; i++ {
+		start := thisMonth.AddDate(0, i, 0)
+		fm := ForecastMonth{
+			Year:              start.Year(),
+			Month:             int(start.Month()),
+			OpeningBalance:    balance,
+			ExpectedIncome:    monthlyIncome,
+			RecurringExpenses: monthlyRecurring,
+		}
+		for _, it := range sc.OneOffExpenses {
+			if it.Month.Year() == fm.Year && int(it.Month.Month()) == fm.Month {
+				fm.OneOffExpenses += it.Amount
+			}
+		}
+		balance = roundCents(balance + fm.ExpectedIncome - fm.RecurringExpenses - fm.OneOffExpenses)
+		fm.ClosingBalance = balance
+		rpt.Months = append(rpt.Months, fm)
+
+		if i == 1 || balance < rpt.LowestBalance {
+			rpt.LowestBalance = balance
+			rpt.LowestMonth = start.Format("2006-01")
+		}
+	}
+
+	return rpt, nil
+}
+
+func roundCents(v float64) float64 {
+	return math.Round(v*100) / 100
+}
@@ -1,9 +1,100 @@
 package report
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"time"
+)
 
-// ErrInvalidYear is returned when the requested year is out of range.
-var ErrInvalidYear = errors.New("invalid year")
+var (
+	// ErrInvalidYear is returned when the requested year is out of range.
+	ErrInvalidYear        = errors.New("invalid year")
+	ErrInvalidRange       = errors.New("invalid date range")
+	ErrInvalidGranularity = errors.New("invalid granularity")
+	ErrInvalidBasis       = errors.New("invalid basis")
+)
+
+// Basis decides which month a contribution's income falls in.
+//
+// On a cash basis income is grouped by payment date: the year's TotalIncome
+// is the money actually received in it, and ClosingBalance matches the money
+// on hand across the financial accounts.
+//
+// On an accrual basis income is grouped by the month and year the
+// contribution pays for: TotalIncome is what the year's fees brought in,
+// whenever they were paid. OpeningBalance likewise counts earlier years'
+// income by accrual, so a year's ClosingBalance is still the next year's
+// OpeningBalance, but neither matches the money on hand. Expenses and the
+// per-account breakdown are always reported on their cash dates.
+type Basis string
+
+const (
+	BasisCash    Basis = "cash"
+	BasisAccrual Basis = "accrual"
+)
+
+func (b Basis) Valid() bool {
+	return b == BasisCash || b == BasisAccrual
+}
+
+// Granularity is the size of the buckets a date-range report is grouped into.
+type Granularity string
+
+const (
+	GroupDay     Granularity = "day"
+	GroupWeek    Granularity = "week"
+	GroupMonth   Granularity = "month"
+	GroupQuarter Granularity = "quarter"
+	GroupYear    Granularity = "year"
+)
+
+func (g Granularity) Valid() bool {
+	switch g {
+	case GroupDay, GroupWeek, GroupMonth, GroupQuarter, GroupYear:
+		return true
+	}
+	return false
+}
+
+// bucket returns the first day of the bucket containing d and its label.
+// Weeks follow ISO 8601 and start on Monday.
+func (g Granularity) bucket(d time.Time) (time.Time, string) {
+	y, m, day := d.Date()
+	switch g {
+	case GroupDay:
+		start := time.Date(y, m, day, 0, 0, 0, 0, time.UTC)
+		return start, start.Format("2006-01-02")
+	case GroupWeek:
+		offset := (int(d.Weekday()) + 6) % 7
+		start := time.Date(y, m, day-offset, 0, 0, 0, 0, time.UTC)
+		wy, wk := start.ISOWeek()
+		return start, fmt.Sprintf("%d-W%02d", wy, wk)
+	case GroupQuarter:
+		q := (int(m)-1)/3 + 1
+		return time.Date(y, time.Month(q*3-2), 1, 0, 0, 0, 0, time.UTC), fmt.Sprintf("%d-Q%d", y, q)
+	case GroupYear:
+		return time.Date(y, 1, 1, 0, 0, 0, 0, time.UTC), fmt.Sprintf("%d", y)
+	default:
+		start := time.Date(y, m, 1, 0, 0, 0, 0, time.UTC)
+		return start, start.Format("2006-01")
+	}
+}
+
+// next returns the first day of the bucket following the one starting at start.
+func (g Granularity) next(start time.Time) time.Time {
+	switch g {
+	case GroupDay:
+		return start.AddDate(0, 0, 1)
+	case GroupWeek:
+		return start.AddDate(0, 0, 7)
+	case GroupQuarter:
+		return start.AddDate(0, 3, 0)
+	case GroupYear:
+		return start.AddDate(1, 0, 0)
+	default:
+		return start.AddDate(0, 1, 0)
+	}
+}
 
 // MonthAggregate is a raw aggregation row from the database.
 type MonthAggregate struct {
@@ -11,7 +102,9 @@ type MonthAggregate struct {
 	Amount float64
 }
 
-// MonthSummary is a computed row for one month.
+// MonthSummary is a computed row for one month. CumulativeBalance is the
+// balance at the end of the month, opening balance included; on a cash basis
+// it is the money on hand.
 type MonthSummary struct {
 	Month             int     `json:"month"`
 	Income            float64 `json:"income"`
@@ -20,11 +113,279 @@ type MonthSummary struct {
 	CumulativeBalance float64 `json:"cumulative_balance"`
 }
 
-// MonthlyBalanceReport is the full yearly report.
+// MonthlyBalanceReport is the full yearly report. OpeningBalance carries over
+// the prior year's ClosingBalance on the same basis; for the first year it is
+// the sum of the financial accounts' configured opening balances.
 type MonthlyBalanceReport struct {
-	Year          int            `json:"year"`
-	Months        []MonthSummary `json:"months"`
-	TotalIncome   float64        `json:"total_income"`
-	TotalExpenses float64        `json:"total_expenses"`
-	TotalBalance  float64        `json:"total_balance"`
+	Year           int                `json:"year"`
+	Basis          Basis              `json:"basis"`
+	OpeningBalance float64            `json:"opening_balance"`
+	Months         []MonthSummary     `json:"months"`
+	TotalIncome    float64            `json:"total_income"`
+	TotalExpenses  float64            `json:"total_expenses"`
+	TotalBalance   float64            `json:"total_balance"`
+	ClosingBalance float64            `json:"closing_balance"`
+	Accounts       []AccountBreakdown `json:"accounts"`
+	Categories     CategoryBreakdown  `json:"categories"`
+}
+
+// CategoryMonthAggregate is a raw per-category, per-month aggregation row.
+type CategoryMonthAggregate struct {
+	CategoryID   int64
+	CategoryName string
+	Month        int
+	Amount       float64
+}
+
+// CategoryRow is one category across the year. Months holds twelve amounts,
+// January first.
+type CategoryRow struct {
+	CategoryID   int64     `json:"category_id"`
+	CategoryName string    `json:"category_name"`
+	Months       []float64 `json:"months"`
+	Total        float64   `json:"total"`
+}
+
+// CategoryMatrix is a category-by-month matrix with subtotals per month
+// (MonthTotals) and per category (CategoryRow.Total). Every series has twelve
+// points so it can be charted as is.
+type CategoryMatrix struct {
+	Categories  []CategoryRow `json:"categories"`
+	MonthTotals []float64     `json:"month_totals"`
+	Total       float64       `json:"total"`
+}
+
+// CategoryBreakdown splits the year's income by contribution category and its
+// expenses by expense category.
+type CategoryBreakdown struct {
+	Income   CategoryMatrix `json:"income"`
+	Expenses CategoryMatrix `json:"expenses"`
+}
+
+// DayAggregate is a raw per-day aggregation row from the database.
+type DayAggregate struct {
+	Date   time.Time
+	Amount float64
+}
+
+// PeriodSummary is one bucket of a date-range report. Start and End are
+// clipped to the requested range, so the first and last buckets may be partial.
+type PeriodSummary struct {
+	Label             string    `json:"label"`
+	Start             time.Time `json:"start"`
+	End               time.Time `json:"end"`
+	Income            float64   `json:"income"`
+	Expenses          float64   `json:"expenses"`
+	Balance           float64   `json:"balance"`
+	CumulativeBalance float64   `json:"cumulative_balance"`
+}
+
+// RangeReport is the balance over an arbitrary date range, both ends
+// inclusive. OpeningBalance is the money on hand before From.
+type RangeReport struct {
+	From           time.Time       `json:"from"`
+	To             time.Time       `json:"to"`
+	GroupBy        Granularity     `json:"group_by"`
+	Basis          Basis           `json:"basis"`
+	OpeningBalance float64         `json:"opening_balance"`
+	Periods        []PeriodSummary `json:"periods"`
+	TotalIncome    float64         `json:"total_income"`
+	TotalExpenses  float64         `json:"total_expenses"`
+	TotalBalance   float64         `json:"total_balance"`
+	ClosingBalance float64         `json:"closing_balance"`
+}
+
+// ComparisonFigures are one year's figures for a month (or the whole year)
+// in a year-over-year comparison. The deltas are the compared year's figures
+// minus these ones, so they are zero for the compared year itself.
+// IncomeDeltaPct is nil when there is no base income to compare against.
+type ComparisonFigures struct {
+	Year           int      `json:"year"`
+	Income         float64  `json:"income"`
+	Expenses       float64  `json:"expenses"`
+	Balance        float64  `json:"balance"`
+	IncomeDelta    float64  `json:"income_delta"`
+	ExpensesDelta  float64  `json:"expenses_delta"`
+	BalanceDelta   float64  `json:"balance_delta"`
+	IncomeDeltaPct *float64 `json:"income_delta_pct"`
+}
+
+// ComparisonMonth places one month beside the same month of prior years.
+type ComparisonMonth struct {
+	Month int                 `json:"month"`
+	Years []ComparisonFigures `json:"years"`
+}
+
+// YearComparisonReport compares a year month by month against prior years.
+// Years is ordered from the compared year backwards.
+type YearComparisonReport struct {
+	Basis  Basis               `json:"basis"`
+	Years  []int               `json:"years"`
+	Months []ComparisonMonth   `json:"months"`
+	Totals []ComparisonFigures `json:"totals"`
+}
+
+// AccountOpening is a raw per-account row: the financial account's balance
+// at the start of the year.
+type AccountOpening struct {
+	AccountID   int64
+	AccountName string
+	Balance     float64
+}
+
+// AccountMonthAggregate is a raw per-account, per-month aggregation row.
+type AccountMonthAggregate struct {
+	AccountID    int64
+	Month        int
+	Income       float64
+	Expenses     float64
+	TransfersIn  float64
+	TransfersOut float64
+}
+
+// AccountMonth is one month of a financial account's movements.
+type AccountMonth struct {
+	Month          int     `json:"month"`
+	Income         float64 `json:"income"`
+	Expenses       float64 `json:"expenses"`
+	TransfersIn    float64 `json:"transfers_in"`
+	TransfersOut   float64 `json:"transfers_out"`
+	ClosingBalance float64 `json:"closing_balance"`
+}
+
+// AccountBreakdown follows one financial account (cash box, bank account)
+// through the year. Transfers move money between accounts, so they cancel
+// out across the breakdowns and do not appear in the report totals.
+type AccountBreakdown struct {
+	AccountID      int64          `json:"account_id"`
+	AccountName    string         `json:"account_name"`
+	OpeningBalance float64        `json:"opening_balance"`
+	Months         []AccountMonth `json:"months"`
+	ClosingBalance float64        `json:"closing_balance"`
+}
+
+// RosterHouse is a house on the contributors roster. Since is when it
+// joined: the house is expected to pay from that month on.
+type RosterHouse struct {
+	ContributorID int64     `json:"contributor_id"`
+	HouseNumber   string    `json:"house_number"`
+	Name          string    `json:"name"`
+	Since         time.Time `json:"-"`
+}
+
+// CollectionCategory is a contribution category houses are expected to pay.
+type CollectionCategory struct {
+	ID   int64
+	Name string
+}
+
+// PaidMark records that a house paid a category for a month of the year.
+type PaidMark struct {
+	ContributorID int64
+	CategoryID    int64
+	Month         int
+}
+
+// CollectionMonth is how one category was collected in one month. Rate is a
+// percentage of Expected, zero when no house was expected to pay.
+type CollectionMonth struct {
+	Month     int           `json:"month"`
+	Expected  int           `json:"expected"`
+	Paid      int           `json:"paid"`
+	Rate      float64       `json:"rate"`
+	NonPayers []RosterHouse `json:"non_payers"`
+}
+
+// CategoryCollection follows one contribution category through the year.
+type CategoryCollection struct {
+	CategoryID   int64             `json:"category_id"`
+	CategoryName string            `json:"category_name"`
+	Months       []CollectionMonth `json:"months"`
+	Expected     int               `json:"expected"`
+	Paid         int               `json:"paid"`
+	Rate         float64           `json:"rate"`
+}
+
+// CollectionRateReport shows, per category and month, how many houses paid
+// out of those expected to. A contribution counts for the month and year it
+// pays for, not the month it was paid in. For the current year Months stop
+// at the current month.
+type CollectionRateReport struct {
+	Year       int                  `json:"year"`
+	Categories []CategoryCollection `json:"categories"`
+}
+
+// BudgetAggregate is a raw budget row. Kind is "income" for contribution
+// categories and "expense" for expense categories.
+type BudgetAggregate struct {
+	Kind         string
+	CategoryID   int64
+	CategoryName string
+	Amount       float64
+}
+
+// BudgetVariance compares what was budgeted for a category with what actually
+// came in or went out. Variance is Actual minus Budgeted; VariancePct is nil
+// for categories that had no budget.
+type BudgetVariance struct {
+	CategoryID   int64    `json:"category_id"`
+	CategoryName string   `json:"category_name"`
+	Budgeted     float64  `json:"budgeted"`
+	Actual       float64  `json:"actual"`
+	Variance     float64  `json:"variance"`
+	VariancePct  *float64 `json:"variance_pct"`
+}
+
+// BudgetVarianceReport is the year's budget against its actual figures.
+// Categories with actual movements but no budget are included with a zero
+// budget so unplanned spending stands out.
+type BudgetVarianceReport struct {
+	Year             int              `json:"year"`
+	Basis            Basis            `json:"basis"`
+	Income           []BudgetVariance `json:"income"`
+	Expenses         []BudgetVariance `json:"expenses"`
+	BudgetedIncome   float64          `json:"budgeted_income"`
+	ActualIncome     float64          `json:"actual_income"`
+	BudgetedExpenses float64          `json:"budgeted_expenses"`
+	ActualExpenses   float64          `json:"actual_expenses"`
+}
+
+// IssuedReceipt is a signed receipt folio for a year's contributions. Cancelled
+// folios stay listed, flagged, with the folio that replaced them if any.
+type IssuedReceipt struct {
+	Folio            string    `json:"folio"`
+	HouseNumber      string    `json:"house_number"`
+	ContributorName  string    `json:"contributor_name"`
+	ReceiptYear      int       `json:"receipt_year"`
+	SignerName       string    `json:"signer_name"`
+	SignedAt         time.Time `json:"signed_at"`
+	Cancelled        bool      `json:"cancelled,omitempty"`
+	ReplacementFolio string    `json:"replacement_folio,omitempty"`
+}
+
+// SupplierAggregate is a raw per-supplier aggregation row from the database.
+// SupplierID is zero for expenses that are not linked to a supplier.
+type SupplierAggregate struct {
+	SupplierID   int64
+	SupplierName string
+	RFC          string
+	Count        int
+	Amount       float64
+}
+
+// SupplierSpending is the yearly total paid to one supplier.
+type SupplierSpending struct {
+	SupplierID   int64   `json:"supplier_id"`
+	SupplierName string  `json:"supplier_name"`
+	RFC          string  `json:"rfc"`
+	ExpenseCount int     `json:"expense_count"`
+	Total        float64 `json:"total"`
+}
+
+// SupplierSpendingReport lists how much was paid to each supplier in a year.
+type SupplierSpendingReport struct {
+	Year       int                `json:"year"`
+	Suppliers  []SupplierSpending `json:"suppliers"`
+	Unassigned float64            `json:"unassigned"`
+	Total      float64            `json:"total"`
 }
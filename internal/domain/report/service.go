@@ -1,28 +1,86 @@
 package report
 
-import "context"
+import (
+	"context"
+	"math"
+	"sort"
+	"time"
+)
+
+// MaxComparisonYears caps how many prior years a comparison may include.
+const MaxComparisonYears = 10
 
 // Repository is the outbound port for report aggregation queries.
 type Repository interface {
-	AggregateIncomeByMonth(ctx context.Context, year int) ([]MonthAggregate, error)
+	// AggregateIncomeByMonth groups contributions by payment date on a cash
+	// basis and by the month they pay for on an accrual basis.
+	AggregateIncomeByMonth(ctx context.Context, year int, basis Basis) ([]MonthAggregate, error)
 	AggregateExpensesByMonth(ctx context.Context, year int) ([]MonthAggregate, error)
+	AggregateExpensesBySupplier(ctx context.Context, year int) ([]SupplierAggregate, error)
+	// AccountOpeningBalances lists every financial account with its balance
+	// at the start of the year.
+	AccountOpeningBalances(ctx context.Context, year int) ([]AccountOpening, error)
+	// AccrualOpeningShift is accrual-basis income before the year less
+	// cash-basis income before it: fees for earlier years paid since, less
+	// fees for the year or later paid in advance.
+	AccrualOpeningShift(ctx context.Context, year int) (float64, error)
+	// AccrualShiftBefore is the same difference for income before date,
+	// dating accrual income on the first day of the month it pays for.
+	AccrualShiftBefore(ctx context.Context, date time.Time) (float64, error)
+	AggregateByAccountAndMonth(ctx context.Context, year int) ([]AccountMonthAggregate, error)
+	// AggregateIncomeByCategoryAndMonth groups contributions by contribution
+	// category; AggregateExpensesByCategoryAndMonth groups expense lines by
+	// expense category. Income follows basis like AggregateIncomeByMonth.
+	AggregateIncomeByCategoryAndMonth(ctx context.Context, year int, basis Basis) ([]CategoryMonthAggregate, error)
+	AggregateExpensesByCategoryAndMonth(ctx context.Context, year int) ([]CategoryMonthAggregate, error)
+	// AggregateIncomeByDay and AggregateExpensesByDay cover from..to inclusive.
+	// Accrual income is dated on the first day of the month it pays for.
+	AggregateIncomeByDay(ctx context.Context, from, to time.Time, basis Basis) ([]DayAggregate, error)
+	AggregateExpensesByDay(ctx context.Context, from, to time.Time) ([]DayAggregate, error)
+	// BalanceBefore is the money on hand across all financial accounts
+	// before date: opening balances plus earlier income minus earlier expenses.
+	BalanceBefore(ctx context.Context, date time.Time) (float64, error)
+	// Roster lists every contributor ordered by house number.
+	Roster(ctx context.Context) ([]RosterHouse, error)
+	// CollectionCategories lists the active contribution categories plus any
+	// inactive one that still has contributions for the year.
+	CollectionCategories(ctx context.Context, year int) ([]CollectionCategory, error)
+	// PaidMarks lists which house paid which category for each month of the
+	// year, by the period the contribution covers.
+	PaidMarks(ctx context.Context, year int) ([]PaidMark, error)
+	// ContributionHistory summarizes, per active contribution category, the
+	// contributions covering the months from..to, to excluded.
+	ContributionHistory(ctx context.Context, from, to time.Time) ([]CategoryHistory, error)
+	// ExpenseTotalsByCategory totals expense lines dated from..to, to excluded.
+	ExpenseTotalsByCategory(ctx context.Context, from, to time.Time) ([]ExpenseCategoryTotal, error)
+	BudgetLines(ctx context.Context, year int) ([]BudgetAggregate, error)
+	// IssuedReceipts lists the receipt folios covering the year's
+	// contributions, whenever they were signed, in folio order.
+	IssuedReceipts(ctx context.Context, year int) ([]IssuedReceipt, error)
 }
 
 // Service orchestrates report use cases.
 type Service struct {
 	repo Repository
+	now  func() time.Time
 }
 
 func NewService(repo Repository) *Service {
-	return &Service{repo: repo}
+	return &Service{repo: repo, now: time.Now}
 }
 
-func (s *Service) GetMonthlyBalance(ctx context.Context, year int) (*MonthlyBalanceReport, error) {
+// GetMonthlyBalance reports the year month by month. basis defaults to cash;
+// see Basis for how the totals differ.
+func (s *Service) GetMonthlyBalance(ctx context.Context, year int, basis Basis) (*MonthlyBalanceReport, error) {
 	if year < 2000 {
 		return nil, ErrInvalidYear
 	}
+	basis, err := normalizeBasis(basis)
+	if err != nil {
+		return nil, err
+	}
 
-	income, err := s.repo.AggregateIncomeByMonth(ctx, year)
+	income, err := s.repo.AggregateIncomeByMonth(ctx, year, basis)
 	if err != nil {
 		return nil, err
 	}
@@ -42,12 +100,28 @@ func (s *Service) GetMonthlyBalance(ctx context.Context, year int) (*MonthlyBala
 		expenseMap[a.Month] = a.Amount
 	}
 
+	openings, err := s.repo.AccountOpeningBalances(ctx, year)
+	if err != nil {
+		return nil, err
+	}
+
 	rpt := &MonthlyBalanceReport{
 		Year:   year,
+		Basis:  basis,
 		Months: make([]MonthSummary, 0, 12),
 	}
+	for _, o := range openings {
+		rpt.OpeningBalance += o.Balance
+	}
+	if basis == BasisAccrual {
+		shift, err := s.repo.AccrualOpeningShift(ctx, year)
+		if err != nil {
+			return nil, err
+		}
+		rpt.OpeningBalance += shift
+	}
 
-	var cumulative float64
+	cumulative := rpt.OpeningBalance
 	for m := 1; m <= 12; m++ {
 		inc := incomeMap[m]
 		exp := expenseMap[m]
@@ -66,6 +140,504 @@ func (s *Service) GetMonthlyBalance(ctx context.Context, year int) (*MonthlyBala
 		rpt.TotalExpenses += exp
 	}
 	rpt.TotalBalance = rpt.TotalIncome - rpt.TotalExpenses
+	rpt.ClosingBalance = rpt.OpeningBalance + rpt.TotalBalance
+
+	rpt.Accounts, err = s.accountBreakdowns(ctx, year, openings)
+	if err != nil {
+		return nil, err
+	}
+
+	incomeByCategory, err := s.repo.AggregateIncomeByCategoryAndMonth(ctx, year, basis)
+	if err != nil {
+		return nil, err
+	}
+	expensesByCategory, err := s.repo.AggregateExpensesByCategoryAndMonth(ctx, year)
+	if err != nil {
+		return nil, err
+	}
+	rpt.Categories = CategoryBreakdown{
+		Income:   categoryMatrix(incomeByCategory),
+		Expenses: categoryMatrix(expensesByCategory),
+	}
+
+	return rpt, nil
+}
+
+// categoryMatrix pivots aggregation rows into a category-by-month matrix,
+// keeping categories in the order the repository returned them.
+func categoryMatrix(rows []CategoryMonthAggregate) CategoryMatrix {
+	m := CategoryMatrix{
+		Categories:  []CategoryRow{},
+		MonthTotals: make([]float64, 12),
+	}
+	index := make(map[int64]int)
+	for _, a := range rows {
+		i, ok := index[a.CategoryID]
+		if !ok {
+			i = len(m.Categories)
+			index[a.CategoryID] = i
+			m.Categories = append(m.Categories, CategoryRow{
+				CategoryID:   a.CategoryID,
+				CategoryName: a.CategoryName,
+				Months:       make([]float64, 12),
+			})
+		}
+		m.Categories[i].Months[a.Month-1] += a.Amount
+		m.Categories[i].Total += a.Amount
+		m.MonthTotals[a.Month-1] += a.Amount
+		m.Total += a.Amount
+	}
+	return m
+}
+
+// accountBreakdowns rolls each financial account's opening balance forward
+// month by month.
+func (s *Service) accountBreakdowns(ctx context.Context, year int, openings []AccountOpening) ([]AccountBreakdown, error) {
+	rows, err := s.repo.AggregateByAccountAndMonth(ctx, year)
+	if err != nil {
+		return nil, err
+	}
+
+	type key struct {
+		account int64
+		month   int
+	}
+	byKey := make(map[key]AccountMonthAggregate, len(rows))
+	for _, a := range rows {
+		byKey[key{a.AccountID, a.Month}] = a
+	}
+
+	result := make([]AccountBreakdown, 0, len(openings))
+	for _, o := range openings {
+		b := AccountBreakdown{
+			AccountID:      o.AccountID,
+			AccountName:    o.AccountName,
+			OpeningBalance: o.Balance,
+			Months:         make([]AccountMonth, 0, 12),
+		}
+		balance := o.Balance
+		for m := 1; m <= 12; m++ {
+			a := byKey[key{o.AccountID, m}]
+			balance += a.Income + a.TransfersIn - a.Expenses - a.TransfersOut
+			b.Months = append(b.Months, AccountMonth{
+				Month:          m,
+				Income:         a.Income,
+				Expenses:       a.Expenses,
+				TransfersIn:    a.TransfersIn,
+				TransfersOut:   a.TransfersOut,
+				ClosingBalance: balance,
+			})
+		}
+		b.ClosingBalance = balance
+		result = append(result, b)
+	}
+	return result, nil
+}
+
+// GetRangeBalance reports income and expenses between from and to, both
+// inclusive, grouped into buckets of the given granularity. On an accrual
+// basis a month's fees land on its first day, and the opening balance counts
+// earlier income by accrual as GetMonthlyBalance does.
+func (s *Service) GetRangeBalance(ctx context.Context, from, to time.Time, groupBy Granularity, basis Basis) (*RangeReport, error) {
+	if groupBy == "" {
+		groupBy = GroupMonth
+	}
+	if !groupBy.Valid() {
+		return nil, ErrInvalidGranularity
+	}
+	from = truncateDay(from)
+	to = truncateDay(to)
+	if from.Year() < 2000 || to.Before(from) {
+		return nil, ErrInvalidRange
+	}
+	basis, err := normalizeBasis(basis)
+	if err != nil {
+		return nil, err
+	}
+
+	income, err := s.repo.AggregateIncomeByDay(ctx, from, to, basis)
+	if err != nil {
+		return nil, err
+	}
+	expenses, err := s.repo.AggregateExpensesByDay(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+	opening, err := s.repo.BalanceBefore(ctx, from)
+	if err != nil {
+		return nil, err
+	}
+	if basis == BasisAccrual {
+		shift, err := s.repo.AccrualShiftBefore(ctx, from)
+		if err != nil {
+			return nil, err
+		}
+		opening += shift
+	}
+
+	rpt := &RangeReport{
+		From:           from,
+		To:             to,
+		GroupBy:        groupBy,
+		Basis:          basis,
+		OpeningBalance: opening,
+	}
+
+	index := make(map[time.Time]int)
+	for start, _ := groupBy.bucket(from); !start.After(to); start = groupBy.next(start) {
+		_, label := groupBy.bucket(start)
+		p := PeriodSummary{Label: label, Start: start, End: groupBy.next(start).AddDate(0, 0, -1)}
+		if p.Start.Before(from) {
+			p.Start = from
+		}
+		if p.End.After(to) {
+			p.End = to
+		}
+		index[start] = len(rpt.Periods)
+		rpt.Periods = append(rpt.Periods, p)
+	}
+
+	for _, a := range income {
+		start, _ := groupBy.bucket(a.Date)
+		if i, ok := index[start]; ok {
+			rpt.Periods[i].Income += a.Amount
+		}
+	}
+	for _, a := range expenses {
+		start, _ := groupBy.bucket(a.Date)
+		if i, ok := index[start]; ok {
+			rpt.Periods[i].Expenses += a.Amount
+		}
+	}
+
+	cumulative := opening
+	for i := range rpt.Periods {
+		p := &rpt.Periods[i]
+		p.Balance = p.Income - p.Expenses
+		cumulative += p.Balance
+		p.CumulativeBalance = cumulative
+		rpt.TotalIncome += p.Income
+		rpt.TotalExpenses += p.Expenses
+	}
+	rpt.TotalBalance = rpt.TotalIncome - rpt.TotalExpenses
+	rpt.ClosingBalance = opening + rpt.TotalBalance
+
+	return rpt, nil
+}
+
+// GetYearComparison places each month of year beside the same month of the
+// prior years. priorYears defaults to 1, the previous year, and basis to cash.
+func (s *Service) GetYearComparison(ctx context.Context, year, priorYears int, basis Basis) (*YearComparisonReport, error) {
+	if priorYears == 0 {
+		priorYears = 1
+	}
+	if year-priorYears < 2000 || priorYears < 0 || priorYears > MaxComparisonYears {
+		return nil, ErrInvalidYear
+	}
+	basis, err := normalizeBasis(basis)
+	if err != nil {
+		return nil, err
+	}
+
+	rpt := &YearComparisonReport{
+		Basis:  basis,
+		Months: make([]ComparisonMonth, 12),
+	}
+	for m := range rpt.Months {
+		rpt.Months[m].Month = m + 1
+	}
+
+	var base [12]ComparisonFigures
+	var baseTotal ComparisonFigures
+	for y := year; y >= year-priorYears; y-- {
+		income, err := s.repo.AggregateIncomeByMonth(ctx, y, basis)
+		if err != nil {
+			return nil, err
+		}
+		expenses, err := s.repo.AggregateExpensesByMonth(ctx, y)
+		if err != nil {
+			return nil, err
+		}
+
+		var months [12]ComparisonFigures
+		for m := range months {
+			months[m].Year = y
+		}
+		for _, a := range income {
+			months[a.Month-1].Income += a.Amount
+		}
+		for _, a := range expenses {
+			months[a.Month-1].Expenses += a.Amount
+		}
+
+		total := ComparisonFigures{Year: y}
+		for m := range months {
+			months[m].Balance = months[m].Income - months[m].Expenses
+			total.Income += months[m].Income
+			total.Expenses += months[m].Expenses
+		}
+		total.Balance = total.Income - total.Expenses
+
+		if y == year {
+			base, baseTotal = months, total
+		}
+		for m := range months {
+			rpt.Months[m].Years = append(rpt.Months[m].Years, compareFigures(base[m], months[m]))
+		}
+		rpt.Years = append(rpt.Years, y)
+		rpt.Totals = append(rpt.Totals, compareFigures(baseTotal, total))
+	}
+
+	return rpt, nil
+}
+
+// compareFigures fills f's deltas against the compared year's figures.
+func compareFigures(base, f ComparisonFigures) ComparisonFigures {
+	f.IncomeDelta = base.Income - f.Income
+	f.ExpensesDelta = base.Expenses - f.Expenses
+	f.BalanceDelta = base.Balance - f.Balance
+	if f.Income != 0 {
+		pct := f.IncomeDelta / f.Income * 100
+		f.IncomeDeltaPct = &pct
+	}
+	return f
+}
+
+// normalizeBasis defaults an empty basis to cash.
+func normalizeBasis(b Basis) (Basis, error) {
+	if b == "" {
+		return BasisCash, nil
+	}
+	if !b.Valid() {
+		return "", ErrInvalidBasis
+	}
+	return b, nil
+}
+
+func truncateDay(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+// GetCollectionRate reports, for each contribution category and month of the
+// year, how many houses on the roster paid and which did not. Every house is
+// expected to pay every category each month from the month it joined the
+// roster; months still ahead are left out. categoryID narrows the report to
+// one category; zero includes them all.
+func (s *Service) GetCollectionRate(ctx context.Context, year int, categoryID int64) (*CollectionRateReport, error) {
+	if year < 2000 {
+		return nil, ErrInvalidYear
+	}
+
+	roster, err := s.repo.Roster(ctx)
+	if err != nil {
+		return nil, err
+	}
+	categories, err := s.repo.CollectionCategories(ctx, year)
+	if err != nil {
+		return nil, err
+	}
+	marks, err := s.repo.PaidMarks(ctx, year)
+	if err != nil {
+		return nil, err
+	}
+
+	type key struct {
+		contributor int64
+		category    int64
+		month       int
+	}
+	paid := make(map[key]bool, len(marks))
+	for _, m := range marks {
+		paid[key{m.ContributorID, m.CategoryID, m.Month}] = true
+	}
+
+	lastMonth := 12
+	if now := s.now(); year > now.Year() {
+		lastMonth = 0
+	} else if year == now.Year() {
+		lastMonth = int(now.Month())
+	}
+
+	rpt := &CollectionRateReport{
+		Year:       year,
+		Categories: []CategoryCollection{},
+	}
+	for _, c := range categories {
+		if categoryID != 0 && c.ID != categoryID {
+			continue
+		}
+		cc := CategoryCollection{
+			CategoryID:   c.ID,
+			CategoryName: c.Name,
+			Months:       make([]CollectionMonth, 0, lastMonth),
+		}
+		for m := 1; m <= lastMonth; m++ {
+			cm := CollectionMonth{
+				Month:     m,
+				NonPayers: []RosterHouse{},
+			}
+			monthEnd := time.Date(year, time.Month(m)+1, 1, 0, 0, 0, 0, time.UTC)
+			for _, h := range roster {
+				if !h.Since.IsZero() && !h.Since.Before(monthEnd) {
+					continue
+				}
+				cm.Expected++
+				if paid[key{h.ContributorID, c.ID, m}] {
+					cm.Paid++
+				} else {
+					cm.NonPayers = append(cm.NonPayers, h)
+				}
+			}
+			cm.Rate = collectionRate(cm.Paid, cm.Expected)
+			cc.Months = append(cc.Months, cm)
+			cc.Expected += cm.Expected
+			cc.Paid += cm.Paid
+		}
+		cc.Rate = collectionRate(cc.Paid, cc.Expected)
+		rpt.Categories = append(rpt.Categories, cc)
+	}
+
+	return rpt, nil
+}
+
+// collectionRate is paid as a percentage of expected, rounded to two decimals.
+func collectionRate(paid, expected int) float64 {
+	if expected == 0 {
+		return 0
+	}
+	return math.Round(float64(paid)/float64(expected)*10000) / 100
+}
+
+// GetBudgetVariance compares the year's budget with its actual income and
+// expenses per category. basis defaults to cash.
+func (s *Service) GetBudgetVariance(ctx context.Context, year int, basis Basis) (*BudgetVarianceReport, error) {
+	if year < 2000 {
+		return nil, ErrInvalidYear
+	}
+	basis, err := normalizeBasis(basis)
+	if err != nil {
+		return nil, err
+	}
+
+	budget, err := s.repo.BudgetLines(ctx, year)
+	if err != nil {
+		return nil, err
+	}
+	income, err := s.repo.AggregateIncomeByCategoryAndMonth(ctx, year, basis)
+	if err != nil {
+		return nil, err
+	}
+	expenses, err := s.repo.AggregateExpensesByCategoryAndMonth(ctx, year)
+	if err != nil {
+		return nil, err
+	}
+
+	var incomeBudget, expenseBudget []BudgetAggregate
+	for _, b := range budget {
+		if b.Kind == "income" {
+			incomeBudget = append(incomeBudget, b)
+		} else {
+			expenseBudget = append(expenseBudget, b)
+		}
+	}
+
+	rpt := &BudgetVarianceReport{
+		Year:     year,
+		Basis:    basis,
+		Income:   budgetVariances(incomeBudget, categoryMatrix(income)),
+		Expenses: budgetVariances(expenseBudget, categoryMatrix(expenses)),
+	}
+	for _, v := range rpt.Income {
+		rpt.BudgetedIncome += v.Budgeted
+		rpt.ActualIncome += v.Actual
+	}
+	for _, v := range rpt.Expenses {
+		rpt.BudgetedExpenses += v.Budgeted
+		rpt.ActualExpenses += v.Actual
+	}
+	return rpt, nil
+}
+
+// budgetVariances lines up budgeted categories, in budget order, followed by
+// the categories that only have actual movements.
+func budgetVariances(budget []BudgetAggregate, actual CategoryMatrix) []BudgetVariance {
+	actuals := make(map[int64]CategoryRow, len(actual.Categories))
+	for _, c := range actual.Categories {
+		actuals[c.CategoryID] = c
+	}
+
+	result := make([]BudgetVariance, 0, len(budget)+len(actual.Categories))
+	budgeted := make(map[int64]bool, len(budget))
+	for _, b := range budget {
+		budgeted[b.CategoryID] = true
+		v := BudgetVariance{
+			CategoryID:   b.CategoryID,
+			CategoryName: b.CategoryName,
+			Budgeted:     b.Amount,
+			Actual:       actuals[b.CategoryID].Total,
+		}
+		v.Variance = roundCents(v.Actual - v.Budgeted)
+		if v.Budgeted != 0 {
+			pct := math.Round(v.Variance/v.Budgeted*10000) / 100
+			v.VariancePct = &pct
+		}
+		result = append(result, v)
+	}
+	for _, c := range actual.Categories {
+		if budgeted[c.CategoryID] {
+			continue
+		}
+		result = append(result, BudgetVariance{
+			CategoryID:   c.CategoryID,
+			CategoryName: c.CategoryName,
+			Actual:       c.Total,
+			Variance:     c.Total,
+		})
+	}
+	return result
+}
+
+func (s *Service) GetIssuedReceipts(ctx context.Context, year int) ([]IssuedReceipt, error) {
+	if year < 2000 {
+		return nil, ErrInvalidYear
+	}
+	return s.repo.IssuedReceipts(ctx, year)
+}
+
+// GetSupplierSpending totals the year's expenses per supplier, highest first.
+// Expenses without a supplier are reported separately as Unassigned.
+func (s *Service) GetSupplierSpending(ctx context.Context, year int) (*SupplierSpendingReport, error) {
+	if year < 2000 {
+		return nil, ErrInvalidYear
+	}
+
+	rows, err := s.repo.AggregateExpensesBySupplier(ctx, year)
+	if err != nil {
+		return nil, err
+	}
+
+	rpt := &SupplierSpendingReport{
+		Year:      year,
+		Suppliers: make([]SupplierSpending, 0, len(rows)),
+	}
+	for _, a := range rows {
+		rpt.Total += a.Amount
+		if a.SupplierID == 0 {
+			rpt.Unassigned += a.Amount
+			continue
+		}
+		rpt.Suppliers = append(rpt.Suppliers, SupplierSpending{
+			SupplierID:   a.SupplierID,
+			SupplierName: a.SupplierName,
+			RFC:          a.RFC,
+			ExpenseCount: a.Count,
+			Total:        a.Amount,
+		})
+	}
+	sort.SliceStable(rpt.Suppliers, func(i, j int) bool {
+		return rpt.Suppliers[i].Total > rpt.Suppliers[j].Total
+	})
 
 	return rpt, nil
 }
@@ -4,33 +4,153 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/ivsanmendez/ControlDeContabilidad/internal/domain/report"
 )
 
 type fakeRepo struct {
-	income   []report.MonthAggregate
-	expenses []report.MonthAggregate
-	err      error
+	income     []report.MonthAggregate
+	expenses   []report.MonthAggregate
+	suppliers  []report.SupplierAggregate
+	openings   []report.AccountOpening
+	accounts   []report.AccountMonthAggregate
+	dayIncome  []report.DayAggregate
+	dayExpense []report.DayAggregate
+	before     float64
+	shift      map[int]float64
+	incomeCat  []report.CategoryMonthAggregate
+	// dayAccrual and shiftBefore are the accrual-basis range figures.
+	dayAccrual  []report.DayAggregate
+	shiftBefore float64
+	// accrual, when set, is returned instead of income on an accrual basis.
+	accrual    []report.MonthAggregate
+	roster     []report.RosterHouse
+	categories []report.CollectionCategory
+	marks      []report.PaidMark
+	history    []report.CategoryHistory
+	expTotals  []report.ExpenseCategoryTotal
+	expenseCat []report.CategoryMonthAggregate
+	budget     []report.BudgetAggregate
+	receipts   []report.IssuedReceipt
+	// byYear, when set, overrides income and expenses per year.
+	byYear map[int][2][]report.MonthAggregate
+	err    error
 }
 
-func (r *fakeRepo) AggregateIncomeByMonth(_ context.Context, _ int) ([]report.MonthAggregate, error) {
+func (r *fakeRepo) AggregateIncomeByMonth(_ context.Context, year int, basis report.Basis) ([]report.MonthAggregate, error) {
 	if r.err != nil {
 		return nil, r.err
 	}
+	if basis == report.BasisAccrual && r.accrual != nil {
+		return r.accrual, nil
+	}
+	if r.byYear != nil {
+		return r.byYear[year][0], nil
+	}
 	return r.income, nil
 }
 
-func (r *fakeRepo) AggregateExpensesByMonth(_ context.Context, _ int) ([]report.MonthAggregate, error) {
+func (r *fakeRepo) AggregateExpensesByMonth(_ context.Context, year int) ([]report.MonthAggregate, error) {
 	if r.err != nil {
 		return nil, r.err
 	}
+	if r.byYear != nil {
+		return r.byYear[year][1], nil
+	}
 	return r.expenses, nil
 }
 
+func (r *fakeRepo) AggregateExpensesBySupplier(_ context.Context, _ int) ([]report.SupplierAggregate, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	return r.suppliers, nil
+}
+
+func (r *fakeRepo) AccountOpeningBalances(_ context.Context, _ int) ([]report.AccountOpening, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	return r.openings, nil
+}
+
+func (r *fakeRepo) AccrualOpeningShift(_ context.Context, year int) (float64, error) {
+	if r.err != nil {
+		return 0, r.err
+	}
+	return r.shift[year], nil
+}
+
+func (r *fakeRepo) AggregateByAccountAndMonth(_ context.Context, _ int) ([]report.AccountMonthAggregate, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	return r.accounts, nil
+}
+
+func (r *fakeRepo) AggregateIncomeByDay(_ context.Context, _, _ time.Time, basis report.Basis) ([]report.DayAggregate, error) {
+	if basis == report.BasisAccrual {
+		return r.dayAccrual, r.err
+	}
+	return r.dayIncome, r.err
+}
+
+func (r *fakeRepo) AggregateExpensesByDay(_ context.Context, _, _ time.Time) ([]report.DayAggregate, error) {
+	return r.dayExpense, r.err
+}
+
+func (r *fakeRepo) AccrualShiftBefore(_ context.Context, _ time.Time) (float64, error) {
+	return r.shiftBefore, r.err
+}
+
+func (r *fakeRepo) BalanceBefore(_ context.Context, _ time.Time) (float64, error) {
+	return r.before, r.err
+}
+
+func (r *fakeRepo) AggregateIncomeByCategoryAndMonth(_ context.Context, _ int, _ report.Basis) ([]report.CategoryMonthAggregate, error) {
+	return r.incomeCat, r.err
+}
+
+func (r *fakeRepo) AggregateExpensesByCategoryAndMonth(_ context.Context, _ int) ([]report.CategoryMonthAggregate, error) {
+	return r.expenseCat, r.err
+}
+
+func (r *fakeRepo) Roster(_ context.Context) ([]report.RosterHouse, error) {
+	return r.roster, r.err
+}
+
+func (r *fakeRepo) CollectionCategories(_ context.Context, _ int) ([]report.CollectionCategory, error) {
+	return r.categories, r.err
+}
+
+func (r *fakeRepo) PaidMarks(_ context.Context, _ int) ([]report.PaidMark, error) {
+	return r.marks, r.err
+}
+
+func (r *fakeRepo) ContributionHistory(_ context.Context, _, _ time.Time) ([]report.CategoryHistory, error) {
+	return r.history, r.err
+}
+
+func (r *fakeRepo) ExpenseTotalsByCategory(_ context.Context, _, _ time.Time) ([]report.ExpenseCategoryTotal, error) {
+	return r.expTotals, r.err
+}
+
+func (r *fakeRepo) BudgetLines(_ context.Context, _ int) ([]report.BudgetAggregate, error) {
+	return r.budget, r.err
+}
+
+func (r *fakeRepo) IssuedReceipts(_ context.Context, _ int) ([]report.IssuedReceipt, error) {
+	return r.receipts, r.err
+}
+
+func day(y int, m time.Month, d int) time.Time {
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
 func TestGetMonthlyBalance_InvalidYear(t *testing.T) {
 	svc := report.NewService(&fakeRepo{})
-	_, err := svc.GetMonthlyBalance(context.Background(), 1999)
+	_, err := svc.GetMonthlyBalance(context.Background(), 1999, report.BasisCash)
 	if !errors.Is(err, report.ErrInvalidYear) {
 		t.Fatalf("expected ErrInvalidYear, got %v", err)
 	}
@@ -38,7 +158,7 @@ func TestGetMonthlyBalance_InvalidYear(t *testing.T) {
 
 func TestGetMonthlyBalance_EmptyYear(t *testing.T) {
 	svc := report.NewService(&fakeRepo{})
-	rpt, err := svc.GetMonthlyBalance(context.Background(), 2026)
+	rpt, err := svc.GetMonthlyBalance(context.Background(), 2026, report.BasisCash)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -62,7 +182,7 @@ func TestGetMonthlyBalance_Computation(t *testing.T) {
 		},
 	}
 	svc := report.NewService(repo)
-	rpt, err := svc.GetMonthlyBalance(context.Background(), 2026)
+	rpt, err := svc.GetMonthlyBalance(context.Background(), 2026, report.BasisCash)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -100,8 +220,559 @@ func TestGetMonthlyBalance_Computation(t *testing.T) {
 func TestGetMonthlyBalance_RepoError(t *testing.T) {
 	repo := &fakeRepo{err: errors.New("db down")}
 	svc := report.NewService(repo)
-	_, err := svc.GetMonthlyBalance(context.Background(), 2026)
+	_, err := svc.GetMonthlyBalance(context.Background(), 2026, report.BasisCash)
 	if err == nil {
 		t.Fatal("expected error, got nil")
 	}
 }
+
+func TestGetMonthlyBalance_AccountBreakdown(t *testing.T) {
+	repo := &fakeRepo{
+		income:   []report.MonthAggregate{{Month: 1, Amount: 1000}},
+		expenses: []report.MonthAggregate{{Month: 2, Amount: 300}},
+		openings: []report.AccountOpening{
+			{AccountID: 1, AccountName: "Caja", Balance: 50},
+			{AccountID: 2, AccountName: "Cheques", Balance: 0},
+		},
+		accounts: []report.AccountMonthAggregate{
+			{AccountID: 1, Month: 1, Income: 1000, TransfersOut: 800},
+			{AccountID: 2, Month: 1, TransfersIn: 800},
+			{AccountID: 2, Month: 2, Expenses: 300},
+		},
+	}
+	svc := report.NewService(repo)
+
+	rpt, err := svc.GetMonthlyBalance(context.Background(), 2026, report.BasisCash)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(rpt.Accounts) != 2 {
+		t.Fatalf("expected 2 account breakdowns, got %d", len(rpt.Accounts))
+	}
+	cash, bank := rpt.Accounts[0], rpt.Accounts[1]
+	if cash.Months[0].ClosingBalance != 250 || cash.ClosingBalance != 250 {
+		t.Fatalf("unexpected cash breakdown: %+v", cash)
+	}
+	if bank.Months[0].ClosingBalance != 800 || bank.Months[1].ClosingBalance != 500 || bank.ClosingBalance != 500 {
+		t.Fatalf("unexpected bank breakdown: %+v", bank)
+	}
+	// The accounts together hold the opening money plus the year's result.
+	if cash.ClosingBalance+bank.ClosingBalance != 50+rpt.TotalBalance {
+		t.Fatalf("account closings %f do not reconcile with total balance %f",
+			cash.ClosingBalance+bank.ClosingBalance, rpt.TotalBalance)
+	}
+}
+
+func TestGetMonthlyBalance_CarriesOpeningBalance(t *testing.T) {
+	repo := &fakeRepo{
+		income:   []report.MonthAggregate{{Month: 1, Amount: 1000}},
+		expenses: []report.MonthAggregate{{Month: 6, Amount: 400}},
+		openings: []report.AccountOpening{
+			{AccountID: 1, AccountName: "Caja", Balance: 1500},
+			{AccountID: 2, AccountName: "Cheques", Balance: 2500},
+		},
+	}
+	svc := report.NewService(repo)
+
+	rpt, err := svc.GetMonthlyBalance(context.Background(), 2026, report.BasisCash)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if rpt.OpeningBalance != 4000 {
+		t.Fatalf("expected opening balance 4000, got %f", rpt.OpeningBalance)
+	}
+	if rpt.Months[0].CumulativeBalance != 5000 || rpt.Months[11].CumulativeBalance != 4600 {
+		t.Fatalf("cumulative balance must start from the opening balance: %+v", rpt.Months)
+	}
+	if rpt.ClosingBalance != 4600 || rpt.ClosingBalance != rpt.OpeningBalance+rpt.TotalBalance {
+		t.Fatalf("expected closing balance 4600, got %f", rpt.ClosingBalance)
+	}
+}
+
+func TestGetMonthlyBalance_CategoryBreakdown(t *testing.T) {
+	repo := &fakeRepo{
+		incomeCat: []report.CategoryMonthAggregate{
+			{CategoryID: 2, CategoryName: "Cuota", Month: 1, Amount: 800},
+			{CategoryID: 2, CategoryName: "Cuota", Month: 2, Amount: 700},
+			{CategoryID: 5, CategoryName: "Extraordinaria", Month: 2, Amount: 300},
+		},
+		expenseCat: []report.CategoryMonthAggregate{
+			{CategoryID: 1, CategoryName: "Vigilancia", Month: 12, Amount: 450},
+		},
+	}
+	svc := report.NewService(repo)
+
+	rpt, err := svc.GetMonthlyBalance(context.Background(), 2026, report.BasisCash)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	income := rpt.Categories.Income
+	if len(income.Categories) != 2 || income.Categories[0].CategoryName != "Cuota" {
+		t.Fatalf("unexpected income categories: %+v", income.Categories)
+	}
+	if len(income.Categories[0].Months) != 12 || income.Categories[0].Months[1] != 700 || income.Categories[0].Total != 1500 {
+		t.Fatalf("unexpected Cuota row: %+v", income.Categories[0])
+	}
+	if income.MonthTotals[1] != 1000 || income.Total != 1800 {
+		t.Fatalf("unexpected income subtotals: %v total %f", income.MonthTotals, income.Total)
+	}
+	expenses := rpt.Categories.Expenses
+	if expenses.MonthTotals[11] != 450 || expenses.Total != 450 {
+		t.Fatalf("unexpected expense subtotals: %+v", expenses)
+	}
+}
+
+func TestGetMonthlyBalance_Basis(t *testing.T) {
+	// January's fee was paid in March: cash books it in March, accrual in January.
+	repo := &fakeRepo{
+		income:  []report.MonthAggregate{{Month: 3, Amount: 500}},
+		accrual: []report.MonthAggregate{{Month: 1, Amount: 500}},
+	}
+	svc := report.NewService(repo)
+	ctx := context.Background()
+
+	cash, err := svc.GetMonthlyBalance(ctx, 2026, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cash.Basis != report.BasisCash || cash.Months[2].Income != 500 || cash.Months[0].Income != 0 {
+		t.Fatalf("expected cash basis by default with income in March: %+v", cash.Months[:3])
+	}
+
+	accrual, err := svc.GetMonthlyBalance(ctx, 2026, report.BasisAccrual)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if accrual.Months[0].Income != 500 || accrual.Months[2].Income != 0 || accrual.TotalIncome != cash.TotalIncome {
+		t.Fatalf("expected accrual income in January: %+v", accrual.Months[:3])
+	}
+
+	if _, err := svc.GetMonthlyBalance(ctx, 2026, "deferred"); !errors.Is(err, report.ErrInvalidBasis) {
+		t.Fatalf("expected ErrInvalidBasis, got %v", err)
+	}
+}
+
+func TestGetMonthlyBalance_AccrualCarriesOpeningBalance(t *testing.T) {
+	// 1000 on hand at the start of 2025; December 2025's fee of 500 was paid
+	// in January 2026. Accrual books it in 2025, so 2025 closes at 1500 and
+	// 2026 must open there even though the cash was not received until 2026.
+	repo := &fakeRepo{
+		openings: []report.AccountOpening{{AccountID: 1, AccountName: "Caja", Balance: 1000}},
+		accrual:  []report.MonthAggregate{{Month: 12, Amount: 500}},
+	}
+	svc := report.NewService(repo)
+	ctx := context.Background()
+
+	y2025, err := svc.GetMonthlyBalance(ctx, 2025, report.BasisAccrual)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if y2025.ClosingBalance != 1500 {
+		t.Fatalf("expected 2025 to close at 1500, got %v", y2025.ClosingBalance)
+	}
+
+	// By 2026 the cash openings still hold 1000: the fee arrived in 2026.
+	repo.accrual = []report.MonthAggregate{}
+	repo.shift = map[int]float64{2026: 500}
+	y2026, err := svc.GetMonthlyBalance(ctx, 2026, report.BasisAccrual)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if y2026.OpeningBalance != y2025.ClosingBalance {
+		t.Fatalf("2026 opens at %v, 2025 closed at %v", y2026.OpeningBalance, y2025.ClosingBalance)
+	}
+
+	cash, err := svc.GetMonthlyBalance(ctx, 2026, report.BasisCash)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cash.OpeningBalance != 1000 {
+		t.Fatalf("the cash opening must ignore the accrual shift, got %v", cash.OpeningBalance)
+	}
+}
+
+func TestGetRangeBalance_GroupsIntoBuckets(t *testing.T) {
+	repo := &fakeRepo{
+		dayIncome: []report.DayAggregate{
+			{Date: day(2026, 1, 20), Amount: 500},
+			{Date: day(2026, 3, 31), Amount: 300},
+			{Date: day(2026, 4, 1), Amount: 200},
+		},
+		dayExpense: []report.DayAggregate{{Date: day(2026, 5, 10), Amount: 100}},
+		before:     1000,
+	}
+	svc := report.NewService(repo)
+
+	rpt, err := svc.GetRangeBalance(context.Background(), day(2026, 1, 15), day(2026, 5, 15), report.GroupQuarter, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(rpt.Periods) != 2 {
+		t.Fatalf("expected 2 quarters, got %d", len(rpt.Periods))
+	}
+	q1, q2 := rpt.Periods[0], rpt.Periods[1]
+	if q1.Label != "2026-Q1" || !q1.Start.Equal(day(2026, 1, 15)) || !q1.End.Equal(day(2026, 3, 31)) || q1.Income != 800 {
+		t.Fatalf("unexpected first quarter: %+v", q1)
+	}
+	if q2.Label != "2026-Q2" || !q2.End.Equal(day(2026, 5, 15)) || q2.Income != 200 || q2.Expenses != 100 {
+		t.Fatalf("unexpected second quarter: %+v", q2)
+	}
+	if q2.CumulativeBalance != 1900 || rpt.ClosingBalance != 1900 || rpt.TotalBalance != 900 {
+		t.Fatalf("unexpected balances: cumulative %f closing %f total %f", q2.CumulativeBalance, rpt.ClosingBalance, rpt.TotalBalance)
+	}
+}
+
+func TestGetRangeBalance_WeeksStartOnMonday(t *testing.T) {
+	svc := report.NewService(&fakeRepo{})
+
+	// 2026-01-01 is a Thursday; the range spans the end of ISO week 1 and week 2.
+	rpt, err := svc.GetRangeBalance(context.Background(), day(2026, 1, 1), day(2026, 1, 11), report.GroupWeek, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(rpt.Periods) != 2 || rpt.Periods[0].Label != "2026-W01" || rpt.Periods[1].Label != "2026-W02" {
+		t.Fatalf("unexpected weeks: %+v", rpt.Periods)
+	}
+	if !rpt.Periods[1].Start.Equal(day(2026, 1, 5)) {
+		t.Fatalf("expected week 2 to start on Monday 5 Jan, got %s", rpt.Periods[1].Start)
+	}
+}
+
+func TestGetRangeBalance_Validation(t *testing.T) {
+	svc := report.NewService(&fakeRepo{})
+	ctx := context.Background()
+
+	if _, err := svc.GetRangeBalance(ctx, day(2026, 2, 1), day(2026, 1, 1), report.GroupMonth, ""); !errors.Is(err, report.ErrInvalidRange) {
+		t.Fatalf("expected ErrInvalidRange, got %v", err)
+	}
+	if _, err := svc.GetRangeBalance(ctx, day(2026, 1, 1), day(2026, 2, 1), "fortnight", ""); !errors.Is(err, report.ErrInvalidGranularity) {
+		t.Fatalf("expected ErrInvalidGranularity, got %v", err)
+	}
+	if _, err := svc.GetRangeBalance(ctx, day(2026, 1, 1), day(2026, 2, 1), report.GroupMonth, "deferred"); !errors.Is(err, report.ErrInvalidBasis) {
+		t.Fatalf("expected ErrInvalidBasis, got %v", err)
+	}
+	rpt, err := svc.GetRangeBalance(ctx, day(2026, 1, 1), day(2026, 12, 31), "", "")
+	if err != nil || rpt.GroupBy != report.GroupMonth || len(rpt.Periods) != 12 || rpt.Basis != report.BasisCash {
+		t.Fatalf("expected monthly grouping on a cash basis by default, got %v %+v", err, rpt)
+	}
+}
+
+func TestGetRangeBalance_AccrualBasis(t *testing.T) {
+	repo := &fakeRepo{
+		// 500 paid in February for January, 300 paid in January for March.
+		dayIncome:   []report.DayAggregate{{Date: day(2026, 1, 10), Amount: 300}, {Date: day(2026, 2, 3), Amount: 500}},
+		dayAccrual:  []report.DayAggregate{{Date: day(2026, 1, 1), Amount: 500}, {Date: day(2026, 3, 1), Amount: 300}},
+		before:      1000,
+		shiftBefore: 150,
+	}
+	svc := report.NewService(repo)
+
+	rpt, err := svc.GetRangeBalance(context.Background(), day(2026, 1, 1), day(2026, 3, 31), report.GroupMonth, report.BasisAccrual)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if rpt.Basis != report.BasisAccrual || rpt.OpeningBalance != 1150 {
+		t.Fatalf("expected the accrual opening to include the shift, got %s %v", rpt.Basis, rpt.OpeningBalance)
+	}
+	want := []float64{500, 0, 300}
+	for i, p := range rpt.Periods {
+		if p.Income != want[i] {
+			t.Errorf("%s: expected income %v, got %v", p.Label, want[i], p.Income)
+		}
+	}
+	if rpt.ClosingBalance != 1950 {
+		t.Errorf("expected closing balance 1950, got %v", rpt.ClosingBalance)
+	}
+
+	cash, err := svc.GetRangeBalance(context.Background(), day(2026, 1, 1), day(2026, 3, 31), report.GroupMonth, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cash.OpeningBalance != 1000 || cash.Periods[0].Income != 300 || cash.Periods[1].Income != 500 {
+		t.Errorf("unexpected cash figures: %+v", cash)
+	}
+}
+
+func TestGetYearComparison_DefaultsToPreviousYear(t *testing.T) {
+	repo := &fakeRepo{byYear: map[int][2][]report.MonthAggregate{
+		2026: {{{Month: 3, Amount: 1200}}, {{Month: 3, Amount: 400}}},
+		2025: {{{Month: 3, Amount: 1000}}, {{Month: 3, Amount: 500}}},
+	}}
+	svc := report.NewService(repo)
+
+	rpt, err := svc.GetYearComparison(context.Background(), 2026, 0, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(rpt.Years) != 2 || rpt.Years[0] != 2026 || rpt.Years[1] != 2025 {
+		t.Fatalf("unexpected years: %v", rpt.Years)
+	}
+	march := rpt.Months[2].Years
+	if march[0].IncomeDelta != 0 || march[1].Year != 2025 {
+		t.Fatalf("unexpected March figures: %+v", march)
+	}
+	prev := march[1]
+	if prev.IncomeDelta != 200 || prev.ExpensesDelta != -100 || prev.BalanceDelta != 300 {
+		t.Fatalf("unexpected deltas: %+v", prev)
+	}
+	if prev.IncomeDeltaPct == nil || *prev.IncomeDeltaPct != 20 {
+		t.Fatalf("expected a 20%% income increase, got %v", prev.IncomeDeltaPct)
+	}
+	if rpt.Months[0].Years[1].IncomeDeltaPct != nil {
+		t.Fatalf("expected no percentage without base income")
+	}
+	if rpt.Totals[1].BalanceDelta != 300 {
+		t.Fatalf("unexpected total delta: %+v", rpt.Totals[1])
+	}
+}
+
+func TestGetCollectionRate_CountsPayersAndListsNonPayers(t *testing.T) {
+	repo := &fakeRepo{
+		roster: []report.RosterHouse{
+			{ContributorID: 1, HouseNumber: "A-1", Name: "Ana"},
+			{ContributorID: 2, HouseNumber: "A-2", Name: "Beto"},
+			{ContributorID: 3, HouseNumber: "A-3", Name: "Carla"},
+		},
+		categories: []report.CollectionCategory{{ID: 7, Name: "Vigilancia"}, {ID: 8, Name: "Jardinería"}},
+		marks: []report.PaidMark{
+			{ContributorID: 1, CategoryID: 7, Month: 1},
+			{ContributorID: 3, CategoryID: 7, Month: 1},
+			{ContributorID: 2, CategoryID: 8, Month: 1},
+		},
+	}
+	svc := report.NewService(repo)
+
+	rpt, err := svc.GetCollectionRate(context.Background(), 2025, 7)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(rpt.Categories) != 1 || rpt.Categories[0].CategoryName != "Vigilancia" {
+		t.Fatalf("expected only the requested category, got %+v", rpt.Categories)
+	}
+	jan := rpt.Categories[0].Months[0]
+	if jan.Expected != 3 || jan.Paid != 2 || jan.Rate != 66.67 {
+		t.Fatalf("unexpected January figures: %+v", jan)
+	}
+	if len(jan.NonPayers) != 1 || jan.NonPayers[0].HouseNumber != "A-2" {
+		t.Fatalf("expected A-2 as the only non-payer, got %+v", jan.NonPayers)
+	}
+	feb := rpt.Categories[0].Months[1]
+	if feb.Paid != 0 || len(feb.NonPayers) != 3 || feb.Rate != 0 {
+		t.Fatalf("unexpected February figures: %+v", feb)
+	}
+	if rpt.Categories[0].Expected != 36 || rpt.Categories[0].Paid != 2 {
+		t.Fatalf("unexpected yearly figures: %+v", rpt.Categories[0])
+	}
+
+	all, _ := svc.GetCollectionRate(context.Background(), 2025, 0)
+	if len(all.Categories) != 2 {
+		t.Fatalf("expected every category without a filter, got %d", len(all.Categories))
+	}
+}
+
+func TestGetCollectionRate_StopsAtCurrentMonth(t *testing.T) {
+	repo := &fakeRepo{
+		roster:     []report.RosterHouse{{ContributorID: 1, HouseNumber: "A-1"}, {ContributorID: 2, HouseNumber: "A-2"}},
+		categories: []report.CollectionCategory{{ID: 7, Name: "Vigilancia"}},
+	}
+	svc := report.NewService(repo)
+	now := time.Now()
+
+	rpt, err := svc.GetCollectionRate(context.Background(), now.Year(), 7)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	months := rpt.Categories[0].Months
+	if len(months) != int(now.Month()) || months[len(months)-1].Month != int(now.Month()) {
+		t.Fatalf("expected months up to %d, got %d", now.Month(), len(months))
+	}
+	if rpt.Categories[0].Expected != 2*int(now.Month()) {
+		t.Fatalf("expected no payments due after the current month, got %d", rpt.Categories[0].Expected)
+	}
+
+	next, _ := svc.GetCollectionRate(context.Background(), now.Year()+1, 7)
+	if len(next.Categories[0].Months) != 0 || next.Categories[0].Expected != 0 {
+		t.Fatalf("expected nothing due next year, got %+v", next.Categories[0])
+	}
+}
+
+func TestGetCollectionRate_ExcludesHousesBeforeRegistration(t *testing.T) {
+	repo := &fakeRepo{
+		roster: []report.RosterHouse{
+			{ContributorID: 1, HouseNumber: "A-1", Since: day(2024, 6, 1)},
+			// Registered mid-April: April is the first month it owes.
+			{ContributorID: 2, HouseNumber: "A-2", Since: day(2025, 4, 15)},
+		},
+		categories: []report.CollectionCategory{{ID: 7, Name: "Vigilancia"}},
+	}
+	svc := report.NewService(repo)
+
+	rpt, err := svc.GetCollectionRate(context.Background(), 2025, 7)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	months := rpt.Categories[0].Months
+	mar, apr := months[2], months[3]
+	if mar.Expected != 1 || len(mar.NonPayers) != 1 || mar.NonPayers[0].HouseNumber != "A-1" {
+		t.Fatalf("expected only A-1 due in March, got %+v", mar)
+	}
+	if apr.Expected != 2 || len(apr.NonPayers) != 2 {
+		t.Fatalf("expected both houses due in April, got %+v", apr)
+	}
+	// A-1: 12 months; A-2: April to December.
+	if rpt.Categories[0].Expected != 21 {
+		t.Fatalf("expected 21 payments due, got %d", rpt.Categories[0].Expected)
+	}
+}
+
+func TestGetForecast_ProjectsMonthEndBalances(t *testing.T) {
+	repo := &fakeRepo{
+		before: 10000,
+		roster: []report.RosterHouse{{ContributorID: 1}, {ContributorID: 2}, {ContributorID: 3}, {ContributorID: 4}},
+		// 4 houses x 12 months = 48 expected payments; 36 were made (75%).
+		history:   []report.CategoryHistory{{CategoryID: 7, CategoryName: "Vigilancia", AverageAmount: 500, Payments: 36}},
+		expTotals: []report.ExpenseCategoryTotal{{CategoryID: 1, CategoryName: "Vigilancia", Amount: 14400}},
+	}
+	svc := report.NewService(repo)
+	asOf := day(2026, 10, 19)
+
+	rpt, err := svc.GetForecast(context.Background(), asOf, report.ForecastScenario{
+		Months:                 3,
+		WeightByCollectionRate: true,
+		OneOffExpenses:         []report.ForecastItem{{Description: "Impermeabilización", Amount: 5000, Month: day(2026, 12, 1)}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if rpt.Income[0].CollectionRate != 75 || rpt.Income[0].MonthlyExpected != 1500 {
+		t.Fatalf("unexpected income line: %+v", rpt.Income[0])
+	}
+	if len(rpt.RecurringExpenses) != 1 || rpt.RecurringExpenses[0].Amount != 1200 {
+		t.Fatalf("expected historical recurring expenses of 1200, got %+v", rpt.RecurringExpenses)
+	}
+	if len(rpt.Months) != 3 || rpt.Months[0].Month != 11 || rpt.Months[2].Year != 2027 {
+		t.Fatalf("expected November 2026 to January 2027, got %+v", rpt.Months)
+	}
+	// 10000 +300 = 10300 (Nov), +300 -5000 = 5600 (Dec), +300 = 5900 (Jan).
+	if rpt.Months[1].ClosingBalance != 5600 || rpt.Months[2].ClosingBalance != 5900 {
+		t.Fatalf("unexpected balances: %+v", rpt.Months)
+	}
+	if rpt.LowestBalance != 5600 || rpt.LowestMonth != "2026-12" {
+		t.Fatalf("unexpected lowest balance: %f in %s", rpt.LowestBalance, rpt.LowestMonth)
+	}
+
+	unweighted, _ := svc.GetForecast(context.Background(), asOf, report.ForecastScenario{
+		RecurringExpenses: []report.ForecastItem{},
+	})
+	if len(unweighted.Months) != report.DefaultForecastMonths || unweighted.Income[0].MonthlyExpected != 2000 {
+		t.Fatalf("unexpected unweighted forecast: %+v", unweighted.Income)
+	}
+	if unweighted.Months[0].RecurringExpenses != 0 {
+		t.Fatalf("expected an explicit empty list to drop recurring expenses")
+	}
+}
+
+func TestGetForecast_Validation(t *testing.T) {
+	svc := report.NewService(&fakeRepo{})
+	ctx := context.Background()
+
+	if _, err := svc.GetForecast(ctx, day(2026, 10, 1), report.ForecastScenario{Months: 24}); !errors.Is(err, report.ErrInvalidHorizon) {
+		t.Fatalf("expected ErrInvalidHorizon, got %v", err)
+	}
+	sc := report.ForecastScenario{OneOffExpenses: []report.ForecastItem{{Description: "Bomba", Amount: 0}}}
+	if _, err := svc.GetForecast(ctx, day(2026, 10, 1), sc); !errors.Is(err, report.ErrInvalidItem) {
+		t.Fatalf("expected ErrInvalidItem, got %v", err)
+	}
+	if _, err := svc.GetForecast(ctx, day(2026, 10, 1), report.ForecastScenario{IncomeAdjustmentPct: -150}); !errors.Is(err, report.ErrInvalidAdjustment) {
+		t.Fatalf("expected ErrInvalidAdjustment, got %v", err)
+	}
+
+	// The default six-month horizon from October 2026 runs November to April.
+	months := []struct {
+		name  string
+		month time.Time
+		want  error
+	}{
+		{"no month", time.Time{}, report.ErrInvalidItemMonth},
+		{"current month", day(2026, 10, 20), report.ErrInvalidItemMonth},
+		{"first forecast month", day(2026, 11, 1), nil},
+		{"last forecast month", day(2027, 4, 30), nil},
+		{"past the horizon", day(2027, 5, 1), report.ErrInvalidItemMonth},
+	}
+	for _, tt := range months {
+		t.Run(tt.name, func(t *testing.T) {
+			sc := report.ForecastScenario{OneOffExpenses: []report.ForecastItem{{Description: "Bomba", Amount: 100, Month: tt.month}}}
+			if _, err := svc.GetForecast(ctx, day(2026, 10, 1), sc); !errors.Is(err, tt.want) {
+				t.Fatalf("expected %v, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestGetSupplierSpending_SortsAndSeparatesUnassigned(t *testing.T) {
+	repo := &fakeRepo{
+		suppliers: []report.SupplierAggregate{
+			{SupplierID: 0, Count: 2, Amount: 150},
+			{SupplierID: 1, SupplierName: "Jardinero", Count: 12, Amount: 9600},
+			{SupplierID: 2, SupplierName: "Ferretería", RFC: "FER010101AB1", Count: 3, Amount: 12000},
+		},
+	}
+	svc := report.NewService(repo)
+	rpt, err := svc.GetSupplierSpending(context.Background(), 2026)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(rpt.Suppliers) != 2 {
+		t.Fatalf("expected 2 suppliers, got %d", len(rpt.Suppliers))
+	}
+	if rpt.Suppliers[0].SupplierID != 2 || rpt.Suppliers[1].SupplierID != 1 {
+		t.Fatalf("expected suppliers sorted by total desc, got %+v", rpt.Suppliers)
+	}
+	if rpt.Unassigned != 150 {
+		t.Fatalf("expected unassigned 150, got %f", rpt.Unassigned)
+	}
+	if rpt.Total != 21750 {
+		t.Fatalf("expected total 21750, got %f", rpt.Total)
+	}
+}
+
+func TestGetBudgetVariance(t *testing.T) {
+	repo := &fakeRepo{
+		budget: []report.BudgetAggregate{
+			{Kind: "income", CategoryID: 1, CategoryName: "Cuota", Amount: 1200},
+			{Kind: "expense", CategoryID: 7, CategoryName: "Vigilancia", Amount: 600},
+		},
+		incomeCat: []report.CategoryMonthAggregate{
+			{CategoryID: 1, CategoryName: "Cuota", Month: 1, Amount: 500},
+			{CategoryID: 1, CategoryName: "Cuota", Month: 2, Amount: 400},
+		},
+		expenseCat: []report.CategoryMonthAggregate{
+			{CategoryID: 7, CategoryName: "Vigilancia", Month: 1, Amount: 660},
+			{CategoryID: 9, CategoryName: "Jardinería", Month: 3, Amount: 80},
+		},
+	}
+	svc := report.NewService(repo)
+
+	rpt, err := svc.GetBudgetVariance(context.Background(), 2026, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if rpt.Basis != report.BasisCash {
+		t.Errorf("expected cash basis by default, got %q", rpt.Basis)
+	}
+	if len(rpt.Income) != 1 || rpt.Income[0].Variance != -300 || *rpt.Income[0].VariancePct != -25 {
+		t.Errorf("unexpected income variance: %+v", rpt.Income)
+	}
+	if len(rpt.Expenses) != 2 {
+		t.Fatalf("expected budgeted and unbudgeted expense rows, got %d", len(rpt.Expenses))
+	}
+	if v := rpt.Expenses[0]; v.Variance != 60 || *v.VariancePct != 10 {
+		t.Errorf("unexpected expense variance: %+v", v)
+	}
+	if v := rpt.Expenses[1]; v.Budgeted != 0 || v.Variance != 80 || v.VariancePct != nil {
+		t.Errorf("unbudgeted category should have no percentage: %+v", v)
+	}
+	if rpt.BudgetedExpenses != 600 || rpt.ActualExpenses != 740 {
+		t.Errorf("unexpected expense totals: %v / %v", rpt.BudgetedExpenses, rpt.ActualExpenses)
+	}
+}
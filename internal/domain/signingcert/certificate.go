@@ -0,0 +1,116 @@
+package signingcert
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/ivsanmendez/ControlDeContabilidad/internal/domain/receipt"
+)
+
+var (
+	ErrNotFound            = errors.New("signing certificate not found")
+	ErrNoCertificate       = errors.New("user has no active signing certificate")
+	ErrSelectionRequired   = errors.New("user has several active signing certificates; certificate_id is required")
+	ErrInvalidUserID       = errors.New("user ID must be positive")
+	ErrCertificateRequired = errors.New("certificate is required")
+	ErrKeyRequired         = errors.New("private key is required")
+	ErrInvalidCertificate  = errors.New("certificate is neither valid PEM nor DER X.509")
+	ErrKeyNotEncrypted     = errors.New("private key must be uploaded encrypted, as issued by SAT")
+	ErrDuplicate           = errors.New("certificate is already active for this user")
+	ErrInactive            = errors.New("signing certificate is deactivated")
+	ErrNotHolder           = errors.New("signing certificate belongs to another user")
+)
+
+// Certificate is a user's e.firma: the X.509 certificate and its private
+// key, kept encrypted exactly as uploaded. Only the holder's password, given
+// at signing time, decrypts it. Deactivated certificates are kept so the
+// history of who could sign stays auditable; receipts carry their own copy
+// of the certificate, so deactivation never affects their verification.
+type Certificate struct {
+	ID             int64
+	UserID         int64
+	Label          string
+	Serial         string
+	RFC            string
+	Holder         string
+	CertificateDER []byte
+	EncryptedKey   []byte
+	NotBefore      time.Time
+	NotAfter       time.Time
+	UploadedBy     int64
+	UploadedAt     time.Time
+	DeactivatedBy  *int64
+	DeactivatedAt  *time.Time
+}
+
+// Active reports whether the certificate may still be selected for signing.
+func (c *Certificate) Active() bool {
+	return c.DeactivatedAt == nil
+}
+
+// New creates a Certificate enforcing domain invariants. The certificate may
+// be PEM or DER and must pass Validate against trust; the key is stored as
+// given and must be encrypted.
+func New(uploadedBy, userID int64, label string, cert, key []byte, trust *TrustStore) (*Certificate, error) {
+	if uploadedBy <= 0 || userID <= 0 {
+		return nil, ErrInvalidUserID
+	}
+	if len(cert) == 0 {
+		return nil, ErrCertificateRequired
+	}
+	if len(key) == 0 {
+		return nil, ErrKeyRequired
+	}
+
+	der := cert
+	if block, _ := pem.Decode(cert); block != nil && block.Type == "CERTIFICATE" {
+		der = block.Bytes
+	}
+	serial, err := receipt.CertificateSerial(der)
+	if err != nil {
+		return nil, ErrInvalidCertificate
+	}
+	if plaintextKey(key) {
+		return nil, ErrKeyNotEncrypted
+	}
+	id, err := Validate(der, trust, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	return &Certificate{
+		UserID:         userID,
+		Label:          strings.TrimSpace(label),
+		Serial:         serial,
+		RFC:            id.RFC,
+		Holder:         id.Name,
+		CertificateDER: der,
+		EncryptedKey:   key,
+		NotBefore:      id.NotBefore,
+		NotAfter:       id.NotAfter,
+		UploadedBy:     uploadedBy,
+		UploadedAt:     time.Now(),
+	}, nil
+}
+
+// plaintextKey reports whether key parses without a password.
+func plaintextKey(key []byte) bool {
+	der := key
+	if block, _ := pem.Decode(key); block != nil {
+		if block.Type == "ENCRYPTED PRIVATE KEY" {
+			return false
+		}
+		der = block.Bytes
+	}
+	if _, err := x509.ParsePKCS8PrivateKey(der); err == nil {
+		return true
+	}
+	if _, err := x509.ParsePKCS1PrivateKey(der); err == nil {
+		return true
+	}
+	_, err := x509.ParseECPrivateKey(der)
+	return err == nil
+}
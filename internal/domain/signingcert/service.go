@@ -0,0 +1,158 @@
+package signingcert
+
+import (
+	"context"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/ivsanmendez/ControlDeContabilidad/internal/domain/user"
+)
+
+// Repository is the outbound port for signing certificate persistence.
+type Repository interface {
+	// Save returns ErrDuplicate if the user already has the certificate active.
+	Save(ctx context.Context, c *Certificate) error
+	FindByID(ctx context.Context, id int64) (*Certificate, error)
+	// List returns the certificates of userID, or of every user when 0,
+	// deactivated ones included.
+	List(ctx context.Context, userID int64) ([]Certificate, error)
+	// Deactivate returns ErrInactive if the certificate was already deactivated.
+	Deactivate(ctx context.Context, c *Certificate) error
+}
+
+// Signer signs with one stored certificate. The key is decrypted with the
+// password on each Sign, or once by Unlock for a batch.
+type Signer interface {
+	Sign(data []byte, password string) ([]byte, error)
+	Unlock(password string) (func(data []byte) ([]byte, error), error)
+	Certificate() []byte
+	Available() bool
+}
+
+// Opener builds a Signer from a stored certificate and its encrypted key.
+type Opener func(certDER, encryptedKey []byte) (Signer, error)
+
+// Service manages per-user signing certificates. A nil trust store skips
+// only the SAT chain check.
+type Service struct {
+	repo  Repository
+	open  Opener
+	trust *TrustStore
+	audit user.AuditLogger
+}
+
+func NewService(repo Repository, open Opener, trust *TrustStore, audit user.AuditLogger) *Service {
+	return &Service{repo: repo, open: open, trust: trust, audit: audit}
+}
+
+// Upload registers a certificate for userID. The key is stored still encrypted.
+func (s *Service) Upload(ctx context.Context, callerID, userID int64, label string, cert, key []byte, info user.AuditInfo) (*Certificate, error) {
+	c, err := New(callerID, userID, label, cert, key, s.trust)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.Save(ctx, c); err != nil {
+		return nil, err
+	}
+	s.logAudit(ctx, callerID, user.AuditCertificateUpload, info, c)
+	return c, nil
+}
+
+// ListCertificates returns the certificates of userID, or of everyone when 0.
+func (s *Service) ListCertificates(ctx context.Context, userID int64) ([]Certificate, error) {
+	if userID < 0 {
+		return nil, ErrInvalidUserID
+	}
+	return s.repo.List(ctx, userID)
+}
+
+// Deactivate withdraws a certificate from signing. Receipts it already signed
+// stay valid: they embed the certificate and are verified against that copy.
+func (s *Service) Deactivate(ctx context.Context, callerID, id int64, info user.AuditInfo) (*Certificate, error) {
+	if callerID <= 0 {
+		return nil, ErrInvalidUserID
+	}
+	c, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !c.Active() {
+		return nil, ErrInactive
+	}
+
+	now := time.Now()
+	c.DeactivatedBy = &callerID
+	c.DeactivatedAt = &now
+	if err := s.repo.Deactivate(ctx, c); err != nil {
+		return nil, err
+	}
+	s.logAudit(ctx, callerID, user.AuditCertificateDeactivate, info, c)
+	return c, nil
+}
+
+// SignerFor selects the certificate userID signs with. With certID 0 the
+// user's only active certificate is used; ErrNoCertificate lets the caller
+// fall back to the community-wide certificate. A certificate that expired or
+// lost its trust chain since upload is refused.
+func (s *Service) SignerFor(ctx context.Context, userID, certID int64) (Signer, error) {
+	c, err := s.selectCertificate(ctx, userID, certID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := Validate(c.CertificateDER, s.trust, time.Now()); err != nil {
+		return nil, err
+	}
+	return s.open(c.CertificateDER, c.EncryptedKey)
+}
+
+func (s *Service) selectCertificate(ctx context.Context, userID, certID int64) (*Certificate, error) {
+	if userID <= 0 {
+		return nil, ErrInvalidUserID
+	}
+	if certID != 0 {
+		c, err := s.repo.FindByID(ctx, certID)
+		if err != nil {
+			return nil, err
+		}
+		if c.UserID != userID {
+			return nil, ErrNotHolder
+		}
+		if !c.Active() {
+			return nil, ErrInactive
+		}
+		return c, nil
+	}
+
+	all, err := s.repo.List(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	var active []Certificate
+	for _, c := range all {
+		if c.Active() {
+			active = append(active, c)
+		}
+	}
+	switch len(active) {
+	case 0:
+		return nil, ErrNoCertificate
+	case 1:
+		return &active[0], nil
+	default:
+		return nil, ErrSelectionRequired
+	}
+}
+
+// logAudit fires-and-forgets an audit entry. Errors are logged but never returned.
+func (s *Service) logAudit(ctx context.Context, userID int64, action user.AuditAction, info user.AuditInfo, c *Certificate) {
+	metadata := map[string]string{
+		"certificate_id": strconv.FormatInt(c.ID, 10),
+		"holder_user_id": strconv.FormatInt(c.UserID, 10),
+		"serial":         c.Serial,
+	}
+	entry := user.NewAuditEntry(&userID, action, info, metadata)
+	if err := s.audit.Log(ctx, entry); err != nil {
+		log.Printf("audit log error: %v", err)
+	}
+}
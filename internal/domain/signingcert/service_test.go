@@ -0,0 +1,256 @@
+package signingcert_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ivsanmendez/ControlDeContabilidad/internal/domain/signingcert"
+	"github.com/ivsanmendez/ControlDeContabilidad/internal/domain/user"
+)
+
+// --- Fakes ---
+
+type fakeRepo struct {
+	certs []signingcert.Certificate
+}
+
+func (r *fakeRepo) Save(_ context.Context, c *signingcert.Certificate) error {
+	for _, existing := range r.certs {
+		if existing.UserID == c.UserID && existing.Serial == c.Serial && existing.Active() {
+			return signingcert.ErrDuplicate
+		}
+	}
+	c.ID = int64(len(r.certs) + 1)
+	r.certs = append(r.certs, *c)
+	return nil
+}
+
+func (r *fakeRepo) FindByID(_ context.Context, id int64) (*signingcert.Certificate, error) {
+	if id < 1 || int(id) > len(r.certs) {
+		return nil, signingcert.ErrNotFound
+	}
+	c := r.certs[id-1]
+	return &c, nil
+}
+
+func (r *fakeRepo) List(_ context.Context, userID int64) ([]signingcert.Certificate, error) {
+	var result []signingcert.Certificate
+	for _, c := range r.certs {
+		if userID == 0 || c.UserID == userID {
+			result = append(result, c)
+		}
+	}
+	return result, nil
+}
+
+func (r *fakeRepo) Deactivate(_ context.Context, c *signingcert.Certificate) error {
+	r.certs[c.ID-1] = *c
+	return nil
+}
+
+type fakeAudit struct {
+	entries []user.AuditEntry
+}
+
+func (a *fakeAudit) Log(_ context.Context, e user.AuditEntry) error {
+	a.entries = append(a.entries, e)
+	return nil
+}
+
+type fakeSigner struct {
+	cert, key []byte
+}
+
+func (s *fakeSigner) Sign([]byte, string) ([]byte, error) { return nil, nil }
+func (s *fakeSigner) Unlock(string) (func([]byte) ([]byte, error), error) {
+	return nil, nil
+}
+func (s *fakeSigner) Certificate() []byte { return s.cert }
+func (s *fakeSigner) Available() bool     { return true }
+
+func openFake(cert, key []byte) (signingcert.Signer, error) {
+	return &fakeSigner{cert: cert, key: key}, nil
+}
+
+var (
+	ctx  = context.Background()
+	info = user.AuditInfo{IP: "127.0.0.1", UserAgent: "test"}
+	// encryptedKey stands in for a SAT .key; it does not parse without a password.
+	encryptedKey = []byte("encrypted PKCS#8 key bytes")
+)
+
+func certificate(t *testing.T, serial int64, name string) ([]byte, *rsa.PrivateKey) {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(365 * 24 * time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("create certificate: %v", err)
+	}
+	return der, key
+}
+
+func TestNew(t *testing.T) {
+	der, key := certificate(t, 1, "TESORERA")
+	plainKey, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatalf("marshal key: %v", err)
+	}
+	pemCert := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	pemPlainKey := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: plainKey})
+	pemEncKey := pem.EncodeToMemory(&pem.Block{Type: "ENCRYPTED PRIVATE KEY", Bytes: encryptedKey})
+
+	tests := []struct {
+		name     string
+		by, user int64
+		cert     []byte
+		key      []byte
+		want     error
+	}{
+		{"DER certificate", 1, 2, der, encryptedKey, nil},
+		{"PEM certificate and key", 1, 2, pemCert, pemEncKey, nil},
+		{"no uploader", 0, 2, der, encryptedKey, signingcert.ErrInvalidUserID},
+		{"no holder", 1, 0, der, encryptedKey, signingcert.ErrInvalidUserID},
+		{"no certificate", 1, 2, nil, encryptedKey, signingcert.ErrCertificateRequired},
+		{"no key", 1, 2, der, nil, signingcert.ErrKeyRequired},
+		{"garbage certificate", 1, 2, []byte("not a cert"), encryptedKey, signingcert.ErrInvalidCertificate},
+		{"plaintext DER key", 1, 2, der, plainKey, signingcert.ErrKeyNotEncrypted},
+		{"plaintext PEM key", 1, 2, der, pemPlainKey, signingcert.ErrKeyNotEncrypted},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := signingcert.New(tt.by, tt.user, " Tesorería 2026 ", tt.cert, tt.key, nil)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("expected %v, got %v", tt.want, err)
+			}
+			if err != nil {
+				return
+			}
+			if c.Holder != "TESORERA" || c.Label != "Tesorería 2026" || !c.Active() {
+				t.Errorf("unexpected certificate: %+v", c)
+			}
+			if string(c.CertificateDER) != string(der) {
+				t.Error("expected the certificate normalized to DER")
+			}
+			if string(c.EncryptedKey) != string(tt.key) {
+				t.Error("expected the key stored exactly as uploaded")
+			}
+		})
+	}
+}
+
+func TestUploadAndDeactivate(t *testing.T) {
+	repo, audit := &fakeRepo{}, &fakeAudit{}
+	svc := signingcert.NewService(repo, openFake, nil, audit)
+	der, _ := certificate(t, 7, "PRESIDENTE")
+
+	c, err := svc.Upload(ctx, 1, 5, "", der, encryptedKey, info)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := svc.Upload(ctx, 1, 5, "", der, encryptedKey, info); !errors.Is(err, signingcert.ErrDuplicate) {
+		t.Errorf("expected ErrDuplicate, got %v", err)
+	}
+
+	d, err := svc.Deactivate(ctx, 1, c.ID, info)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if d.Active() || *d.DeactivatedBy != 1 {
+		t.Errorf("expected deactivated certificate, got %+v", d)
+	}
+	if _, err := svc.Deactivate(ctx, 1, c.ID, info); !errors.Is(err, signingcert.ErrInactive) {
+		t.Errorf("expected ErrInactive, got %v", err)
+	}
+
+	// A deactivated certificate can be uploaded again.
+	if _, err := svc.Upload(ctx, 1, 5, "", der, encryptedKey, info); err != nil {
+		t.Errorf("expected re-upload after deactivation, got %v", err)
+	}
+
+	var actions []user.AuditAction
+	for _, e := range audit.entries {
+		actions = append(actions, e.Action)
+	}
+	want := []user.AuditAction{user.AuditCertificateUpload, user.AuditCertificateDeactivate, user.AuditCertificateUpload}
+	if len(actions) != len(want) {
+		t.Fatalf("expected audit %v, got %v", want, actions)
+	}
+	for i := range want {
+		if actions[i] != want[i] {
+			t.Errorf("expected audit %v, got %v", want, actions)
+		}
+	}
+}
+
+func TestSignerFor(t *testing.T) {
+	treasurer, _ := certificate(t, 1, "TESORERA")
+	president, _ := certificate(t, 2, "PRESIDENTE")
+	second, _ := certificate(t, 3, "PRESIDENTE")
+
+	repo := &fakeRepo{}
+	svc := signingcert.NewService(repo, openFake, nil, &fakeAudit{})
+	mustUpload := func(userID int64, der []byte) *signingcert.Certificate {
+		c, err := svc.Upload(ctx, 1, userID, "", der, encryptedKey, info)
+		if err != nil {
+			t.Fatalf("upload: %v", err)
+		}
+		return c
+	}
+	tc := mustUpload(10, treasurer)
+	pc := mustUpload(20, president)
+	sc := mustUpload(20, second)
+	if _, err := svc.Deactivate(ctx, 1, sc.ID, info); err != nil {
+		t.Fatalf("deactivate: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		user   int64
+		certID int64
+		want   []byte
+		err    error
+	}{
+		{"only active certificate", 10, 0, treasurer, nil},
+		{"deactivated ones are not counted", 20, 0, president, nil},
+		{"explicit choice", 20, pc.ID, president, nil},
+		{"no certificate", 30, 0, nil, signingcert.ErrNoCertificate},
+		{"someone else's", 10, pc.ID, nil, signingcert.ErrNotHolder},
+		{"deactivated", 20, sc.ID, nil, signingcert.ErrInactive},
+		{"unknown", 10, 99, nil, signingcert.ErrNotFound},
+		{"no user", 0, tc.ID, nil, signingcert.ErrInvalidUserID},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := svc.SignerFor(ctx, tt.user, tt.certID)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("expected %v, got %v", tt.err, err)
+			}
+			if err == nil && string(s.Certificate()) != string(tt.want) {
+				t.Error("selected the wrong certificate")
+			}
+		})
+	}
+
+	// A second active certificate makes the choice explicit.
+	mustUpload(10, second)
+	if _, err := svc.SignerFor(ctx, 10, 0); !errors.Is(err, signingcert.ErrSelectionRequired) {
+		t.Errorf("expected ErrSelectionRequired, got %v", err)
+	}
+}
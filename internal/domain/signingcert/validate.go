@@ -0,0 +1,127 @@
+package signingcert
+
+import (
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+var (
+	ErrCertificateExpired     = errors.New("signing certificate has expired")
+	ErrCertificateNotYetValid = errors.New("signing certificate is not yet valid")
+	ErrCertificateKeyUsage    = errors.New("signing certificate is not enabled for digital signatures")
+	ErrCertificateUntrusted   = errors.New("signing certificate does not chain to a trusted SAT certificate")
+	// ErrKeyMismatch is returned by signers when the decrypted private key
+	// is not the one the certificate was issued for.
+	ErrKeyMismatch = errors.New("private key does not belong to the signing certificate")
+)
+
+// oidUniqueIdentifier is x500UniqueIdentifier, where SAT certificates carry
+// "RFC / RFC of the legal representative".
+var oidUniqueIdentifier = asn1.ObjectIdentifier{2, 5, 4, 45}
+
+// rfcPattern matches the RFC of a persona moral (12) or física (13).
+var rfcPattern = regexp.MustCompile(`^[A-ZÑ&]{3,4}[0-9]{6}[A-Z0-9]{3}$`)
+
+// TrustStore holds the SAT root and intermediate certificates a signing
+// certificate must chain to. Every certificate in it is a trust anchor, so
+// configuring only the issuing intermediate is enough.
+type TrustStore struct {
+	pool *x509.CertPool
+}
+
+// NewTrustStore parses PEM or DER certificates; a PEM input may hold several.
+func NewTrustStore(certs ...[]byte) (*TrustStore, error) {
+	pool := x509.NewCertPool()
+	for i, raw := range certs {
+		if !strings.Contains(string(raw), "-----BEGIN") {
+			c, err := x509.ParseCertificate(raw)
+			if err != nil {
+				return nil, fmt.Errorf("trust store certificate %d: %w", i+1, err)
+			}
+			pool.AddCert(c)
+			continue
+		}
+		for block, rest := pem.Decode(raw); block != nil; block, rest = pem.Decode(rest) {
+			if block.Type != "CERTIFICATE" {
+				continue
+			}
+			c, err := x509.ParseCertificate(block.Bytes)
+			if err != nil {
+				return nil, fmt.Errorf("trust store certificate %d: %w", i+1, err)
+			}
+			pool.AddCert(c)
+		}
+	}
+	return &TrustStore{pool: pool}, nil
+}
+
+// Identity is what a valid certificate says about its holder.
+type Identity struct {
+	RFC       string
+	Name      string
+	NotBefore time.Time
+	NotAfter  time.Time
+}
+
+// Validate checks that a certificate may sign at the given time: it must be
+// within its validity window, allow digital signatures and, when trust is
+// non-nil, chain to the trust store. A nil trust store skips only the chain.
+func Validate(der []byte, trust *TrustStore, at time.Time) (*Identity, error) {
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, ErrInvalidCertificate
+	}
+
+	if at.Before(cert.NotBefore) {
+		return nil, fmt.Errorf("%w: valid from %s", ErrCertificateNotYetValid, cert.NotBefore.UTC().Format(time.DateOnly))
+	}
+	if at.After(cert.NotAfter) {
+		return nil, fmt.Errorf("%w: expired on %s", ErrCertificateExpired, cert.NotAfter.UTC().Format(time.DateOnly))
+	}
+	// Without the extension every usage is allowed (RFC 5280 §4.2.1.3).
+	if cert.KeyUsage != 0 && cert.KeyUsage&(x509.KeyUsageDigitalSignature|x509.KeyUsageContentCommitment) == 0 {
+		return nil, ErrCertificateKeyUsage
+	}
+	if trust != nil {
+		_, err := cert.Verify(x509.VerifyOptions{
+			Roots:       trust.pool,
+			CurrentTime: at,
+			KeyUsages:   []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrCertificateUntrusted, err)
+		}
+	}
+
+	return &Identity{
+		RFC:       subjectRFC(cert),
+		Name:      cert.Subject.CommonName,
+		NotBefore: cert.NotBefore,
+		NotAfter:  cert.NotAfter,
+	}, nil
+}
+
+// subjectRFC returns the holder's RFC, or "" when the subject carries none.
+func subjectRFC(cert *x509.Certificate) string {
+	for _, attr := range cert.Subject.Names {
+		if !attr.Type.Equal(oidUniqueIdentifier) {
+			continue
+		}
+		v, ok := attr.Value.(string)
+		if !ok {
+			return ""
+		}
+		rfc, _, _ := strings.Cut(v, "/")
+		rfc = strings.ToUpper(strings.TrimSpace(rfc))
+		if rfcPattern.MatchString(rfc) {
+			return rfc
+		}
+	}
+	return ""
+}
@@ -0,0 +1,182 @@
+package signingcert_test
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ivsanmendez/ControlDeContabilidad/internal/domain/signingcert"
+)
+
+type issuer struct {
+	cert *x509.Certificate
+	der  []byte
+	key  *rsa.PrivateKey
+}
+
+func newCA(t *testing.T, serial int64, name string, parent *issuer) *issuer {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(serial),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-24 * time.Hour),
+		NotAfter:              time.Now().Add(10 * 365 * 24 * time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	parentCert, parentKey := tmpl, key
+	if parent != nil {
+		parentCert, parentKey = parent.cert, parent.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, parentCert, &key.PublicKey, parentKey)
+	if err != nil {
+		t.Fatalf("create certificate: %v", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("parse certificate: %v", err)
+	}
+	return &issuer{cert: cert, der: der, key: key}
+}
+
+// efirma issues a holder certificate the way SAT does: the RFC in
+// x500UniqueIdentifier and the name in the common name.
+func efirma(t *testing.T, ca *issuer, notBefore, notAfter time.Time, usage x509.KeyUsage) []byte {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(100),
+		Subject: pkix.Name{
+			CommonName: "MARIA GOMEZ LOPEZ",
+			ExtraNames: []pkix.AttributeTypeAndValue{
+				{Type: asn1.ObjectIdentifier{2, 5, 4, 45}, Value: "GOMA800101AB1 / "},
+			},
+		},
+		NotBefore: notBefore,
+		NotAfter:  notAfter,
+		KeyUsage:  usage,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, ca.cert, &key.PublicKey, ca.key)
+	if err != nil {
+		t.Fatalf("create certificate: %v", err)
+	}
+	return der
+}
+
+func trustStore(t *testing.T, certs ...[]byte) *signingcert.TrustStore {
+	t.Helper()
+	ts, err := signingcert.NewTrustStore(certs...)
+	if err != nil {
+		t.Fatalf("trust store: %v", err)
+	}
+	return ts
+}
+
+func TestValidate(t *testing.T) {
+	root := newCA(t, 1, "AC RAIZ SAT", nil)
+	intermediate := newCA(t, 2, "AC DEL SERVICIO DE ADMINISTRACION TRIBUTARIA", root)
+	other := newCA(t, 3, "OTRA AC", nil)
+
+	now := time.Now()
+	signing := x509.KeyUsageDigitalSignature | x509.KeyUsageContentCommitment
+	valid := efirma(t, intermediate, now.Add(-time.Hour), now.Add(365*24*time.Hour), signing)
+	expired := efirma(t, intermediate, now.Add(-48*time.Hour), now.Add(-24*time.Hour), signing)
+	future := efirma(t, intermediate, now.Add(24*time.Hour), now.Add(48*time.Hour), signing)
+	certSignOnly := efirma(t, intermediate, now.Add(-time.Hour), now.Add(time.Hour), x509.KeyUsageCertSign)
+
+	// Both as PEM bundle, the way SAT publishes them.
+	bundle := append(
+		pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: root.der}),
+		pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: intermediate.der})...,
+	)
+
+	tests := []struct {
+		name  string
+		der   []byte
+		trust *signingcert.TrustStore
+		want  error
+	}{
+		{"trusted via root and intermediate", valid, trustStore(t, bundle), nil},
+		{"trusted via intermediate only", valid, trustStore(t, intermediate.der), nil},
+		{"no trust store skips the chain", valid, nil, nil},
+		{"issued by another authority", valid, trustStore(t, other.der), signingcert.ErrCertificateUntrusted},
+		{"root alone lacks the intermediate", valid, trustStore(t, root.der), signingcert.ErrCertificateUntrusted},
+		{"expired", expired, trustStore(t, bundle), signingcert.ErrCertificateExpired},
+		{"not yet valid", future, trustStore(t, bundle), signingcert.ErrCertificateNotYetValid},
+		{"no digital signature usage", certSignOnly, nil, signingcert.ErrCertificateKeyUsage},
+		{"garbage", []byte("not a cert"), nil, signingcert.ErrInvalidCertificate},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id, err := signingcert.Validate(tt.der, tt.trust, now)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("expected %v, got %v", tt.want, err)
+			}
+			if err != nil {
+				return
+			}
+			if id.RFC != "GOMA800101AB1" || id.Name != "MARIA GOMEZ LOPEZ" {
+				t.Errorf("unexpected identity: %+v", id)
+			}
+		})
+	}
+}
+
+func TestNewTrustStore_Invalid(t *testing.T) {
+	if _, err := signingcert.NewTrustStore([]byte("not a cert")); err == nil {
+		t.Error("expected an error for an unparseable certificate")
+	}
+}
+
+func TestUpload_Validation(t *testing.T) {
+	ca := newCA(t, 1, "AC DEL SERVICIO DE ADMINISTRACION TRIBUTARIA", nil)
+	now := time.Now()
+	svc := signingcert.NewService(&fakeRepo{}, openFake, trustStore(t, ca.der), &fakeAudit{})
+
+	valid := efirma(t, ca, now.Add(-time.Hour), now.Add(time.Hour), x509.KeyUsageDigitalSignature)
+	c, err := svc.Upload(ctx, 1, 5, "", valid, encryptedKey, info)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if c.RFC != "GOMA800101AB1" || c.Holder != "MARIA GOMEZ LOPEZ" {
+		t.Errorf("expected the identity taken from the subject, got %+v", c)
+	}
+
+	expired := efirma(t, ca, now.Add(-48*time.Hour), now.Add(-24*time.Hour), x509.KeyUsageDigitalSignature)
+	if _, err := svc.Upload(ctx, 1, 5, "", expired, encryptedKey, info); !errors.Is(err, signingcert.ErrCertificateExpired) {
+		t.Errorf("expected ErrCertificateExpired, got %v", err)
+	}
+
+	selfSigned, _ := certificate(t, 9, "AJENO")
+	if _, err := svc.Upload(ctx, 1, 5, "", selfSigned, encryptedKey, info); !errors.Is(err, signingcert.ErrCertificateUntrusted) {
+		t.Errorf("expected ErrCertificateUntrusted, got %v", err)
+	}
+}
+
+func TestSignerFor_Expired(t *testing.T) {
+	ca := newCA(t, 1, "AC DEL SERVICIO DE ADMINISTRACION TRIBUTARIA", nil)
+	now := time.Now()
+	// Stored while valid; it has expired since.
+	expired := efirma(t, ca, now.Add(-48*time.Hour), now.Add(-24*time.Hour), x509.KeyUsageDigitalSignature)
+	repo := &fakeRepo{certs: []signingcert.Certificate{{ID: 1, UserID: 5, Serial: "100", CertificateDER: expired, EncryptedKey: encryptedKey}}}
+	svc := signingcert.NewService(repo, openFake, trustStore(t, ca.der), &fakeAudit{})
+
+	if _, err := svc.SignerFor(ctx, 5, 0); !errors.Is(err, signingcert.ErrCertificateExpired) {
+		t.Errorf("expected ErrCertificateExpired, got %v", err)
+	}
+}
@@ -0,0 +1,78 @@
+package supplier
+
+import (
+	"context"
+	"time"
+)
+
+// Repository is the outbound port for supplier persistence.
+type Repository interface {
+	Save(ctx context.Context, s *Supplier) error
+	FindByID(ctx context.Context, id int64) (*Supplier, error)
+	FindAll(ctx context.Context) ([]Supplier, error)
+	FindActive(ctx context.Context) ([]Supplier, error)
+	Update(ctx context.Context, s *Supplier) error
+	Delete(ctx context.Context, id int64) error
+}
+
+// Service orchestrates supplier use cases.
+type Service struct {
+	repo Repository
+}
+
+func NewService(repo Repository) *Service {
+	return &Service{repo: repo}
+}
+
+func (s *Service) CreateSupplier(ctx context.Context, callerID int64, d Details) (*Supplier, error) {
+	sp, err := New(callerID, d)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.Save(ctx, sp); err != nil {
+		return nil, err
+	}
+	return sp, nil
+}
+
+func (s *Service) GetSupplier(ctx context.Context, id int64) (*Supplier, error) {
+	return s.repo.FindByID(ctx, id)
+}
+
+func (s *Service) ListSuppliers(ctx context.Context) ([]Supplier, error) {
+	return s.repo.FindAll(ctx)
+}
+
+func (s *Service) ListActiveSuppliers(ctx context.Context) ([]Supplier, error) {
+	return s.repo.FindActive(ctx)
+}
+
+func (s *Service) UpdateSupplier(ctx context.Context, id int64, d Details, isActive bool) (*Supplier, error) {
+	sp, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	d, err = d.normalize()
+	if err != nil {
+		return nil, err
+	}
+
+	sp.Name = d.Name
+	sp.RFC = d.RFC
+	sp.ContactName = d.ContactName
+	sp.Phone = d.Phone
+	sp.Email = d.Email
+	sp.CLABE = d.CLABE
+	sp.DefaultCategoryID = d.DefaultCategoryID
+	sp.IsActive = isActive
+	sp.UpdatedAt = time.Now()
+
+	if err := s.repo.Update(ctx, sp); err != nil {
+		return nil, err
+	}
+	return sp, nil
+}
+
+func (s *Service) DeleteSupplier(ctx context.Context, id int64) error {
+	return s.repo.Delete(ctx, id)
+}
@@ -0,0 +1,122 @@
+package supplier
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+)
+
+var (
+	ErrNotFound          = errors.New("supplier not found")
+	ErrDuplicate         = errors.New("supplier with this RFC already exists")
+	ErrEmptyName         = errors.New("supplier name must not be empty")
+	ErrInvalidRFC        = errors.New("RFC must be 12 (persona moral) or 13 (persona física) characters")
+	ErrInvalidCLABE      = errors.New("CLABE must be 18 digits with a valid control digit")
+	ErrInvalidCategoryID = errors.New("default category ID must be positive")
+	ErrInvalidUserID     = errors.New("user ID must be positive")
+)
+
+// rfcPattern matches both persona moral (3 letters) and persona física (4 letters) RFCs.
+var rfcPattern = regexp.MustCompile(`^[A-ZÑ&]{3,4}[0-9]{6}[A-Z0-9]{3}$`)
+
+// Supplier is a vendor (proveedor) the community pays for goods or services.
+type Supplier struct {
+	ID                int64
+	Name              string
+	RFC               string
+	ContactName       string
+	Phone             string
+	Email             string
+	CLABE             string
+	DefaultCategoryID *int64
+	IsActive          bool
+	UserID            int64
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+}
+
+// Details groups the editable supplier fields.
+type Details struct {
+	Name              string
+	RFC               string
+	ContactName       string
+	Phone             string
+	Email             string
+	CLABE             string
+	DefaultCategoryID *int64
+}
+
+// New creates a Supplier enforcing domain invariants.
+func New(userID int64, d Details) (*Supplier, error) {
+	if userID <= 0 {
+		return nil, ErrInvalidUserID
+	}
+	d, err := d.normalize()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	return &Supplier{
+		Name:              d.Name,
+		RFC:               d.RFC,
+		ContactName:       d.ContactName,
+		Phone:             d.Phone,
+		Email:             d.Email,
+		CLABE:             d.CLABE,
+		DefaultCategoryID: d.DefaultCategoryID,
+		IsActive:          true,
+		UserID:            userID,
+		CreatedAt:         now,
+		UpdatedAt:         now,
+	}, nil
+}
+
+// normalize trims and upper-cases identifiers and validates the details.
+// RFC and CLABE are optional; when present they must be well formed.
+func (d Details) normalize() (Details, error) {
+	d.Name = strings.TrimSpace(d.Name)
+	d.RFC = strings.ToUpper(strings.TrimSpace(d.RFC))
+	d.CLABE = strings.TrimSpace(d.CLABE)
+
+	if d.Name == "" {
+		return d, ErrEmptyName
+	}
+	if d.RFC != "" && !ValidRFC(d.RFC) {
+		return d, ErrInvalidRFC
+	}
+	if d.CLABE != "" && !ValidCLABE(d.CLABE) {
+		return d, ErrInvalidCLABE
+	}
+	if d.DefaultCategoryID != nil && *d.DefaultCategoryID <= 0 {
+		return d, ErrInvalidCategoryID
+	}
+	return d, nil
+}
+
+// ValidRFC reports whether rfc has the shape of a Mexican tax ID (RFC).
+func ValidRFC(rfc string) bool {
+	return rfcPattern.MatchString(rfc)
+}
+
+// ValidCLABE reports whether clabe is an 18-digit interbank account number
+// whose last digit matches the Banxico control digit (weights 3, 7, 1).
+func ValidCLABE(clabe string) bool {
+	if len(clabe) != 18 {
+		return false
+	}
+	weights := [3]int{3, 7, 1}
+	sum := 0
+	for i := 0; i < 18; i++ {
+		c := clabe[i]
+		if c < '0' || c > '9' {
+			return false
+		}
+		if i < 17 {
+			sum += (int(c-'0') * weights[i%3]) % 10
+		}
+	}
+	control := (10 - sum%10) % 10
+	return int(clabe[17]-'0') == control
+}
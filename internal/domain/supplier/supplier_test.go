@@ -0,0 +1,82 @@
+package supplier_test
+
+import (
+	"testing"
+
+	"github.com/ivsanmendez/ControlDeContabilidad/internal/domain/supplier"
+)
+
+func TestNew_Valid(t *testing.T) {
+	catID := int64(3)
+	s, err := supplier.New(1, supplier.Details{
+		Name:              "  Jardinería Los Pinos ",
+		RFC:               "jlp010203ab4",
+		CLABE:             "002010077777777771",
+		DefaultCategoryID: &catID,
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if s.Name != "Jardinería Los Pinos" {
+		t.Errorf("name = %q, want trimmed name", s.Name)
+	}
+	if s.RFC != "JLP010203AB4" {
+		t.Errorf("rfc = %q, want upper-cased RFC", s.RFC)
+	}
+	if !s.IsActive {
+		t.Error("new supplier should be active")
+	}
+	if s.DefaultCategoryID == nil || *s.DefaultCategoryID != catID {
+		t.Errorf("defaultCategoryID = %v, want %d", s.DefaultCategoryID, catID)
+	}
+}
+
+func TestNew_OptionalFieldsMayBeEmpty(t *testing.T) {
+	if _, err := supplier.New(1, supplier.Details{Name: "Don Pepe"}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+}
+
+func TestNew_EmptyName(t *testing.T) {
+	_, err := supplier.New(1, supplier.Details{Name: "   "})
+	if err != supplier.ErrEmptyName {
+		t.Errorf("expected ErrEmptyName, got %v", err)
+	}
+}
+
+func TestNew_InvalidUserID(t *testing.T) {
+	_, err := supplier.New(0, supplier.Details{Name: "Don Pepe"})
+	if err != supplier.ErrInvalidUserID {
+		t.Errorf("expected ErrInvalidUserID, got %v", err)
+	}
+}
+
+func TestValidRFC(t *testing.T) {
+	cases := map[string]bool{
+		"ABC010203XY1":  true,  // persona moral
+		"ABCD010203XY1": true,  // persona física
+		"AB010203XY1":   false, // too short
+		"ABCD01023XY1":  false, // malformed date
+		"":              false,
+	}
+	for rfc, want := range cases {
+		if got := supplier.ValidRFC(rfc); got != want {
+			t.Errorf("ValidRFC(%q) = %v, want %v", rfc, got, want)
+		}
+	}
+}
+
+func TestValidCLABE(t *testing.T) {
+	cases := map[string]bool{
+		"002010077777777771":  true,
+		"002010077777777772":  false, // wrong control digit
+		"00201007777777777":   false, // 17 digits
+		"00201007777777777A":  false,
+		"0020100777777777711": false,
+	}
+	for clabe, want := range cases {
+		if got := supplier.ValidCLABE(clabe); got != want {
+			t.Errorf("ValidCLABE(%q) = %v, want %v", clabe, got, want)
+		}
+	}
+}
@@ -0,0 +1,24 @@
+package treasury
+
+import (
+	"errors"
+	"time"
+)
+
+type EventType string
+
+const (
+	EventTransferCreated EventType = "transfer.created"
+	EventTransferDeleted EventType = "transfer.deleted"
+)
+
+type Event struct {
+	Type       EventType
+	Transfer   Transfer
+	OccurredAt time.Time
+}
+
+// ErrNotPosted reports that the transfer was saved but a subscriber, such as
+// the ledger, failed to process its event. Rebuilding the ledger from its
+// source documents brings it back in line.
+var ErrNotPosted = errors.New("transfer saved but its ledger entry could not be updated")
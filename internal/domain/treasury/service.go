@@ -0,0 +1,159 @@
+package treasury
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Repository is the outbound port for financial account persistence.
+type Repository interface {
+	Save(ctx context.Context, a *Account) error
+	Update(ctx context.Context, a *Account) error
+	FindByID(ctx context.Context, id int64) (*Account, error)
+	FindAll(ctx context.Context) ([]Account, error)
+	// FindBalances returns every account with its movements up to and
+	// including asOf; Balance is left for the service to compute.
+	FindBalances(ctx context.Context, asOf time.Time) ([]AccountBalance, error)
+
+	SaveTransfer(ctx context.Context, t *Transfer) error
+	FindTransferByID(ctx context.Context, id int64) (*Transfer, error)
+	// FindTransfers lists transfers touching the account, or all when accountID is 0.
+	FindTransfers(ctx context.Context, accountID int64) ([]Transfer, error)
+	DeleteTransfer(ctx context.Context, id int64) error
+}
+
+// EventPublisher is the outbound port for transfer event dispatch.
+type EventPublisher interface {
+	Publish(ctx context.Context, event Event) error
+}
+
+// Service orchestrates financial account and transfer use cases.
+type Service struct {
+	repo   Repository
+	events EventPublisher
+}
+
+func NewService(repo Repository, events EventPublisher) *Service {
+	return &Service{repo: repo, events: events}
+}
+
+func (s *Service) CreateAccount(ctx context.Context, callerID int64, d Details) (*Account, error) {
+	a, err := New(callerID, d)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.Save(ctx, a); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+func (s *Service) GetAccount(ctx context.Context, id int64) (*Account, error) {
+	return s.repo.FindByID(ctx, id)
+}
+
+func (s *Service) ListAccounts(ctx context.Context) ([]Account, error) {
+	return s.repo.FindAll(ctx)
+}
+
+func (s *Service) UpdateAccount(ctx context.Context, id int64, d Details, isActive bool) (*Account, error) {
+	a, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := d.validate(); err != nil {
+		return nil, err
+	}
+	if a.IsDefault && !isActive {
+		return nil, ErrDefaultCannotDisable
+	}
+
+	a.apply(d)
+	a.IsActive = isActive
+	a.UpdatedAt = time.Now()
+
+	if err := s.repo.Update(ctx, a); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// GetBalances returns every account's current balance.
+func (s *Service) GetBalances(ctx context.Context) ([]AccountBalance, error) {
+	return s.balances(ctx, time.Now())
+}
+
+// CreateTransfer moves money between two active accounts. The source must
+// hold enough money on the transfer date.
+func (s *Service) CreateTransfer(ctx context.Context, callerID, fromAccountID, toAccountID int64, amount float64, date time.Time, description string) (*Transfer, error) {
+	t, err := NewTransfer(callerID, fromAccountID, toAccountID, amount, date, description)
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range []int64{fromAccountID, toAccountID} {
+		a, err := s.repo.FindByID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if !a.IsActive {
+			return nil, ErrAccountInactive
+		}
+	}
+
+	balances, err := s.balances(ctx, date)
+	if err != nil {
+		return nil, err
+	}
+	for _, b := range balances {
+		if b.ID == fromAccountID && roundCents(amount) > roundCents(b.Balance) {
+			return nil, ErrInsufficientFunds
+		}
+	}
+
+	if err := s.repo.SaveTransfer(ctx, t); err != nil {
+		return nil, err
+	}
+	if err := s.events.Publish(ctx, Event{
+		Type:       EventTransferCreated,
+		Transfer:   *t,
+		OccurredAt: time.Now(),
+	}); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrNotPosted, err)
+	}
+	return t, nil
+}
+
+func (s *Service) ListTransfers(ctx context.Context, accountID int64) ([]Transfer, error) {
+	return s.repo.FindTransfers(ctx, accountID)
+}
+
+func (s *Service) DeleteTransfer(ctx context.Context, id int64) error {
+	t, err := s.repo.FindTransferByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.DeleteTransfer(ctx, id); err != nil {
+		return err
+	}
+	if err := s.events.Publish(ctx, Event{
+		Type:       EventTransferDeleted,
+		Transfer:   *t,
+		OccurredAt: time.Now(),
+	}); err != nil {
+		return fmt.Errorf("%w: %w", ErrNotPosted, err)
+	}
+	return nil
+}
+
+func (s *Service) balances(ctx context.Context, asOf time.Time) ([]AccountBalance, error) {
+	balances, err := s.repo.FindBalances(ctx, asOf)
+	if err != nil {
+		return nil, err
+	}
+	for i := range balances {
+		b := &balances[i]
+		b.Balance = roundCents(b.OpeningBalance + b.Income + b.TransfersIn - b.Expenses - b.TransfersOut)
+	}
+	return balances, nil
+}
@@ -0,0 +1,239 @@
+package treasury_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ivsanmendez/ControlDeContabilidad/internal/domain/treasury"
+)
+
+// --- Fakes ---
+
+type fakeRepo struct {
+	accounts  map[int64]*treasury.Account
+	transfers map[int64]*treasury.Transfer
+	// income and expenses are the contribution and expense totals per account.
+	income   map[int64]float64
+	expenses map[int64]float64
+	nextID   int64
+}
+
+func newFakeRepo() *fakeRepo {
+	return &fakeRepo{
+		accounts:  make(map[int64]*treasury.Account),
+		transfers: make(map[int64]*treasury.Transfer),
+		income:    make(map[int64]float64),
+		expenses:  make(map[int64]float64),
+		nextID:    1,
+	}
+}
+
+func (r *fakeRepo) Save(_ context.Context, a *treasury.Account) error {
+	a.ID = r.nextID
+	r.nextID++
+	cp := *a
+	r.accounts[a.ID] = &cp
+	return nil
+}
+
+func (r *fakeRepo) Update(_ context.Context, a *treasury.Account) error {
+	cp := *a
+	r.accounts[a.ID] = &cp
+	return nil
+}
+
+func (r *fakeRepo) FindByID(_ context.Context, id int64) (*treasury.Account, error) {
+	a, ok := r.accounts[id]
+	if !ok {
+		return nil, treasury.ErrNotFound
+	}
+	cp := *a
+	return &cp, nil
+}
+
+func (r *fakeRepo) FindAll(_ context.Context) ([]treasury.Account, error) {
+	var result []treasury.Account
+	for _, a := range r.accounts {
+		result = append(result, *a)
+	}
+	return result, nil
+}
+
+func (r *fakeRepo) FindBalances(_ context.Context, asOf time.Time) ([]treasury.AccountBalance, error) {
+	var result []treasury.AccountBalance
+	for _, a := range r.accounts {
+		b := treasury.AccountBalance{Account: *a, Income: r.income[a.ID], Expenses: r.expenses[a.ID]}
+		for _, t := range r.transfers {
+			if t.Date.After(asOf) {
+				continue
+			}
+			if t.ToAccountID == a.ID {
+				b.TransfersIn += t.Amount
+			}
+			if t.FromAccountID == a.ID {
+				b.TransfersOut += t.Amount
+			}
+		}
+		result = append(result, b)
+	}
+	return result, nil
+}
+
+func (r *fakeRepo) SaveTransfer(_ context.Context, t *treasury.Transfer) error {
+	t.ID = r.nextID
+	r.nextID++
+	cp := *t
+	r.transfers[t.ID] = &cp
+	return nil
+}
+
+func (r *fakeRepo) FindTransferByID(_ context.Context, id int64) (*treasury.Transfer, error) {
+	t, ok := r.transfers[id]
+	if !ok {
+		return nil, treasury.ErrTransferNotFound
+	}
+	cp := *t
+	return &cp, nil
+}
+
+func (r *fakeRepo) FindTransfers(_ context.Context, accountID int64) ([]treasury.Transfer, error) {
+	var result []treasury.Transfer
+	for _, t := range r.transfers {
+		if accountID == 0 || t.FromAccountID == accountID || t.ToAccountID == accountID {
+			result = append(result, *t)
+		}
+	}
+	return result, nil
+}
+
+func (r *fakeRepo) DeleteTransfer(_ context.Context, id int64) error {
+	delete(r.transfers, id)
+	return nil
+}
+
+type fakePublisher struct {
+	events []treasury.Event
+}
+
+func (p *fakePublisher) Publish(_ context.Context, e treasury.Event) error {
+	p.events = append(p.events, e)
+	return nil
+}
+
+var (
+	ctx      = context.Background()
+	testDate = time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)
+)
+
+const adminID int64 = 1
+
+func setup(t *testing.T) (*treasury.Service, *fakeRepo, *fakePublisher, *treasury.Account, *treasury.Account) {
+	t.Helper()
+	repo := newFakeRepo()
+	pub := &fakePublisher{}
+	svc := treasury.NewService(repo, pub)
+
+	cash, err := svc.CreateAccount(ctx, adminID, treasury.Details{Name: "Caja", Kind: treasury.KindCash, OpeningBalance: 100})
+	if err != nil {
+		t.Fatalf("create cash account: %v", err)
+	}
+	bank, err := svc.CreateAccount(ctx, adminID, treasury.Details{Name: "Cheques", Kind: treasury.KindChecking, BankName: "Banorte"})
+	if err != nil {
+		t.Fatalf("create bank account: %v", err)
+	}
+	return svc, repo, pub, cash, bank
+}
+
+// --- Tests ---
+
+func TestCreateAccount_Validation(t *testing.T) {
+	svc, _, _, _, _ := setup(t)
+
+	tests := []struct {
+		name string
+		d    treasury.Details
+		want error
+	}{
+		{"empty name", treasury.Details{Kind: treasury.KindCash}, treasury.ErrEmptyName},
+		{"bad kind", treasury.Details{Name: "X", Kind: "crypto"}, treasury.ErrInvalidKind},
+		{"negative opening", treasury.Details{Name: "X", Kind: treasury.KindSavings, OpeningBalance: -1}, treasury.ErrInvalidOpening},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := svc.CreateAccount(ctx, adminID, tt.d); !errors.Is(err, tt.want) {
+				t.Fatalf("expected %v, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestCreateTransfer_MovesBalanceAndPublishes(t *testing.T) {
+	svc, repo, pub, cash, bank := setup(t)
+	repo.income[cash.ID] = 900
+	repo.expenses[cash.ID] = 200
+
+	tr, err := svc.CreateTransfer(ctx, adminID, cash.ID, bank.ID, 750, testDate, "Depósito de cuotas")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(pub.events) != 1 || pub.events[0].Type != treasury.EventTransferCreated || pub.events[0].Transfer.ID != tr.ID {
+		t.Fatalf("expected transfer.created event, got %+v", pub.events)
+	}
+
+	balances, err := svc.GetBalances(ctx)
+	if err != nil {
+		t.Fatalf("balances: %v", err)
+	}
+	got := map[int64]float64{}
+	for _, b := range balances {
+		got[b.ID] = b.Balance
+	}
+	// Cash: 100 opening + 900 income - 200 expenses - 750 transferred out.
+	if got[cash.ID] != 50 || got[bank.ID] != 750 {
+		t.Fatalf("unexpected balances: %+v", got)
+	}
+}
+
+func TestCreateTransfer_Rules(t *testing.T) {
+	svc, _, _, cash, bank := setup(t)
+
+	if _, err := svc.CreateTransfer(ctx, adminID, cash.ID, cash.ID, 10, testDate, ""); !errors.Is(err, treasury.ErrSameAccount) {
+		t.Fatalf("expected ErrSameAccount, got %v", err)
+	}
+	if _, err := svc.CreateTransfer(ctx, adminID, cash.ID, bank.ID, 100.01, testDate, ""); !errors.Is(err, treasury.ErrInsufficientFunds) {
+		t.Fatalf("expected ErrInsufficientFunds, got %v", err)
+	}
+
+	if _, err := svc.UpdateAccount(ctx, bank.ID, treasury.Details{Name: bank.Name, Kind: bank.Kind}, false); err != nil {
+		t.Fatalf("deactivate: %v", err)
+	}
+	if _, err := svc.CreateTransfer(ctx, adminID, cash.ID, bank.ID, 10, testDate, ""); !errors.Is(err, treasury.ErrAccountInactive) {
+		t.Fatalf("expected ErrAccountInactive, got %v", err)
+	}
+}
+
+func TestUpdateAccount_DefaultStaysActive(t *testing.T) {
+	svc, repo, _, cash, _ := setup(t)
+	repo.accounts[cash.ID].IsDefault = true
+
+	if _, err := svc.UpdateAccount(ctx, cash.ID, treasury.Details{Name: "Caja", Kind: treasury.KindCash}, false); !errors.Is(err, treasury.ErrDefaultCannotDisable) {
+		t.Fatalf("expected ErrDefaultCannotDisable, got %v", err)
+	}
+}
+
+func TestDeleteTransfer_PublishesDeletion(t *testing.T) {
+	svc, _, pub, cash, bank := setup(t)
+
+	tr, _ := svc.CreateTransfer(ctx, adminID, cash.ID, bank.ID, 40, testDate, "")
+	if err := svc.DeleteTransfer(ctx, tr.ID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if last := pub.events[len(pub.events)-1]; last.Type != treasury.EventTransferDeleted || last.Transfer.Amount != 40 {
+		t.Fatalf("expected transfer.deleted event, got %+v", last)
+	}
+	if err := svc.DeleteTransfer(ctx, tr.ID); !errors.Is(err, treasury.ErrTransferNotFound) {
+		t.Fatalf("expected ErrTransferNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,163 @@
+package treasury
+
+import (
+	"errors"
+	"math"
+	"time"
+)
+
+var (
+	ErrNotFound             = errors.New("financial account not found")
+	ErrTransferNotFound     = errors.New("transfer not found")
+	ErrDuplicate            = errors.New("financial account name already exists")
+	ErrEmptyName            = errors.New("financial account name must not be empty")
+	ErrInvalidKind          = errors.New("financial account kind must be cash, checking, savings, or investment")
+	ErrInvalidOpening       = errors.New("opening balance cannot be negative")
+	ErrInvalidUserID        = errors.New("user ID must be positive")
+	ErrInvalidAmount        = errors.New("amount must be positive")
+	ErrSameAccount          = errors.New("transfer source and destination must differ")
+	ErrAccountInactive      = errors.New("financial account is inactive")
+	ErrInsufficientFunds    = errors.New("transfer exceeds the source account balance")
+	ErrDefaultCannotDisable = errors.New("the default financial account cannot be deactivated")
+)
+
+type Kind string
+
+const (
+	KindCash       Kind = "cash"
+	KindChecking   Kind = "checking"
+	KindSavings    Kind = "savings"
+	KindInvestment Kind = "investment"
+)
+
+func (k Kind) Valid() bool {
+	switch k {
+	case KindCash, KindChecking, KindSavings, KindInvestment:
+		return true
+	}
+	return false
+}
+
+// Account is a place where the community's money is held: the treasurer's
+// cash box, a checking account, an investment account. Every contribution
+// and expense is assigned to one.
+type Account struct {
+	ID             int64
+	Name           string
+	Kind           Kind
+	BankName       string
+	AccountNumber  string
+	OpeningBalance float64
+	// LedgerAccountID is the ledger account generated postings use for money
+	// in this account. Nil falls back to the ledger's default cash account.
+	LedgerAccountID *int64
+	// IsDefault marks the account used when a contribution or expense does
+	// not name one. Exactly one account is the default.
+	IsDefault bool
+	IsActive  bool
+	UserID    int64
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Details holds the user-editable fields of an Account.
+type Details struct {
+	Name            string
+	Kind            Kind
+	BankName        string
+	AccountNumber   string
+	OpeningBalance  float64
+	LedgerAccountID *int64
+}
+
+// AccountBalance is an account with its movements and current balance.
+type AccountBalance struct {
+	Account
+	Income       float64
+	Expenses     float64
+	TransfersIn  float64
+	TransfersOut float64
+	// Balance is OpeningBalance + Income + TransfersIn - Expenses - TransfersOut.
+	Balance float64
+}
+
+// Transfer moves money between two of the community's own accounts, e.g.
+// depositing collected cash into the bank. It is neither income nor expense.
+type Transfer struct {
+	ID            int64
+	FromAccountID int64
+	ToAccountID   int64
+	Amount        float64
+	Date          time.Time
+	Description   string
+	UserID        int64
+	CreatedAt     time.Time
+}
+
+// New creates an Account enforcing domain invariants.
+func New(userID int64, d Details) (*Account, error) {
+	if userID <= 0 {
+		return nil, ErrInvalidUserID
+	}
+	if err := d.validate(); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	a := &Account{
+		IsActive:  true,
+		UserID:    userID,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	a.apply(d)
+	return a, nil
+}
+
+// NewTransfer creates a Transfer enforcing domain invariants.
+func NewTransfer(userID, fromAccountID, toAccountID int64, amount float64, date time.Time, description string) (*Transfer, error) {
+	if userID <= 0 {
+		return nil, ErrInvalidUserID
+	}
+	if fromAccountID == toAccountID {
+		return nil, ErrSameAccount
+	}
+	if amount <= 0 {
+		return nil, ErrInvalidAmount
+	}
+	return &Transfer{
+		FromAccountID: fromAccountID,
+		ToAccountID:   toAccountID,
+		Amount:        amount,
+		Date:          date,
+		Description:   description,
+		UserID:        userID,
+		CreatedAt:     time.Now(),
+	}, nil
+}
+
+func (d Details) validate() error {
+	if d.Name == "" {
+		return ErrEmptyName
+	}
+	if !d.Kind.Valid() {
+		return ErrInvalidKind
+	}
+	if d.OpeningBalance < 0 {
+		return ErrInvalidOpening
+	}
+	return nil
+}
+
+func (a *Account) apply(d Details) {
+	a.Name = d.Name
+	a.Kind = d.Kind
+	a.BankName = d.BankName
+	a.AccountNumber = d.AccountNumber
+	a.OpeningBalance = d.OpeningBalance
+	a.LedgerAccountID = d.LedgerAccountID
+}
+
+func roundCents(v float64) float64 {
+	return math.Round(v*100) / 100
+}
@@ -5,11 +5,17 @@ import "time"
 type AuditAction string
 
 const (
-	AuditLoginSuccess AuditAction = "login_success"
-	AuditLoginFailed  AuditAction = "login_failed"
-	AuditLogout       AuditAction = "logout"
-	AuditTokenRefresh AuditAction = "token_refresh"
-	AuditRegister     AuditAction = "register"
+	AuditLoginSuccess  AuditAction = "login_success"
+	AuditLoginFailed   AuditAction = "login_failed"
+	AuditLogout        AuditAction = "logout"
+	AuditTokenRefresh  AuditAction = "token_refresh"
+	AuditRegister      AuditAction = "register"
+	AuditPeriodClose   AuditAction = "period_close"
+	AuditPeriodReopen  AuditAction = "period_reopen"
+	AuditReceiptCancel AuditAction = "receipt_cancel"
+
+	AuditCertificateUpload     AuditAction = "certificate_upload"
+	AuditCertificateDeactivate AuditAction = "certificate_deactivate"
 )
 
 type AuditEntry struct {
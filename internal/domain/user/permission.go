@@ -27,6 +27,7 @@ const (
 	PermCategoryDelete Permission = "category:delete"
 
 	PermReceiptVerify Permission = "receipt:verify"
+	PermReceiptCancel Permission = "receipt:cancel"
 
 	PermReportRead Permission = "report:read"
 
@@ -34,6 +35,33 @@ const (
 	PermExpenseCategoryRead   Permission = "expense_category:read"
 	PermExpenseCategoryUpdate Permission = "expense_category:update"
 	PermExpenseCategoryDelete Permission = "expense_category:delete"
+
+	PermSupplierCreate Permission = "supplier:create"
+	PermSupplierRead   Permission = "supplier:read"
+	PermSupplierUpdate Permission = "supplier:update"
+	PermSupplierDelete Permission = "supplier:delete"
+
+	PermPettyCashRead    Permission = "petty_cash:read"
+	PermPettyCashOperate Permission = "petty_cash:operate"
+	PermPettyCashManage  Permission = "petty_cash:manage"
+
+	PermLedgerRead  Permission = "ledger:read"
+	PermLedgerWrite Permission = "ledger:write"
+
+	PermTreasuryRead     Permission = "treasury:read"
+	PermTreasuryTransfer Permission = "treasury:transfer"
+	PermTreasuryManage   Permission = "treasury:manage"
+
+	PermPeriodRead   Permission = "period:read"
+	PermPeriodManage Permission = "period:manage"
+
+	PermBudgetRead   Permission = "budget:read"
+	PermBudgetManage Permission = "budget:manage"
+
+	PermAnnualReportRead  Permission = "annual_report:read"
+	PermAnnualReportIssue Permission = "annual_report:issue"
+
+	PermCertificateManage Permission = "certificate:manage"
 )
 
 var rolePermissions = map[Role][]Permission{
@@ -60,6 +88,18 @@ var rolePermissions = map[Role][]Permission{
 		PermExpenseCategoryRead,
 		PermExpenseCategoryUpdate,
 		PermExpenseCategoryDelete,
+		PermSupplierCreate,
+		PermSupplierRead,
+		PermSupplierUpdate,
+		PermSupplierDelete,
+		PermPettyCashRead,
+		PermPettyCashOperate,
+		PermLedgerRead,
+		PermTreasuryRead,
+		PermTreasuryTransfer,
+		PermPeriodRead,
+		PermBudgetRead,
+		PermAnnualReportRead,
 	},
 	RoleAdmin: {
 		PermExpenseCreate,
@@ -82,11 +122,31 @@ var rolePermissions = map[Role][]Permission{
 		PermCategoryUpdate,
 		PermCategoryDelete,
 		PermReceiptVerify,
+		PermReceiptCancel,
 		PermReportRead,
 		PermExpenseCategoryCreate,
 		PermExpenseCategoryRead,
 		PermExpenseCategoryUpdate,
 		PermExpenseCategoryDelete,
+		PermSupplierCreate,
+		PermSupplierRead,
+		PermSupplierUpdate,
+		PermSupplierDelete,
+		PermPettyCashRead,
+		PermPettyCashOperate,
+		PermPettyCashManage,
+		PermLedgerRead,
+		PermLedgerWrite,
+		PermTreasuryRead,
+		PermTreasuryTransfer,
+		PermTreasuryManage,
+		PermPeriodRead,
+		PermPeriodManage,
+		PermBudgetRead,
+		PermBudgetManage,
+		PermAnnualReportRead,
+		PermAnnualReportIssue,
+		PermCertificateManage,
 	},
 }
 
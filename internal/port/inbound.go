@@ -4,23 +4,31 @@ import (
 	"context"
 	"time"
 
+	"github.com/ivsanmendez/ControlDeContabilidad/internal/domain/annualreport"
+	"github.com/ivsanmendez/ControlDeContabilidad/internal/domain/budget"
 	"github.com/ivsanmendez/ControlDeContabilidad/internal/domain/category"
 	"github.com/ivsanmendez/ControlDeContabilidad/internal/domain/contribution"
 	"github.com/ivsanmendez/ControlDeContabilidad/internal/domain/contributor"
 	"github.com/ivsanmendez/ControlDeContabilidad/internal/domain/expense"
 	ec "github.com/ivsanmendez/ControlDeContabilidad/internal/domain/expense_category"
+	"github.com/ivsanmendez/ControlDeContabilidad/internal/domain/ledger"
+	"github.com/ivsanmendez/ControlDeContabilidad/internal/domain/period"
+	"github.com/ivsanmendez/ControlDeContabilidad/internal/domain/pettycash"
 	"github.com/ivsanmendez/ControlDeContabilidad/internal/domain/receipt"
 	"github.com/ivsanmendez/ControlDeContabilidad/internal/domain/report"
+	"github.com/ivsanmendez/ControlDeContabilidad/internal/domain/signingcert"
+	"github.com/ivsanmendez/ControlDeContabilidad/internal/domain/supplier"
+	"github.com/ivsanmendez/ControlDeContabilidad/internal/domain/treasury"
 	"github.com/ivsanmendez/ControlDeContabilidad/internal/domain/user"
 )
 
 // ExpenseService is the driving port — the contract that inbound adapters
 // (HTTP handlers, AI agents) depend on.
 type ExpenseService interface {
-	CreateExpense(ctx context.Context, callerID int64, description string, amount float64, categoryID int64, date time.Time) (*expense.Expense, error)
+	CreateExpense(ctx context.Context, callerID int64, description string, amount float64, categoryID int64, supplierID *int64, financialAccountID int64, lines []expense.Line, date time.Time) (*expense.Expense, error)
 	GetExpense(ctx context.Context, callerID int64, callerRole user.Role, id int64) (*expense.Expense, error)
 	ListExpenses(ctx context.Context, callerID int64, callerRole user.Role) ([]expense.ExpenseDetail, error)
-	UpdateExpense(ctx context.Context, callerID int64, callerRole user.Role, id int64, description string, amount float64, categoryID int64, date time.Time) (*expense.Expense, error)
+	UpdateExpense(ctx context.Context, callerID int64, callerRole user.Role, id int64, description string, amount float64, categoryID int64, supplierID *int64, financialAccountID int64, lines []expense.Line, date time.Time) (*expense.Expense, error)
 	DeleteExpense(ctx context.Context, callerID int64, callerRole user.Role, id int64) error
 }
 
@@ -35,10 +43,10 @@ type ContributorService interface {
 
 // ContributionService is the driving port for contribution use cases.
 type ContributionService interface {
-	CreateContribution(ctx context.Context, callerID int64, contributorID int64, categoryID int64, amount float64, month, year int, paymentDate time.Time, paymentMethod contribution.PaymentMethod) (*contribution.Contribution, error)
+	CreateContribution(ctx context.Context, callerID int64, contributorID int64, categoryID int64, amount float64, month, year int, paymentDate time.Time, paymentMethod contribution.PaymentMethod, financialAccountID int64) (*contribution.Contribution, error)
 	GetContribution(ctx context.Context, id int64) (*contribution.ContributionDetail, error)
 	ListContributions(ctx context.Context, contributorID int64, year int) ([]contribution.ContributionDetail, error)
-	UpdateContribution(ctx context.Context, id int64, contributorID int64, categoryID int64, amount float64, month, year int, paymentDate time.Time, paymentMethod contribution.PaymentMethod) (*contribution.Contribution, error)
+	UpdateContribution(ctx context.Context, id int64, contributorID int64, categoryID int64, amount float64, month, year int, paymentDate time.Time, paymentMethod contribution.PaymentMethod, financialAccountID int64) (*contribution.Contribution, error)
 	DeleteContribution(ctx context.Context, id int64) error
 }
 
@@ -57,11 +65,21 @@ type ReceiptFolioService interface {
 	GenerateNewFolio(ctx context.Context, year int) (folio string, seq int, suffix string, err error)
 	SaveFolio(ctx context.Context, rf *receipt.ReceiptFolio) error
 	VerifyFolio(ctx context.Context, folio string) (*receipt.ReceiptFolio, error)
+	VerifySignature(ctx context.Context, folio string) (*receipt.ReceiptFolio, *receipt.Verdict, error)
+	Cancel(ctx context.Context, signer receipt.Signer, callerID int64, folio, reason, replacementFolio, password string, info user.AuditInfo) (*receipt.Cancellation, error)
+	ListFolios(ctx context.Context, f receipt.Filter) (*receipt.Page, error)
 }
 
 // ReportService is the driving port for report use cases.
 type ReportService interface {
-	GetMonthlyBalance(ctx context.Context, year int) (*report.MonthlyBalanceReport, error)
+	GetMonthlyBalance(ctx context.Context, year int, basis report.Basis) (*report.MonthlyBalanceReport, error)
+	GetSupplierSpending(ctx context.Context, year int) (*report.SupplierSpendingReport, error)
+	GetRangeBalance(ctx context.Context, from, to time.Time, groupBy report.Granularity, basis report.Basis) (*report.RangeReport, error)
+	GetYearComparison(ctx context.Context, year, priorYears int, basis report.Basis) (*report.YearComparisonReport, error)
+	GetCollectionRate(ctx context.Context, year int, categoryID int64) (*report.CollectionRateReport, error)
+	GetForecast(ctx context.Context, asOf time.Time, sc report.ForecastScenario) (*report.ForecastReport, error)
+	GetBudgetVariance(ctx context.Context, year int, basis report.Basis) (*report.BudgetVarianceReport, error)
+	GetIssuedReceipts(ctx context.Context, year int) ([]report.IssuedReceipt, error)
 }
 
 // ExpenseCategoryService is the driving port for expense category use cases.
@@ -73,3 +91,86 @@ type ExpenseCategoryService interface {
 	UpdateCategory(ctx context.Context, id int64, name, description string, isActive bool) (*ec.ExpenseCategory, error)
 	DeleteCategory(ctx context.Context, id int64) error
 }
+
+// SupplierService is the driving port for supplier (proveedor) catalog use cases.
+type SupplierService interface {
+	CreateSupplier(ctx context.Context, callerID int64, d supplier.Details) (*supplier.Supplier, error)
+	GetSupplier(ctx context.Context, id int64) (*supplier.Supplier, error)
+	ListSuppliers(ctx context.Context) ([]supplier.Supplier, error)
+	ListActiveSuppliers(ctx context.Context) ([]supplier.Supplier, error)
+	UpdateSupplier(ctx context.Context, id int64, d supplier.Details, isActive bool) (*supplier.Supplier, error)
+	DeleteSupplier(ctx context.Context, id int64) error
+}
+
+// PettyCashService is the driving port for petty cash (caja chica) use cases.
+type PettyCashService interface {
+	CreateFund(ctx context.Context, callerID int64, name string, custodianUserID int64, floatAmount float64, financialAccountID int64) (*pettycash.Fund, error)
+	ListFunds(ctx context.Context) ([]pettycash.Fund, error)
+	GetFundStatus(ctx context.Context, id int64) (*pettycash.FundStatus, error)
+	UpdateFund(ctx context.Context, id int64, name string, custodianUserID int64, floatAmount float64, financialAccountID int64, isActive bool) (*pettycash.Fund, error)
+	ChargeExpense(ctx context.Context, callerID int64, callerRole user.Role, fundID int64, description string, amount float64, categoryID int64, supplierID *int64, date time.Time) (*pettycash.Charge, error)
+	ListCharges(ctx context.Context, fundID int64) ([]pettycash.Charge, error)
+	RequestReplenishment(ctx context.Context, callerID int64, callerRole user.Role, fundID int64) (*pettycash.Replenishment, error)
+	CompleteReplenishment(ctx context.Context, callerID int64, id int64) (*pettycash.Replenishment, error)
+	ListReplenishments(ctx context.Context, fundID int64) ([]pettycash.Replenishment, error)
+	Reconcile(ctx context.Context, callerID int64, callerRole user.Role, fundID int64, counted float64, notes string) (*pettycash.Reconciliation, error)
+	ListReconciliations(ctx context.Context, fundID int64) ([]pettycash.Reconciliation, error)
+}
+
+// LedgerService is the driving port for general ledger use cases.
+type LedgerService interface {
+	CreateAccount(ctx context.Context, code, name string, accountType ledger.AccountType, nature ledger.Nature, parentID *int64, satCode string) (*ledger.Account, error)
+	ListAccounts(ctx context.Context) ([]ledger.Account, error)
+	UpdateAccount(ctx context.Context, id int64, name string, parentID *int64, satCode string, isActive bool) (*ledger.Account, error)
+	ListCategoryAccounts(ctx context.Context) ([]ledger.CategoryAccount, error)
+	SetCategoryAccount(ctx context.Context, kind ledger.CategoryKind, categoryID int64, accountID *int64) error
+	CreateManualEntry(ctx context.Context, callerID int64, date time.Time, description string, postings []ledger.Posting) (*ledger.Entry, error)
+	GetEntry(ctx context.Context, id int64) (*ledger.EntryDetail, error)
+	ListEntries(ctx context.Context, from, to time.Time) ([]ledger.EntryDetail, error)
+	Rebuild(ctx context.Context) (*ledger.RebuildResult, error)
+	GetTrialBalance(ctx context.Context, asOf time.Time) (*ledger.TrialBalance, error)
+	GetBalanceSheet(ctx context.Context, asOf time.Time) (*ledger.BalanceSheet, error)
+	GetIncomeStatement(ctx context.Context, from, to time.Time) (*ledger.IncomeStatement, error)
+	GetChartBalance(ctx context.Context, asOf time.Time) (*ledger.ChartBalance, error)
+}
+
+// TreasuryService is the driving port for financial account and transfer use cases.
+type TreasuryService interface {
+	CreateAccount(ctx context.Context, callerID int64, d treasury.Details) (*treasury.Account, error)
+	GetAccount(ctx context.Context, id int64) (*treasury.Account, error)
+	ListAccounts(ctx context.Context) ([]treasury.Account, error)
+	UpdateAccount(ctx context.Context, id int64, d treasury.Details, isActive bool) (*treasury.Account, error)
+	GetBalances(ctx context.Context) ([]treasury.AccountBalance, error)
+	CreateTransfer(ctx context.Context, callerID, fromAccountID, toAccountID int64, amount float64, date time.Time, description string) (*treasury.Transfer, error)
+	ListTransfers(ctx context.Context, accountID int64) ([]treasury.Transfer, error)
+	DeleteTransfer(ctx context.Context, id int64) error
+}
+
+// PeriodService is the driving port for closing and reopening accounting periods.
+type PeriodService interface {
+	Close(ctx context.Context, callerID int64, year, month int, info user.AuditInfo) (*period.Period, error)
+	Reopen(ctx context.Context, callerID int64, year, month int, reason string, info user.AuditInfo) (*period.Period, error)
+	ListPeriods(ctx context.Context, year int) ([]period.Period, error)
+}
+
+// BudgetService is the driving port for annual budget use cases.
+type BudgetService interface {
+	SetBudget(ctx context.Context, callerID int64, year int, inputs []budget.LineInput) ([]budget.Line, error)
+	GetBudget(ctx context.Context, year int) ([]budget.Line, error)
+}
+
+// AnnualReportService is the driving port for signed annual treasury reports.
+type AnnualReportService interface {
+	Issue(ctx context.Context, signer annualreport.Signer, callerID int64, year int, signerName, password string) (*annualreport.Document, error)
+	GetReport(ctx context.Context, folio string) (*annualreport.Document, error)
+	VerifyReport(ctx context.Context, folio string) (*annualreport.Document, *receipt.Verdict, error)
+	ListReports(ctx context.Context, year int) ([]annualreport.Document, error)
+}
+
+// SigningCertificateService is the driving port for per-user signing certificates.
+type SigningCertificateService interface {
+	Upload(ctx context.Context, callerID, userID int64, label string, cert, key []byte, info user.AuditInfo) (*signingcert.Certificate, error)
+	ListCertificates(ctx context.Context, userID int64) ([]signingcert.Certificate, error)
+	Deactivate(ctx context.Context, callerID, id int64, info user.AuditInfo) (*signingcert.Certificate, error)
+	SignerFor(ctx context.Context, userID, certID int64) (signingcert.Signer, error)
+}
@@ -1,15 +1,21 @@
 package port
 
 import (
-	"context"
-
+	"github.com/ivsanmendez/ControlDeContabilidad/internal/domain/annualreport"
+	"github.com/ivsanmendez/ControlDeContabilidad/internal/domain/budget"
 	"github.com/ivsanmendez/ControlDeContabilidad/internal/domain/category"
 	"github.com/ivsanmendez/ControlDeContabilidad/internal/domain/contribution"
 	"github.com/ivsanmendez/ControlDeContabilidad/internal/domain/contributor"
 	"github.com/ivsanmendez/ControlDeContabilidad/internal/domain/expense"
 	ec "github.com/ivsanmendez/ControlDeContabilidad/internal/domain/expense_category"
+	"github.com/ivsanmendez/ControlDeContabilidad/internal/domain/ledger"
+	"github.com/ivsanmendez/ControlDeContabilidad/internal/domain/period"
+	"github.com/ivsanmendez/ControlDeContabilidad/internal/domain/pettycash"
 	"github.com/ivsanmendez/ControlDeContabilidad/internal/domain/receipt"
 	"github.com/ivsanmendez/ControlDeContabilidad/internal/domain/report"
+	"github.com/ivsanmendez/ControlDeContabilidad/internal/domain/signingcert"
+	"github.com/ivsanmendez/ControlDeContabilidad/internal/domain/supplier"
+	"github.com/ivsanmendez/ControlDeContabilidad/internal/domain/treasury"
 	"github.com/ivsanmendez/ControlDeContabilidad/internal/domain/user"
 )
 
@@ -19,11 +25,6 @@ type ExpenseRepository = expense.Repository
 // EventPublisher is the driven port for publishing domain events.
 type EventPublisher = expense.EventPublisher
 
-// EventSubscriber reacts to domain events.
-type EventSubscriber interface {
-	Handle(ctx context.Context, event expense.Event) error
-}
-
 // UserRepository is the driven port for user persistence.
 type UserRepository = user.Repository
 
@@ -54,10 +55,36 @@ type ReportRepository = report.Repository
 // ExpenseCategoryRepository is the driven port for expense category persistence.
 type ExpenseCategoryRepository = ec.Repository
 
+// SupplierRepository is the driven port for supplier persistence.
+type SupplierRepository = supplier.Repository
+
+// PettyCashRepository is the driven port for petty cash persistence.
+type PettyCashRepository = pettycash.Repository
+
+// LedgerRepository is the driven port for ledger persistence.
+type LedgerRepository = ledger.Repository
+
+// TreasuryRepository is the driven port for financial account persistence.
+type TreasuryRepository = treasury.Repository
+
+// PeriodRepository is the driven port for closed period persistence.
+type PeriodRepository = period.Repository
+
+// BudgetRepository is the driven port for budget persistence.
+type BudgetRepository = budget.Repository
+
+// AnnualReportRepository is the driven port for annual report persistence.
+type AnnualReportRepository = annualreport.Repository
+
+// SigningCertificateRepository is the driven port for signing certificate persistence.
+type SigningCertificateRepository = signingcert.Repository
+
 // ReceiptSigner is the driven port for digitally signing receipt data.
 // The password is required per-call to decrypt the private key (SAT format).
 type ReceiptSigner interface {
 	Sign(data []byte, password string) ([]byte, error)
+	// Unlock decrypts the key once for signing a batch of receipts.
+	Unlock(password string) (func(data []byte) ([]byte, error), error)
 	Certificate() []byte
 	Available() bool
 }